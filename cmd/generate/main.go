@@ -25,6 +25,12 @@ func run() error {
 			return err
 		}
 		fmt.Println(string(data))
+	case "openapi":
+		data, err := generate.OpenAPI()
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
 	case "routes":
 		data, err := generate.Routes()
 		if err != nil {
@@ -45,6 +51,6 @@ func run() error {
 }
 
 func usage() {
-	fmt.Fprintf(os.Stderr, "usage: generate <controllers|routes>\n")
+	fmt.Fprintf(os.Stderr, "usage: generate <controllers|openapi|routes|sdk>\n")
 	os.Exit(1)
 }
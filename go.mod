@@ -30,6 +30,7 @@ require (
 	github.com/elastic/go-elasticsearch/v6 v6.8.2
 	github.com/elazarl/goproxy v0.0.0-20170405201442-c4fc26588b6e // indirect
 	github.com/fsouza/go-dockerclient v1.4.2
+	github.com/ghodss/yaml v0.0.0-20150909031657-73d445a93680
 	github.com/go-redis/redis v6.15.2+incompatible
 	github.com/gobuffalo/packr v1.30.1
 	github.com/gobwas/glob v0.2.3
@@ -52,7 +53,7 @@ require (
 	golang.org/x/crypto v0.0.0-20191117063200-497ca9f6d64f
 	golang.org/x/net v0.0.0-20191101175033-0deb6923b6d9 // indirect
 	golang.org/x/sys v0.0.0-20191104094858-e8c54fb511f6 // indirect
-	golang.org/x/time v0.0.0-20190308202827-9d24e82272b4 // indirect
+	golang.org/x/time v0.0.0-20190308202827-9d24e82272b4
 	google.golang.org/api v0.9.0
 	gopkg.in/inf.v0 v0.9.0 // indirect
 	gopkg.in/yaml.v2 v2.2.4
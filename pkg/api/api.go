@@ -12,6 +12,8 @@ type Server struct {
 	*stdapi.Server
 	Password string
 	Provider structs.Provider
+
+	limiter *limiter
 }
 
 func New() (*Server, error) {
@@ -35,6 +37,7 @@ func NewWithProvider(p structs.Provider) *Server {
 	s := &Server{
 		Provider: p,
 		Server:   stdapi.New("api", "api"),
+		limiter:  newLimiter(),
 	}
 
 	// s.Router.HandleFunc("/debug/pprof/", pprof.Index)
@@ -43,10 +46,15 @@ func NewWithProvider(p structs.Provider) *Server {
 	// s.Router.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
 	// s.Router.HandleFunc("/debug/pprof/trace", pprof.Trace)
 
+	s.Route("GET", "/openapi.json", s.openapi)
+
 	s.Subrouter("/", func(auth *stdapi.Router) {
 		auth.Route("GET", "/auth", func(c *stdapi.Context) error { return c.RenderOK() })
 
 		auth.Use(s.authenticate)
+		auth.Use(s.audit)
+		auth.Use(s.authorize)
+		auth.Use(s.limit)
 
 		s.setupRoutes(*auth)
 	})
@@ -54,11 +62,54 @@ func NewWithProvider(p structs.Provider) *Server {
 	return s
 }
 
+// Listen keeps request limits in sync with the rack parameters while serving the api
+func (s *Server) Listen(proto, addr string) error {
+	go s.limitsRefresh()
+
+	return s.Server.Listen(proto, addr)
+}
+
+func (s *Server) openapi(c *stdapi.Context) error {
+	c.Response().Header().Set("Content-Type", "application/json")
+
+	if _, err := c.Write(openapi); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (s *Server) authenticate(next stdapi.HandlerFunc) stdapi.HandlerFunc {
 	return func(c *stdapi.Context) error {
-		if _, pass, _ := c.Request().BasicAuth(); s.Password != "" && s.Password != pass {
+		_, pass, _ := c.Request().BasicAuth()
+
+		if s.Password == "" || s.Password == pass {
+			return next(c)
+		}
+
+		if pass == "" {
 			return stdapi.Errorf(401, "invalid authentication")
 		}
+
+		t, err := s.Provider.TokenAuthenticate(pass)
+		if err != nil {
+			return stdapi.Errorf(401, "invalid authentication")
+		}
+
+		c.Set("token", t)
+
+		return next(c)
+	}
+}
+
+func (s *Server) authorize(next stdapi.HandlerFunc) stdapi.HandlerFunc {
+	return func(c *stdapi.Context) error {
+		if t, ok := c.Get("token").(*structs.Token); ok {
+			if err := authorize(c, t); err != nil {
+				return err
+			}
+		}
+
 		return next(c)
 	}
 }
@@ -88,5 +139,9 @@ func (s *Server) hook(name string, args ...interface{}) error {
 }
 
 func (s *Server) provider(c *stdapi.Context) structs.Provider {
+	if t, ok := c.Get("token").(*structs.Token); ok && t.Scoped() {
+		return &scopedProvider{Provider: s.Provider, token: t}
+	}
+
 	return s.Provider
 }
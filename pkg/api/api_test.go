@@ -19,6 +19,7 @@ func testServer(t *testing.T, fn func(*stdsdk.Client, *structs.MockProvider)) {
 	p.On("Initialize", mock.Anything).Return(nil)
 	p.On("Start").Return(nil)
 	p.On("WithContext", mock.Anything).Return(p).Maybe()
+	p.On("AuditRecord", mock.Anything).Return(nil).Maybe()
 
 	s := api.NewWithProvider(p)
 	s.Logger = logger.Discard
@@ -52,3 +53,18 @@ func TestCheck(t *testing.T) {
 		require.Equal(t, "ok", string(data))
 	})
 }
+
+func TestOpenAPI(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		var spec struct {
+			OpenAPI string                            `json:"openapi"`
+			Paths   map[string]map[string]interface{} `json:"paths"`
+		}
+		err := c.Get("/openapi.json", stdsdk.RequestOptions{}, &spec)
+		require.NoError(t, err)
+		require.Equal(t, "3.0.0", spec.OpenAPI)
+		require.Contains(t, spec.Paths, "/apps/{app}/builds")
+		require.Contains(t, spec.Paths["/apps/{app}/builds"], "post")
+		require.Contains(t, spec.Paths, "/system/tokens")
+	})
+}
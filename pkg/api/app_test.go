@@ -77,6 +77,31 @@ func TestAppCreate(t *testing.T) {
 	})
 }
 
+func TestAppCreateClone(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		a1 := fxApp
+		a1.Tags = map[string]string{structs.AppTagExpires: "2018-09-04T00:00:00Z"}
+		a2 := structs.App{}
+		opts := structs.AppCreateOptions{
+			CloneFrom:  options.String("app2"),
+			Generation: options.String("2"),
+			Ttl:        options.Duration(72 * time.Hour),
+		}
+		ro := stdsdk.RequestOptions{
+			Params: stdsdk.Params{
+				"clone-from": "app2",
+				"name":       "app1",
+				"ttl":        "72h",
+			},
+		}
+		p.On("AppCreate", "app1", opts).Return(&a1, nil)
+		err := c.Post("/apps", ro, &a2)
+		require.NoError(t, err)
+		require.Equal(t, a1, a2)
+		require.Equal(t, time.Date(2018, 9, 4, 0, 0, 0, 0, time.UTC), a2.Expires())
+	})
+}
+
 func TestAppCreateError(t *testing.T) {
 	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
 		var a1 *structs.App
@@ -220,6 +245,22 @@ func TestAppUpdate(t *testing.T) {
 	})
 }
 
+func TestAppUpdateMaintenance(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		opts := structs.AppUpdateOptions{
+			Maintenance: options.Bool(true),
+		}
+		ro := stdsdk.RequestOptions{
+			Params: stdsdk.Params{
+				"maintenance": "true",
+			},
+		}
+		p.On("AppUpdate", "app1", opts).Return(nil)
+		err := c.Put("/apps/app1", ro, nil)
+		require.NoError(t, err)
+	})
+}
+
 func TestAppUpdateError(t *testing.T) {
 	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
 		p.On("AppUpdate", "app1", structs.AppUpdateOptions{}).Return(fmt.Errorf("err1"))
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/convox/convox/pkg/structs"
+	"github.com/convox/stdapi"
+)
+
+const auditRedacted = "[REDACTED]"
+
+// headers that carry parameters for socket routes
+var auditHeaders = []string{"Command", "Environment", "Image", "Release"}
+
+func (s *Server) audit(next stdapi.HandlerFunc) stdapi.HandlerFunc {
+	return func(c *stdapi.Context) error {
+		name := c.Name()
+		method, path := routeParts(structs.Routes()[name])
+
+		if !auditable(name, method, path) {
+			return next(c)
+		}
+
+		start := time.Now()
+
+		err := next(c)
+
+		e := structs.NewAuditEntry()
+
+		e.App = routeApp(c, path)
+		e.Code = auditCode(c, err)
+		e.Duration = time.Since(start)
+		e.Identity = identity(c)
+		e.Method = method
+		e.Params = auditParams(c)
+		e.Path = c.Request().URL.Path
+		e.Route = name
+
+		if t, ok := c.Get("token").(*structs.Token); ok {
+			e.Token = t.Id
+		}
+
+		if aerr := s.Provider.AuditRecord(*e); aerr != nil {
+			c.Logf("audit=error error=%q", aerr)
+		}
+
+		return err
+	}
+}
+
+// identity names the credential used for a request
+func identity(c *stdapi.Context) string {
+	if t, ok := c.Get("token").(*structs.Token); ok {
+		return t.Name
+	}
+
+	return "password"
+}
+
+func auditable(name, method, path string) bool {
+	switch method {
+	case "", "GET", "HEAD", "OPTIONS":
+		return false
+	case "SOCKET":
+		return routeRole(name, method, path) != structs.TokenRoleReadOnly
+	default:
+		return true
+	}
+}
+
+func auditCode(c *stdapi.Context, err error) int {
+	if err != nil {
+		if e, ok := err.(stdapi.Error); ok {
+			return e.Code()
+		}
+		return 500
+	}
+
+	if code := c.Response().Code(); code != 0 {
+		return code
+	}
+
+	return 200
+}
+
+func auditParams(c *stdapi.Context) map[string]string {
+	ps := map[string]string{}
+
+	r := c.Request()
+
+	for k, v := range r.Form {
+		if len(v) > 0 {
+			ps[k] = v[0]
+		}
+	}
+
+	for _, h := range auditHeaders {
+		if v := r.Header.Get(h); v != "" {
+			ps[strings.ToLower(h)] = v
+		}
+	}
+
+	for k, v := range ps {
+		switch k {
+		case "data", "key", "password":
+			ps[k] = auditRedacted
+		case "env":
+			ps[k] = redactEnv(strings.Split(v, "\n"), "=")
+		case "environment", "parameters":
+			ps[k] = redactEnvQuery(v)
+		}
+	}
+
+	if len(ps) == 0 {
+		return nil
+	}
+
+	return ps
+}
+
+func redactEnv(lines []string, sep string) string {
+	keys := []string{}
+
+	for _, l := range lines {
+		if parts := strings.SplitN(strings.TrimSpace(l), sep, 2); parts[0] != "" {
+			keys = append(keys, fmt.Sprintf("%s=%s", parts[0], auditRedacted))
+		}
+	}
+
+	sort.Strings(keys)
+
+	return strings.Join(keys, "\n")
+}
+
+func redactEnvQuery(v string) string {
+	uv, err := url.ParseQuery(v)
+	if err != nil {
+		return auditRedacted
+	}
+
+	keys := []string{}
+
+	for k := range uv {
+		keys = append(keys, k)
+	}
+
+	return redactEnv(keys, "=")
+}
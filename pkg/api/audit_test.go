@@ -0,0 +1,162 @@
+package api_test
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/convox/convox/pkg/api"
+	"github.com/convox/convox/pkg/options"
+	"github.com/convox/convox/pkg/structs"
+	"github.com/convox/convox/sdk"
+	"github.com/convox/logger"
+	"github.com/convox/stdsdk"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var fxAuditEntry = structs.AuditEntry{
+	Id:       "AABCDEFGHI",
+	App:      "app1",
+	Code:     200,
+	Duration: 2 * time.Second,
+	Identity: "ci",
+	Method:   "POST",
+	Path:     "/apps/app1/releases/release1/promote",
+	Route:    "ReleasePromote",
+	Time:     time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC),
+	Token:    "TABCDEFGHI",
+}
+
+func testAuditServer(t *testing.T, fn func(*sdk.Client, *structs.MockProvider, func() structs.AuditEntry)) {
+	p := &structs.MockProvider{}
+	p.On("Initialize", mock.Anything).Return(nil)
+	p.On("Start").Return(nil)
+	p.On("WithContext", mock.Anything).Return(p).Maybe()
+
+	entries := make(chan structs.AuditEntry, 1)
+
+	p.On("AuditRecord", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		entries <- args.Get(0).(structs.AuditEntry)
+	})
+
+	s := api.NewWithProvider(p)
+	s.Logger = logger.Discard
+	s.Password = "pass1"
+	s.Server.Recover = func(err error) {
+		require.NoError(t, err, "httptest server panic")
+	}
+
+	ht := httptest.NewServer(s)
+	defer ht.Close()
+
+	u, err := url.Parse(ht.URL)
+	require.NoError(t, err)
+
+	u.User = url.UserPassword("convox", "secret1")
+
+	c, err := sdk.New(u.String())
+	require.NoError(t, err)
+
+	fn(c, p, func() structs.AuditEntry { return <-entries })
+
+	p.AssertExpectations(t)
+}
+
+func TestAuditList(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		e1 := structs.AuditEntries{fxAuditEntry, fxAuditEntry}
+		e2 := structs.AuditEntries{}
+		opts := structs.AuditListOptions{
+			App:   options.String("app1"),
+			Limit: options.Int(10),
+			Since: options.Duration(2 * time.Hour),
+		}
+		ro := stdsdk.RequestOptions{
+			Query: stdsdk.Query{
+				"app":   "app1",
+				"limit": "10",
+				"since": "2h0m0s",
+			},
+		}
+		p.On("AuditList", opts).Return(e1, nil)
+		err := c.Get("/system/audit", ro, &e2)
+		require.NoError(t, err)
+		require.Equal(t, e1, e2)
+	})
+}
+
+func TestAuditListError(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		var e1 structs.AuditEntries
+		p.On("AuditList", structs.AuditListOptions{}).Return(nil, fmt.Errorf("err1"))
+		err := c.Get("/system/audit", stdsdk.RequestOptions{}, &e1)
+		require.EqualError(t, err, "err1")
+		require.Nil(t, e1)
+	})
+}
+
+func TestAuditRecord(t *testing.T) {
+	testAuditServer(t, func(c *sdk.Client, p *structs.MockProvider, entry func() structs.AuditEntry) {
+		tk := &structs.Token{Id: "TABCDEFGHI", Name: "ci", Role: "deployer"}
+		p.On("TokenAuthenticate", "secret1").Return(tk, nil)
+		p.On("ReleaseCreate", "app1", structs.ReleaseCreateOptions{Env: options.String("FOO=bar\nBAZ=qux")}).Return(&structs.Release{}, nil)
+
+		_, err := c.ReleaseCreate("app1", structs.ReleaseCreateOptions{Env: options.String("FOO=bar\nBAZ=qux")})
+		require.NoError(t, err)
+
+		e := entry()
+		require.Equal(t, "app1", e.App)
+		require.Equal(t, 200, e.Code)
+		require.Equal(t, "ci", e.Identity)
+		require.Equal(t, "POST", e.Method)
+		require.Equal(t, map[string]string{"env": "BAZ=[REDACTED]\nFOO=[REDACTED]"}, e.Params)
+		require.Equal(t, "/apps/app1/releases", e.Path)
+		require.Equal(t, "ReleaseCreate", e.Route)
+		require.Equal(t, "TABCDEFGHI", e.Token)
+	})
+}
+
+func TestAuditRecordParameters(t *testing.T) {
+	testAuditServer(t, func(c *sdk.Client, p *structs.MockProvider, entry func() structs.AuditEntry) {
+		tk := &structs.Token{Id: "TABCDEFGHI", Name: "ops", Role: "admin"}
+		opts := structs.SystemUpdateOptions{Parameters: map[string]string{"Password": "secret"}}
+		p.On("TokenAuthenticate", "secret1").Return(tk, nil)
+		p.On("SystemUpdate", opts).Return(nil)
+
+		err := c.SystemUpdate(opts)
+		require.NoError(t, err)
+
+		e := entry()
+		require.Equal(t, map[string]string{"parameters": "Password=[REDACTED]"}, e.Params)
+		require.Equal(t, "SystemUpdate", e.Route)
+	})
+}
+
+func TestAuditRecordDenied(t *testing.T) {
+	testAuditServer(t, func(c *sdk.Client, p *structs.MockProvider, entry func() structs.AuditEntry) {
+		tk := &structs.Token{Id: "TABCDEFGHI", Name: "ro", Role: "read-only"}
+		p.On("TokenAuthenticate", "secret1").Return(tk, nil)
+
+		_, err := c.CertificateCreate("pub", "key", structs.CertificateCreateOptions{})
+		require.EqualError(t, err, "token ro does not permit CertificateCreate")
+
+		e := entry()
+		require.Equal(t, 403, e.Code)
+		require.Equal(t, "ro", e.Identity)
+		require.Equal(t, "CertificateCreate", e.Route)
+	})
+}
+
+func TestAuditRecordUnauthenticated(t *testing.T) {
+	testTokenServer(t, func(client func(string) *sdk.Client, p *structs.MockProvider) {
+		p.On("TokenAuthenticate", "secret1").Return(nil, fmt.Errorf("invalid token"))
+
+		_, err := client("secret1").CertificateCreate("pub", "key", structs.CertificateCreateOptions{})
+		require.EqualError(t, err, "invalid authentication")
+
+		p.AssertNotCalled(t, "AuditRecord", mock.Anything)
+	})
+}
@@ -1,11 +1,13 @@
 package api_test
 
 import (
+	"fmt"
 	"net/http/httptest"
 	"net/url"
 	"testing"
 
 	"github.com/convox/convox/pkg/api"
+	"github.com/convox/convox/pkg/options"
 	"github.com/convox/convox/pkg/structs"
 	"github.com/convox/convox/sdk"
 	"github.com/convox/logger"
@@ -47,3 +49,149 @@ func TestAuthentication(t *testing.T) {
 	_, err = c.GetStream("/auth", stdsdk.RequestOptions{})
 	require.NoError(t, err)
 }
+
+func testTokenServer(t *testing.T, fn func(func(string) *sdk.Client, *structs.MockProvider)) {
+	p := &structs.MockProvider{}
+	p.On("Initialize", mock.Anything).Return(nil)
+	p.On("Start").Return(nil)
+	p.On("WithContext", mock.Anything).Return(p).Maybe()
+	p.On("AuditRecord", mock.Anything).Return(nil).Maybe()
+
+	s := api.NewWithProvider(p)
+	s.Logger = logger.Discard
+	s.Password = "pass1"
+	s.Server.Recover = func(err error) {
+		require.NoError(t, err, "httptest server panic")
+	}
+
+	ht := httptest.NewServer(s)
+	defer ht.Close()
+
+	client := func(secret string) *sdk.Client {
+		u, err := url.Parse(ht.URL)
+		require.NoError(t, err)
+
+		u.User = url.UserPassword("convox", secret)
+
+		c, err := sdk.New(u.String())
+		require.NoError(t, err)
+
+		return c
+	}
+
+	fn(client, p)
+
+	p.AssertExpectations(t)
+}
+
+func TestAuthenticationTokenInvalid(t *testing.T) {
+	testTokenServer(t, func(client func(string) *sdk.Client, p *structs.MockProvider) {
+		p.On("TokenAuthenticate", "secret1").Return(nil, fmt.Errorf("invalid token"))
+
+		_, err := client("secret1").AppList()
+		require.EqualError(t, err, "invalid authentication")
+	})
+}
+
+func TestAuthenticationTokenReadOnly(t *testing.T) {
+	testTokenServer(t, func(client func(string) *sdk.Client, p *structs.MockProvider) {
+		tk := &structs.Token{Name: "ro", Role: "read-only"}
+		p.On("TokenAuthenticate", "secret1").Return(tk, nil)
+		p.On("AppList").Return(structs.Apps{}, nil)
+
+		_, err := client("secret1").AppList()
+		require.NoError(t, err)
+
+		err = client("secret1").ServiceRestart("app1", "web")
+		require.EqualError(t, err, "token ro does not permit ServiceRestart")
+
+		_, err = client("secret1").TokenList()
+		require.EqualError(t, err, "token ro does not permit TokenList")
+
+		_, err = client("secret1").AuditList(structs.AuditListOptions{})
+		require.EqualError(t, err, "token ro does not permit AuditList")
+
+		_, err = client("secret1").FilesChecksums("app1", "pid1", "/app")
+		require.EqualError(t, err, "token ro does not permit FilesChecksums")
+
+		_, err = client("secret1").ReleaseGet("app1", "release1")
+		require.EqualError(t, err, "token ro does not permit ReleaseGet")
+
+		_, err = client("secret1").ReleaseList("app1", structs.ReleaseListOptions{})
+		require.EqualError(t, err, "token ro does not permit ReleaseList")
+
+		_, err = client("secret1").ReleaseDiff("app1", "release1", structs.ReleaseDiffOptions{Reveal: options.Bool(true)})
+		require.EqualError(t, err, "token ro does not permit ReleaseDiff")
+
+		_, err = client("secret1").SystemReleases()
+		require.EqualError(t, err, "token ro does not permit SystemReleases")
+	})
+}
+
+func TestAuthenticationTokenDeployer(t *testing.T) {
+	testTokenServer(t, func(client func(string) *sdk.Client, p *structs.MockProvider) {
+		tk := &structs.Token{Name: "ci", Role: "deployer", Apps: []string{"app1"}}
+		p.On("TokenAuthenticate", "secret1").Return(tk, nil)
+		p.On("ServiceRestart", "app1", "web").Return(nil)
+
+		err := client("secret1").ServiceRestart("app1", "web")
+		require.NoError(t, err)
+
+		err = client("secret1").ServiceRestart("app2", "web")
+		require.EqualError(t, err, "token ci does not permit app: app2")
+
+		err = client("secret1").AppDelete("app1")
+		require.EqualError(t, err, "token ci does not permit AppDelete")
+
+		err = client("secret1").InstanceTerminate("i-1")
+		require.EqualError(t, err, "token ci does not permit InstanceTerminate")
+	})
+}
+
+func TestAuthenticationTokenScoped(t *testing.T) {
+	testTokenServer(t, func(client func(string) *sdk.Client, p *structs.MockProvider) {
+		tk := &structs.Token{Name: "ci", Role: "read-only", Apps: []string{"app1"}}
+		p.On("TokenAuthenticate", "secret1").Return(tk, nil)
+		p.On("AppList").Return(structs.Apps{{Name: "app1"}, {Name: "app2"}}, nil)
+		p.On("SystemProcesses", structs.SystemProcessesOptions{}).Return(structs.Processes{{Id: "pid1", App: "app1"}, {Id: "pid2", App: "app2"}}, nil)
+
+		as, err := client("secret1").AppList()
+		require.NoError(t, err)
+		require.Equal(t, structs.Apps{{Name: "app1"}}, as)
+
+		pss, err := client("secret1").SystemProcesses(structs.SystemProcessesOptions{})
+		require.NoError(t, err)
+		require.Equal(t, structs.Processes{{Id: "pid1", App: "app1"}}, pss)
+
+		_, err = client("secret1").SystemResourceList()
+		require.EqualError(t, err, "token ci does not permit SystemResourceList")
+
+		_, err = client("secret1").SystemResourceGet("resource1")
+		require.EqualError(t, err, "token ci does not permit SystemResourceGet")
+	})
+}
+
+func TestAuthenticationTokenScopedSourceApp(t *testing.T) {
+	testTokenServer(t, func(client func(string) *sdk.Client, p *structs.MockProvider) {
+		tk := &structs.Token{Name: "ci", Role: "deployer", Apps: []string{"app1"}}
+		p.On("TokenAuthenticate", "secret1").Return(tk, nil)
+		p.On("VolumeRestore", "app1", "data", "snap1", structs.VolumeRestoreOptions{SourceApp: options.String("app1")}).Return(nil)
+
+		err := client("secret1").VolumeRestore("app1", "data", "snap1", structs.VolumeRestoreOptions{SourceApp: options.String("app1")})
+		require.NoError(t, err)
+
+		err = client("secret1").VolumeRestore("app1", "data", "snap1", structs.VolumeRestoreOptions{SourceApp: options.String("app2")})
+		require.EqualError(t, err, "token ci does not permit app: app2")
+	})
+}
+
+func TestAuthenticationTokenAdmin(t *testing.T) {
+	testTokenServer(t, func(client func(string) *sdk.Client, p *structs.MockProvider) {
+		tk := &structs.Token{Name: "ops", Role: "admin"}
+		p.On("TokenAuthenticate", "secret1").Return(tk, nil)
+		p.On("InstanceTerminate", "i-1").Return(nil)
+
+		err := client("secret1").InstanceTerminate("i-1")
+		require.NoError(t, err)
+	})
+}
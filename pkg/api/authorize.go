@@ -0,0 +1,151 @@
+package api
+
+import (
+	"context"
+	"strings"
+
+	"github.com/convox/convox/pkg/structs"
+	"github.com/convox/stdapi"
+)
+
+// routes that do not follow the default split of reads for read-only,
+// app changes for deployer, and everything else for admin. releases
+// include the plaintext environment of the app
+var routeRoles = map[string]string{
+	"AppDelete":      structs.TokenRoleAdmin,
+	"AppLogs":        structs.TokenRoleReadOnly,
+	"AuditList":      structs.TokenRoleAdmin,
+	"BuildExport":    structs.TokenRoleDeployer,
+	"BuildLogs":      structs.TokenRoleReadOnly,
+	"FilesChecksums": structs.TokenRoleDeployer,
+	"FilesDownload":  structs.TokenRoleDeployer,
+	"InstanceShell":  structs.TokenRoleAdmin,
+	"ObjectFetch":    structs.TokenRoleDeployer,
+	"ProcessLogs":    structs.TokenRoleReadOnly,
+	"Proxy":          structs.TokenRoleAdmin,
+	"ReleaseDiff":    structs.TokenRoleDeployer,
+	"ReleaseGet":     structs.TokenRoleDeployer,
+	"ReleaseList":    structs.TokenRoleDeployer,
+	"ResourceExport": structs.TokenRoleDeployer,
+	"SystemLogs":     structs.TokenRoleReadOnly,
+	"SystemReleases": structs.TokenRoleDeployer,
+	"TokenList":      structs.TokenRoleAdmin,
+}
+
+func authorize(c *stdapi.Context, t *structs.Token) error {
+	name := c.Name()
+
+	method, path := routeParts(structs.Routes()[name])
+
+	role := routeRole(name, method, path)
+
+	// system resource urls include credentials
+	if t.Scoped() && strings.HasPrefix(path, "/resources") {
+		role = structs.TokenRoleAdmin
+	}
+
+	if !t.Allows(role) {
+		return stdapi.Errorf(403, "token %s does not permit %s", t.Name, name)
+	}
+
+	if t.Scoped() {
+		app := routeApp(c, path)
+
+		if app == "" && role != structs.TokenRoleReadOnly {
+			return stdapi.Errorf(403, "token %s is scoped to apps: %s", t.Name, strings.Join(t.Apps, ", "))
+		}
+
+		if app != "" && !t.AllowsApp(app) {
+			return stdapi.Errorf(403, "token %s does not permit app: %s", t.Name, app)
+		}
+
+		// volume restores can read snapshots from another app
+		if source := c.Form("source-app"); source != "" && !t.AllowsApp(source) {
+			return stdapi.Errorf(403, "token %s does not permit app: %s", t.Name, source)
+		}
+	}
+
+	return nil
+}
+
+// scopedProvider limits listings across apps to the apps permitted by a scoped token
+type scopedProvider struct {
+	structs.Provider
+	token *structs.Token
+}
+
+func (p *scopedProvider) AppList() (structs.Apps, error) {
+	as, err := p.Provider.AppList()
+	if err != nil {
+		return nil, err
+	}
+
+	sas := structs.Apps{}
+
+	for _, a := range as {
+		if p.token.AllowsApp(a.Name) {
+			sas = append(sas, a)
+		}
+	}
+
+	return sas, nil
+}
+
+func (p *scopedProvider) SystemProcesses(opts structs.SystemProcessesOptions) (structs.Processes, error) {
+	pss, err := p.Provider.SystemProcesses(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	spss := structs.Processes{}
+
+	for _, ps := range pss {
+		if p.token.AllowsApp(ps.App) {
+			spss = append(spss, ps)
+		}
+	}
+
+	return spss, nil
+}
+
+func (p *scopedProvider) WithContext(ctx context.Context) structs.Provider {
+	return &scopedProvider{Provider: p.Provider.WithContext(ctx), token: p.token}
+}
+
+func routeApp(c *stdapi.Context, path string) string {
+	switch {
+	case strings.HasPrefix(path, "/apps/{app}"):
+		return c.Var("app")
+	case strings.HasPrefix(path, "/apps/{name}"):
+		return c.Var("name")
+	default:
+		return ""
+	}
+}
+
+func routeParts(route string) (string, string) {
+	parts := strings.SplitN(route, " ", 2)
+
+	if len(parts) != 2 {
+		return "", ""
+	}
+
+	return parts[0], parts[1]
+}
+
+func routeRole(name, method, path string) string {
+	if role, ok := routeRoles[name]; ok {
+		return role
+	}
+
+	switch method {
+	case "", "GET", "HEAD", "OPTIONS":
+		return structs.TokenRoleReadOnly
+	}
+
+	if strings.HasPrefix(path, "/apps/") {
+		return structs.TokenRoleDeployer
+	}
+
+	return structs.TokenRoleAdmin
+}
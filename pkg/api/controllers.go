@@ -176,6 +176,32 @@ func (s *Server) AppUpdate(c *stdapi.Context) error {
 	return c.RenderOK()
 }
 
+func (s *Server) AuditList(c *stdapi.Context) error {
+	if err := s.hook("AuditListValidate", c); err != nil {
+		return err
+	}
+
+	var opts structs.AuditListOptions
+	if err := stdapi.UnmarshalOptions(c.Request(), &opts); err != nil {
+		return err
+	}
+
+	v, err := s.provider(c).WithContext(c.Context()).AuditList(opts)
+	if err != nil {
+		return err
+	}
+
+	if vs, ok := interface{}(v).(Sortable); ok {
+		sort.Slice(v, vs.Less)
+	}
+
+	return c.RenderJSON(v)
+}
+
+func (s *Server) AuditRecord(c *stdapi.Context) error {
+	return stdapi.Errorf(404, "not available via api")
+}
+
 func (s *Server) BalancerList(c *stdapi.Context) error {
 	if err := s.hook("BalancerListValidate", c); err != nil {
 		return err
@@ -474,6 +500,25 @@ func (s *Server) CertificateList(c *stdapi.Context) error {
 	return c.RenderJSON(v)
 }
 
+func (s *Server) EnvironmentSecretList(c *stdapi.Context) error {
+	if err := s.hook("EnvironmentSecretListValidate", c); err != nil {
+		return err
+	}
+
+	app := c.Var("app")
+
+	v, err := s.provider(c).WithContext(c.Context()).EnvironmentSecretList(app)
+	if err != nil {
+		return err
+	}
+
+	if vs, ok := interface{}(v).(Sortable); ok {
+		sort.Slice(v, vs.Less)
+	}
+
+	return c.RenderJSON(v)
+}
+
 func (s *Server) EventSend(c *stdapi.Context) error {
 	if err := s.hook("EventSendValidate", c); err != nil {
 		return err
@@ -494,6 +539,27 @@ func (s *Server) EventSend(c *stdapi.Context) error {
 	return c.RenderOK()
 }
 
+func (s *Server) FilesChecksums(c *stdapi.Context) error {
+	if err := s.hook("FilesChecksumsValidate", c); err != nil {
+		return err
+	}
+
+	app := c.Var("app")
+	pid := c.Var("pid")
+	path := c.Value("path")
+
+	v, err := s.provider(c).WithContext(c.Context()).FilesChecksums(app, pid, path)
+	if err != nil {
+		return err
+	}
+
+	if vs, ok := interface{}(v).(Sortable); ok {
+		sort.Slice(v, vs.Less)
+	}
+
+	return c.RenderJSON(v)
+}
+
 func (s *Server) FilesDelete(c *stdapi.Context) error {
 	if err := s.hook("FilesDeleteValidate", c); err != nil {
 		return err
@@ -741,6 +807,32 @@ func (s *Server) ObjectStore(c *stdapi.Context) error {
 	return c.RenderJSON(v)
 }
 
+func (s *Server) ProcessDebug(c *stdapi.Context) error {
+	if err := s.hook("ProcessDebugValidate", c); err != nil {
+		return err
+	}
+
+	app := c.Var("app")
+	pid := c.Var("pid")
+	rw := c
+
+	var opts structs.ProcessDebugOptions
+	if err := stdapi.UnmarshalOptions(c.Request(), &opts); err != nil {
+		return err
+	}
+
+	v, err := s.provider(c).WithContext(c.Context()).ProcessDebug(app, pid, rw, opts)
+	if err != nil {
+		return err
+	}
+
+	if vs, ok := interface{}(v).(Sortable); ok {
+		sort.Slice(v, vs.Less)
+	}
+
+	return renderStatusCode(c, v)
+}
+
 func (s *Server) ProcessExec(c *stdapi.Context) error {
 	if err := s.hook("ProcessExecValidate", c); err != nil {
 		return err
@@ -768,6 +860,33 @@ func (s *Server) ProcessExec(c *stdapi.Context) error {
 	return renderStatusCode(c, v)
 }
 
+func (s *Server) ProcessForward(c *stdapi.Context) error {
+	if err := s.hook("ProcessForwardValidate", c); err != nil {
+		return err
+	}
+
+	app := c.Var("app")
+	pid := c.Var("pid")
+	rw := c
+
+	port, cerr := strconv.Atoi(c.Var("port"))
+	if cerr != nil {
+		return cerr
+	}
+
+	var opts structs.ProcessForwardOptions
+	if err := stdapi.UnmarshalOptions(c.Request(), &opts); err != nil {
+		return err
+	}
+
+	err := s.provider(c).WithContext(c.Context()).ProcessForward(app, pid, port, rw, opts)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (s *Server) ProcessGet(c *stdapi.Context) error {
 	if err := s.hook("ProcessGetValidate", c); err != nil {
 		return err
@@ -989,6 +1108,31 @@ func (s *Server) ReleaseCreate(c *stdapi.Context) error {
 	return c.RenderJSON(v)
 }
 
+func (s *Server) ReleaseDiff(c *stdapi.Context) error {
+	if err := s.hook("ReleaseDiffValidate", c); err != nil {
+		return err
+	}
+
+	app := c.Var("app")
+	id := c.Var("id")
+
+	var opts structs.ReleaseDiffOptions
+	if err := stdapi.UnmarshalOptions(c.Request(), &opts); err != nil {
+		return err
+	}
+
+	v, err := s.provider(c).WithContext(c.Context()).ReleaseDiff(app, id, opts)
+	if err != nil {
+		return err
+	}
+
+	if vs, ok := interface{}(v).(Sortable); ok {
+		sort.Slice(v, vs.Less)
+	}
+
+	return c.RenderJSON(v)
+}
+
 func (s *Server) ReleaseGet(c *stdapi.Context) error {
 	if err := s.hook("ReleaseGetValidate", c); err != nil {
 		return err
@@ -1054,6 +1198,26 @@ func (s *Server) ReleasePromote(c *stdapi.Context) error {
 	return c.RenderOK()
 }
 
+func (s *Server) ResourceBackupList(c *stdapi.Context) error {
+	if err := s.hook("ResourceBackupListValidate", c); err != nil {
+		return err
+	}
+
+	app := c.Var("app")
+	name := c.Var("name")
+
+	v, err := s.provider(c).WithContext(c.Context()).ResourceBackupList(app, name)
+	if err != nil {
+		return err
+	}
+
+	if vs, ok := interface{}(v).(Sortable); ok {
+		sort.Slice(v, vs.Less)
+	}
+
+	return c.RenderJSON(v)
+}
+
 func (s *Server) ResourceConsole(c *stdapi.Context) error {
 	if err := s.hook("ResourceConsoleValidate", c); err != nil {
 		return err
@@ -1160,6 +1324,75 @@ func (s *Server) ResourceList(c *stdapi.Context) error {
 	return c.RenderJSON(v)
 }
 
+func (s *Server) ResourceRestore(c *stdapi.Context) error {
+	if err := s.hook("ResourceRestoreValidate", c); err != nil {
+		return err
+	}
+
+	app := c.Var("app")
+	name := c.Var("name")
+	id := c.Var("id")
+
+	err := s.provider(c).WithContext(c.Context()).ResourceRestore(app, name, id)
+	if err != nil {
+		return err
+	}
+
+	return c.RenderOK()
+}
+
+func (s *Server) ResourceUpdate(c *stdapi.Context) error {
+	if err := s.hook("ResourceUpdateValidate", c); err != nil {
+		return err
+	}
+
+	app := c.Var("app")
+	name := c.Var("name")
+
+	var opts structs.ResourceUpdateOptions
+	if err := stdapi.UnmarshalOptions(c.Request(), &opts); err != nil {
+		return err
+	}
+
+	v, err := s.provider(c).WithContext(c.Context()).ResourceUpdate(app, name, opts)
+	if err != nil {
+		return err
+	}
+
+	if vs, ok := interface{}(v).(Sortable); ok {
+		sort.Slice(v, vs.Less)
+	}
+
+	return c.RenderJSON(v)
+}
+
+func (s *Server) ServiceForward(c *stdapi.Context) error {
+	if err := s.hook("ServiceForwardValidate", c); err != nil {
+		return err
+	}
+
+	app := c.Var("app")
+	name := c.Var("name")
+	rw := c
+
+	port, cerr := strconv.Atoi(c.Var("port"))
+	if cerr != nil {
+		return cerr
+	}
+
+	var opts structs.ServiceForwardOptions
+	if err := stdapi.UnmarshalOptions(c.Request(), &opts); err != nil {
+		return err
+	}
+
+	err := s.provider(c).WithContext(c.Context()).ServiceForward(app, name, port, rw, opts)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (s *Server) ServiceList(c *stdapi.Context) error {
 	if err := s.hook("ServiceListValidate", c); err != nil {
 		return err
@@ -1195,6 +1428,45 @@ func (s *Server) ServiceRestart(c *stdapi.Context) error {
 	return c.RenderOK()
 }
 
+func (s *Server) ServiceScheduleDelete(c *stdapi.Context) error {
+	if err := s.hook("ServiceScheduleDeleteValidate", c); err != nil {
+		return err
+	}
+
+	app := c.Var("app")
+	name := c.Var("name")
+	schedule := c.Value("schedule")
+
+	err := s.provider(c).WithContext(c.Context()).ServiceScheduleDelete(app, name, schedule)
+	if err != nil {
+		return err
+	}
+
+	return c.RenderOK()
+}
+
+func (s *Server) ServiceScheduleSet(c *stdapi.Context) error {
+	if err := s.hook("ServiceScheduleSetValidate", c); err != nil {
+		return err
+	}
+
+	app := c.Var("app")
+	name := c.Var("name")
+	schedule := c.Value("schedule")
+
+	var opts structs.ServiceScheduleSetOptions
+	if err := stdapi.UnmarshalOptions(c.Request(), &opts); err != nil {
+		return err
+	}
+
+	err := s.provider(c).WithContext(c.Context()).ServiceScheduleSet(app, name, schedule, opts)
+	if err != nil {
+		return err
+	}
+
+	return c.RenderOK()
+}
+
 func (s *Server) ServiceUpdate(c *stdapi.Context) error {
 	if err := s.hook("ServiceUpdateValidate", c); err != nil {
 		return err
@@ -1510,6 +1782,214 @@ func (s *Server) SystemUpdate(c *stdapi.Context) error {
 	return c.RenderOK()
 }
 
+func (s *Server) TimerList(c *stdapi.Context) error {
+	if err := s.hook("TimerListValidate", c); err != nil {
+		return err
+	}
+
+	app := c.Var("app")
+
+	v, err := s.provider(c).WithContext(c.Context()).TimerList(app)
+	if err != nil {
+		return err
+	}
+
+	if vs, ok := interface{}(v).(Sortable); ok {
+		sort.Slice(v, vs.Less)
+	}
+
+	return c.RenderJSON(v)
+}
+
+func (s *Server) TimerResume(c *stdapi.Context) error {
+	if err := s.hook("TimerResumeValidate", c); err != nil {
+		return err
+	}
+
+	app := c.Var("app")
+	name := c.Var("name")
+
+	err := s.provider(c).WithContext(c.Context()).TimerResume(app, name)
+	if err != nil {
+		return err
+	}
+
+	return c.RenderOK()
+}
+
+func (s *Server) TimerRun(c *stdapi.Context) error {
+	if err := s.hook("TimerRunValidate", c); err != nil {
+		return err
+	}
+
+	app := c.Var("app")
+	name := c.Var("name")
+
+	err := s.provider(c).WithContext(c.Context()).TimerRun(app, name)
+	if err != nil {
+		return err
+	}
+
+	return c.RenderOK()
+}
+
+func (s *Server) TimerSuspend(c *stdapi.Context) error {
+	if err := s.hook("TimerSuspendValidate", c); err != nil {
+		return err
+	}
+
+	app := c.Var("app")
+	name := c.Var("name")
+
+	err := s.provider(c).WithContext(c.Context()).TimerSuspend(app, name)
+	if err != nil {
+		return err
+	}
+
+	return c.RenderOK()
+}
+
+func (s *Server) TokenAuthenticate(c *stdapi.Context) error {
+	return stdapi.Errorf(404, "not available via api")
+}
+
+func (s *Server) TokenCreate(c *stdapi.Context) error {
+	if err := s.hook("TokenCreateValidate", c); err != nil {
+		return err
+	}
+
+	name := c.Value("name")
+
+	var opts structs.TokenCreateOptions
+	if err := stdapi.UnmarshalOptions(c.Request(), &opts); err != nil {
+		return err
+	}
+
+	v, err := s.provider(c).WithContext(c.Context()).TokenCreate(name, opts)
+	if err != nil {
+		return err
+	}
+
+	if vs, ok := interface{}(v).(Sortable); ok {
+		sort.Slice(v, vs.Less)
+	}
+
+	return c.RenderJSON(v)
+}
+
+func (s *Server) TokenDelete(c *stdapi.Context) error {
+	if err := s.hook("TokenDeleteValidate", c); err != nil {
+		return err
+	}
+
+	id := c.Var("id")
+
+	err := s.provider(c).WithContext(c.Context()).TokenDelete(id)
+	if err != nil {
+		return err
+	}
+
+	return c.RenderOK()
+}
+
+func (s *Server) TokenList(c *stdapi.Context) error {
+	if err := s.hook("TokenListValidate", c); err != nil {
+		return err
+	}
+
+	v, err := s.provider(c).WithContext(c.Context()).TokenList()
+	if err != nil {
+		return err
+	}
+
+	if vs, ok := interface{}(v).(Sortable); ok {
+		sort.Slice(v, vs.Less)
+	}
+
+	return c.RenderJSON(v)
+}
+
+func (s *Server) VolumeList(c *stdapi.Context) error {
+	if err := s.hook("VolumeListValidate", c); err != nil {
+		return err
+	}
+
+	app := c.Var("app")
+
+	v, err := s.provider(c).WithContext(c.Context()).VolumeList(app)
+	if err != nil {
+		return err
+	}
+
+	if vs, ok := interface{}(v).(Sortable); ok {
+		sort.Slice(v, vs.Less)
+	}
+
+	return c.RenderJSON(v)
+}
+
+func (s *Server) VolumeRestore(c *stdapi.Context) error {
+	if err := s.hook("VolumeRestoreValidate", c); err != nil {
+		return err
+	}
+
+	app := c.Var("app")
+	name := c.Var("name")
+	id := c.Var("id")
+
+	var opts structs.VolumeRestoreOptions
+	if err := stdapi.UnmarshalOptions(c.Request(), &opts); err != nil {
+		return err
+	}
+
+	err := s.provider(c).WithContext(c.Context()).VolumeRestore(app, name, id, opts)
+	if err != nil {
+		return err
+	}
+
+	return c.RenderOK()
+}
+
+func (s *Server) VolumeSnapshot(c *stdapi.Context) error {
+	if err := s.hook("VolumeSnapshotValidate", c); err != nil {
+		return err
+	}
+
+	app := c.Var("app")
+	name := c.Var("name")
+
+	v, err := s.provider(c).WithContext(c.Context()).VolumeSnapshot(app, name)
+	if err != nil {
+		return err
+	}
+
+	if vs, ok := interface{}(v).(Sortable); ok {
+		sort.Slice(v, vs.Less)
+	}
+
+	return c.RenderJSON(v)
+}
+
+func (s *Server) VolumeSnapshotList(c *stdapi.Context) error {
+	if err := s.hook("VolumeSnapshotListValidate", c); err != nil {
+		return err
+	}
+
+	app := c.Var("app")
+	name := c.Var("name")
+
+	v, err := s.provider(c).WithContext(c.Context()).VolumeSnapshotList(app, name)
+	if err != nil {
+		return err
+	}
+
+	if vs, ok := interface{}(v).(Sortable); ok {
+		sort.Slice(v, vs.Less)
+	}
+
+	return c.RenderJSON(v)
+}
+
 func (s *Server) Workers(c *stdapi.Context) error {
 	return stdapi.Errorf(404, "not available via api")
 }
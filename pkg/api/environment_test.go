@@ -0,0 +1,37 @@
+package api_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/convox/convox/pkg/structs"
+	"github.com/convox/stdsdk"
+	"github.com/stretchr/testify/require"
+)
+
+var fxEnvironmentSecret = structs.EnvironmentSecret{
+	Name:      "DB_PASSWORD",
+	Reference: "secret://vault/db#password",
+	Status:    "stale",
+}
+
+func TestEnvironmentSecretList(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		s1 := structs.EnvironmentSecrets{fxEnvironmentSecret, fxEnvironmentSecret}
+		s2 := structs.EnvironmentSecrets{}
+		p.On("EnvironmentSecretList", "app1").Return(s1, nil)
+		err := c.Get("/apps/app1/environment/secrets", stdsdk.RequestOptions{}, &s2)
+		require.NoError(t, err)
+		require.Equal(t, s1, s2)
+	})
+}
+
+func TestEnvironmentSecretListError(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		var s1 structs.EnvironmentSecrets
+		p.On("EnvironmentSecretList", "app1").Return(nil, fmt.Errorf("err1"))
+		err := c.Get("/apps/app1/environment/secrets", stdsdk.RequestOptions{}, &s1)
+		require.EqualError(t, err, "err1")
+		require.Nil(t, s1)
+	})
+}
@@ -13,6 +13,35 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func TestFilesChecksums(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		f1 := structs.FileChecksums{
+			{Checksum: "sum2", Path: "/app/b"},
+			{Checksum: "sum1", Path: "/app/a"},
+		}
+		f2 := structs.FileChecksums{}
+		opts := stdsdk.RequestOptions{
+			Query: stdsdk.Query{
+				"path": "/app",
+			},
+		}
+		p.On("FilesChecksums", "app1", "pid1", "/app").Return(f1, nil)
+		err := c.Get("/apps/app1/processes/pid1/files/checksums", opts, &f2)
+		require.NoError(t, err)
+		require.Equal(t, structs.FileChecksums{{Checksum: "sum1", Path: "/app/a"}, {Checksum: "sum2", Path: "/app/b"}}, f2)
+	})
+}
+
+func TestFilesChecksumsError(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		var f1 structs.FileChecksums
+		p.On("FilesChecksums", "app1", "pid1", "").Return(nil, fmt.Errorf("err1"))
+		err := c.Get("/apps/app1/processes/pid1/files/checksums", stdsdk.RequestOptions{}, &f1)
+		require.EqualError(t, err, "err1")
+		require.Nil(t, f1)
+	})
+}
+
 func TestFilesDelete(t *testing.T) {
 	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
 		opts := stdsdk.RequestOptions{
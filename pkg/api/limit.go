@@ -0,0 +1,199 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/convox/convox/pkg/structs"
+	"github.com/convox/stdapi"
+	"golang.org/x/time/rate"
+)
+
+// rack parameters that set request limits per identity, a value of zero disables the limit
+const (
+	LimitConcurrency = "RateLimitConcurrency"
+	LimitRead        = "RateLimitRead"
+	LimitSocket      = "RateLimitSocket"
+	LimitWrite       = "RateLimitWrite"
+)
+
+const limitRefresh = 1 * time.Minute
+
+// limits are tracked in memory so each api process enforces them independently
+type limiter struct {
+	buckets  map[string]*rate.Limiter
+	inflight map[string]int
+	limits   map[string]int
+	lock     sync.Mutex
+}
+
+func newLimiter() *limiter {
+	return &limiter{
+		buckets:  map[string]*rate.Limiter{},
+		inflight: map[string]int{},
+		limits:   map[string]int{},
+	}
+}
+
+// UpdateLimits applies the request limits found in a set of rack parameters
+func (s *Server) UpdateLimits(params map[string]string) error {
+	limits := map[string]int{}
+
+	for _, k := range []string{LimitConcurrency, LimitRead, LimitSocket, LimitWrite} {
+		v, ok := params[k]
+		if !ok || v == "" {
+			continue
+		}
+
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid value for %s: %s", k, v)
+		}
+
+		limits[k] = n
+	}
+
+	s.limiter.set(limits)
+
+	return nil
+}
+
+func (s *Server) limit(next stdapi.HandlerFunc) stdapi.HandlerFunc {
+	return func(c *stdapi.Context) error {
+		method, _ := routeParts(structs.Routes()[c.Name()])
+		class := routeClass(method)
+
+		identity := "password"
+
+		if t, ok := c.Get("token").(*structs.Token); ok {
+			identity = t.Id
+		}
+
+		if wait := s.limiter.reserve(identity, class); wait > 0 {
+			c.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			return stdapi.Errorf(429, "rate limit exceeded for %s requests", class)
+		}
+
+		// sockets are long lived so only count against the rate limit
+		if class == "socket" {
+			return next(c)
+		}
+
+		if !s.limiter.acquire(identity) {
+			c.Response().Header().Set("Retry-After", "1")
+			return stdapi.Errorf(429, "too many concurrent requests")
+		}
+
+		defer s.limiter.release(identity)
+
+		return next(c)
+	}
+}
+
+func (s *Server) limitsLoad() error {
+	sys, err := s.Provider.SystemGet()
+	if err != nil {
+		return err
+	}
+
+	return s.UpdateLimits(sys.Parameters)
+}
+
+func (s *Server) limitsRefresh() {
+	for {
+		if err := s.limitsLoad(); err != nil {
+			s.Logger.At("limits").Error(err)
+		}
+
+		time.Sleep(limitRefresh)
+	}
+}
+
+// acquire returns false if the identity is already at its concurrency limit
+func (l *limiter) acquire(identity string) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if max := l.limits[LimitConcurrency]; max > 0 && l.inflight[identity] >= max {
+		return false
+	}
+
+	l.inflight[identity]++
+
+	return true
+}
+
+func (l *limiter) release(identity string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.inflight[identity]--; l.inflight[identity] <= 0 {
+		delete(l.inflight, identity)
+	}
+}
+
+// reserve returns how long the identity must wait before it can make a request of this class
+func (l *limiter) reserve(identity, class string) time.Duration {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	max := l.limits[limitParameter(class)]
+	if max == 0 {
+		return 0
+	}
+
+	key := fmt.Sprintf("%s/%s", identity, class)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = rate.NewLimiter(rate.Every(time.Minute/time.Duration(max)), max)
+		l.buckets[key] = b
+	}
+
+	r := b.Reserve()
+
+	if d := r.Delay(); d > 0 {
+		r.Cancel()
+		return d
+	}
+
+	return 0
+}
+
+func (l *limiter) set(limits map[string]int) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if reflect.DeepEqual(l.limits, limits) {
+		return
+	}
+
+	l.buckets = map[string]*rate.Limiter{}
+	l.limits = limits
+}
+
+func limitParameter(class string) string {
+	switch class {
+	case "socket":
+		return LimitSocket
+	case "write":
+		return LimitWrite
+	default:
+		return LimitRead
+	}
+}
+
+func routeClass(method string) string {
+	switch method {
+	case "", "GET", "HEAD", "OPTIONS":
+		return "read"
+	case "SOCKET":
+		return "socket"
+	default:
+		return "write"
+	}
+}
@@ -0,0 +1,174 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/convox/convox/pkg/api"
+	"github.com/convox/convox/pkg/structs"
+	"github.com/convox/convox/sdk"
+	"github.com/convox/logger"
+	"github.com/convox/stdsdk"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testLimitServer(t *testing.T, params map[string]string, fn func(func(string) *sdk.Client, *structs.MockProvider)) {
+	retries := sdk.RateLimitRetries
+	sdk.RateLimitRetries = 0
+	defer func() { sdk.RateLimitRetries = retries }()
+
+	p := &structs.MockProvider{}
+	p.On("Initialize", mock.Anything).Return(nil)
+	p.On("Start").Return(nil)
+	p.On("WithContext", mock.Anything).Return(p).Maybe()
+	p.On("AuditRecord", mock.Anything).Return(nil).Maybe()
+
+	s := api.NewWithProvider(p)
+	s.Logger = logger.Discard
+	s.Password = "pass1"
+	s.Server.Recover = func(err error) {
+		require.NoError(t, err, "httptest server panic")
+	}
+
+	err := s.UpdateLimits(params)
+	require.NoError(t, err)
+
+	ht := httptest.NewServer(s)
+	defer ht.Close()
+
+	client := func(secret string) *sdk.Client {
+		u, err := url.Parse(ht.URL)
+		require.NoError(t, err)
+
+		u.User = url.UserPassword("convox", secret)
+
+		c, err := sdk.New(u.String())
+		require.NoError(t, err)
+
+		return c
+	}
+
+	fn(client, p)
+
+	p.AssertExpectations(t)
+}
+
+func TestLimitRead(t *testing.T) {
+	testLimitServer(t, map[string]string{api.LimitRead: "2"}, func(client func(string) *sdk.Client, p *structs.MockProvider) {
+		p.On("AppList").Return(structs.Apps{}, nil).Twice()
+
+		_, err := client("pass1").AppList()
+		require.NoError(t, err)
+
+		_, err = client("pass1").AppList()
+		require.NoError(t, err)
+
+		_, err = client("pass1").AppList()
+		require.EqualError(t, err, "rate limit exceeded for read requests")
+	})
+}
+
+func TestLimitRetryAfter(t *testing.T) {
+	testLimitServer(t, map[string]string{api.LimitWrite: "1"}, func(client func(string) *sdk.Client, p *structs.MockProvider) {
+		p.On("ServiceRestart", "app1", "web").Return(nil).Once()
+
+		err := client("pass1").ServiceRestart("app1", "web")
+		require.NoError(t, err)
+
+		req, err := client("pass1").Request("POST", "/apps/app1/services/web/restart", stdsdk.RequestOptions{})
+		require.NoError(t, err)
+
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		require.Equal(t, 429, res.StatusCode)
+		require.Equal(t, "60", res.Header.Get("Retry-After"))
+	})
+}
+
+func TestLimitClasses(t *testing.T) {
+	testLimitServer(t, map[string]string{api.LimitRead: "1", api.LimitWrite: "1"}, func(client func(string) *sdk.Client, p *structs.MockProvider) {
+		p.On("AppList").Return(structs.Apps{}, nil).Once()
+		p.On("ServiceRestart", "app1", "web").Return(nil).Once()
+
+		_, err := client("pass1").AppList()
+		require.NoError(t, err)
+
+		err = client("pass1").ServiceRestart("app1", "web")
+		require.NoError(t, err)
+
+		err = client("pass1").ServiceRestart("app1", "web")
+		require.EqualError(t, err, "rate limit exceeded for write requests")
+	})
+}
+
+func TestLimitPerToken(t *testing.T) {
+	testLimitServer(t, map[string]string{api.LimitRead: "1"}, func(client func(string) *sdk.Client, p *structs.MockProvider) {
+		p.On("TokenAuthenticate", "secret1").Return(&structs.Token{Id: "T1", Name: "ci", Role: "read-only"}, nil)
+		p.On("TokenAuthenticate", "secret2").Return(&structs.Token{Id: "T2", Name: "dash", Role: "read-only"}, nil)
+		p.On("AppList").Return(structs.Apps{}, nil).Twice()
+
+		_, err := client("secret1").AppList()
+		require.NoError(t, err)
+
+		_, err = client("secret2").AppList()
+		require.NoError(t, err)
+
+		_, err = client("secret1").AppList()
+		require.EqualError(t, err, "rate limit exceeded for read requests")
+	})
+}
+
+func TestLimitConcurrency(t *testing.T) {
+	testLimitServer(t, map[string]string{api.LimitConcurrency: "1"}, func(client func(string) *sdk.Client, p *structs.MockProvider) {
+		started := make(chan bool)
+		finish := make(chan bool)
+
+		p.On("AppList").Return(structs.Apps{}, nil).Once().Run(func(args mock.Arguments) {
+			started <- true
+			<-finish
+		})
+
+		done := make(chan error)
+
+		go func() {
+			_, err := client("pass1").AppList()
+			done <- err
+		}()
+
+		<-started
+
+		_, err := client("pass1").AppList()
+		require.EqualError(t, err, "too many concurrent requests")
+
+		finish <- true
+
+		require.NoError(t, <-done)
+	})
+}
+
+func TestLimitDisabled(t *testing.T) {
+	testLimitServer(t, map[string]string{api.LimitRead: "0"}, func(client func(string) *sdk.Client, p *structs.MockProvider) {
+		p.On("AppList").Return(structs.Apps{}, nil).Times(5)
+
+		for i := 0; i < 5; i++ {
+			_, err := client("pass1").AppList()
+			require.NoError(t, err)
+		}
+	})
+}
+
+func TestLimitInvalid(t *testing.T) {
+	p := &structs.MockProvider{}
+	p.On("Initialize", mock.Anything).Return(nil)
+	p.On("Start").Return(nil)
+
+	s := api.NewWithProvider(p)
+
+	err := s.UpdateLimits(map[string]string{api.LimitRead: "lots"})
+	require.EqualError(t, err, "invalid value for RateLimitRead: lots")
+}
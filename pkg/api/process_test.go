@@ -31,6 +31,65 @@ var fxProcess = structs.Process{
 	Status:   "status",
 }
 
+func TestProcessDebug(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		a1 := fxApp
+		p.On("AppGet", "app1").Return(&a1, nil)
+		opts := structs.ProcessDebugOptions{
+			Command: options.String("sh"),
+			Height:  options.Int(1),
+			Image:   options.String("busybox"),
+			Tty:     options.Bool(true),
+			Width:   options.Int(2),
+		}
+		ro := stdsdk.RequestOptions{
+			Body: strings.NewReader("in"),
+			Headers: stdsdk.Headers{
+				"Command": "sh",
+				"Height":  "1",
+				"Image":   "busybox",
+				"Width":   "2",
+			},
+		}
+		p.On("ProcessDebug", "app1", "pid1", mock.Anything, opts).Return(1, nil).Run(func(args mock.Arguments) {
+			rw := args.Get(2).(io.ReadWriter)
+			rw.Write([]byte("out"))
+			data, err := ioutil.ReadAll(rw)
+			require.NoError(t, err)
+			require.Equal(t, "in", string(data))
+		})
+		r, err := c.Websocket("/apps/app1/processes/pid1/debug", ro)
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, "outF1E49A85-0AD7-4AEF-A618-C249C6E6568D:1\n", string(data))
+	})
+}
+
+func TestProcessDebugError(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		a1 := fxApp
+		p.On("AppGet", "app1").Return(&a1, nil)
+		p.On("ProcessDebug", "app1", "pid1", mock.Anything, structs.ProcessDebugOptions{Tty: options.Bool(true)}).Return(0, fmt.Errorf("err1"))
+		r, err := c.Websocket("/apps/app1/processes/pid1/debug", stdsdk.RequestOptions{})
+		require.NoError(t, err)
+		d, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, []byte("ERROR: err1\n"), d)
+	})
+}
+
+func TestProcessDebugValidate(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		p.On("AppGet", "app1").Return(nil, fmt.Errorf("no such app: app1"))
+		r, err := c.Websocket("/apps/app1/processes/pid1/debug", stdsdk.RequestOptions{})
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, "ERROR: no such app: app1\n", string(data))
+	})
+}
+
 func TestProcessExec(t *testing.T) {
 	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
 		a1 := fxApp
@@ -103,6 +162,40 @@ func TestProcessExecValidate(t *testing.T) {
 	})
 }
 
+func TestProcessForward(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		ro := stdsdk.RequestOptions{
+			Body: strings.NewReader("in"),
+			Headers: stdsdk.Headers{
+				"Protocol": "udp",
+			},
+		}
+		p.On("ProcessForward", "app1", "pid1", 5000, mock.Anything, structs.ProcessForwardOptions{Protocol: options.String("udp")}).Return(nil).Run(func(args mock.Arguments) {
+			rw := args.Get(3).(io.ReadWriter)
+			rw.Write([]byte("out"))
+			data, err := ioutil.ReadAll(rw)
+			require.NoError(t, err)
+			require.Equal(t, "in", string(data))
+		})
+		r, err := c.Websocket("/apps/app1/processes/pid1/forward/5000", ro)
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, "out", string(data))
+	})
+}
+
+func TestProcessForwardError(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		p.On("ProcessForward", "app1", "pid1", 5000, mock.Anything, structs.ProcessForwardOptions{Protocol: options.String("tcp")}).Return(fmt.Errorf("err1"))
+		r, err := c.Websocket("/apps/app1/processes/pid1/forward/5000", stdsdk.RequestOptions{})
+		require.NoError(t, err)
+		d, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, []byte("ERROR: err1\n"), d)
+	})
+}
+
 func TestProcessGet(t *testing.T) {
 	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
 		p1 := fxProcess
@@ -143,6 +236,30 @@ func TestProcessList(t *testing.T) {
 	})
 }
 
+func TestProcessListHistory(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		code := 1
+		ended := time.Now().UTC()
+		p1 := fxProcess
+		p1.Ended = &ended
+		p1.ExitCode = &code
+		p1.Identity = "token1"
+		p2 := structs.Processes{}
+		opts := structs.ProcessListOptions{
+			History: options.Bool(true),
+		}
+		ro := stdsdk.RequestOptions{
+			Query: stdsdk.Query{
+				"history": "true",
+			},
+		}
+		p.On("ProcessList", "app1", opts).Return(structs.Processes{p1}, nil)
+		err := c.Get("/apps/app1/processes", ro, &p2)
+		require.NoError(t, err)
+		require.Equal(t, structs.Processes{p1}, p2)
+	})
+}
+
 func TestProcessListError(t *testing.T) {
 	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
 		var p1 structs.Processes
@@ -163,6 +280,7 @@ func TestProcessRun(t *testing.T) {
 			Command:     options.String("command"),
 			Environment: map[string]string{"k1": "v1", "k2": "v2"},
 			Height:      options.Int(1),
+			Identity:    options.String("password"),
 			Memory:      options.Int(2),
 			Release:     options.String("release"),
 			Width:       options.Int(3),
@@ -185,6 +303,29 @@ func TestProcessRun(t *testing.T) {
 	})
 }
 
+func TestProcessRunIdentity(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		a1 := fxApp
+		p.On("AppGet", "app1").Return(&a1, nil)
+		p1 := fxProcess
+		p2 := structs.Process{}
+		opts := structs.ProcessRunOptions{
+			Command:  options.String("command"),
+			Identity: options.String("password"),
+		}
+		ro := stdsdk.RequestOptions{
+			Headers: stdsdk.Headers{
+				"Command":  "command",
+				"Identity": "someone-else",
+			},
+		}
+		p.On("ProcessRun", "app1", "service1", opts).Return(&p1, nil)
+		err := c.Post("/apps/app1/services/service1/processes", ro, &p2)
+		require.NoError(t, err)
+		require.Equal(t, p1, p2)
+	})
+}
+
 func TestProcessRunError(t *testing.T) {
 	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
 		a1 := fxApp
@@ -194,6 +335,7 @@ func TestProcessRunError(t *testing.T) {
 			Command:     options.String("command"),
 			Environment: map[string]string{"k1": "v1", "k2": "v2"},
 			Height:      options.Int(1),
+			Identity:    options.String("password"),
 			Memory:      options.Int(2),
 			Release:     options.String("release"),
 			Width:       options.Int(3),
@@ -41,6 +41,29 @@ func TestReleaseCreate(t *testing.T) {
 	})
 }
 
+func TestReleaseCreateEnvMeta(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		r1 := fxRelease
+		r2 := structs.Release{}
+		opts := structs.ReleaseCreateOptions{
+			Build:   options.String("build1"),
+			Env:     options.String("env"),
+			EnvMeta: options.String(`{"FOO":{"sensitive":true}}`),
+		}
+		ro := stdsdk.RequestOptions{
+			Params: stdsdk.Params{
+				"build":    "build1",
+				"env":      "env",
+				"env-meta": `{"FOO":{"sensitive":true}}`,
+			},
+		}
+		p.On("ReleaseCreate", "app1", opts).Return(&r1, nil)
+		err := c.Post("/apps/app1/releases", ro, &r2)
+		require.NoError(t, err)
+		require.Equal(t, r1, r2)
+	})
+}
+
 func TestReleaseCreateError(t *testing.T) {
 	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
 		var r1 *structs.Release
@@ -61,6 +84,46 @@ func TestReleaseCreateError(t *testing.T) {
 	})
 }
 
+func TestReleaseDiff(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		d1 := &structs.ReleaseDiff{
+			App:   "app1",
+			From:  "release1",
+			To:    "release2",
+			Build: &structs.ReleaseDiffBuild{From: "build1", To: "build2", Description: "desc"},
+			Env:   []structs.ReleaseDiffChange{{Change: "added", Name: "FOO", To: "bar"}},
+			Services: []structs.ReleaseDiffService{
+				{Change: "changed", Name: "web", Changes: []structs.ReleaseDiffChange{{Change: "changed", Name: "port", From: "http:3000", To: "https:3000"}}},
+			},
+		}
+		d2 := &structs.ReleaseDiff{}
+		opts := structs.ReleaseDiffOptions{
+			From:   options.String("release1"),
+			Reveal: options.Bool(true),
+		}
+		ro := stdsdk.RequestOptions{
+			Query: stdsdk.Query{
+				"from":   "release1",
+				"reveal": "true",
+			},
+		}
+		p.On("ReleaseDiff", "app1", "release2", opts).Return(d1, nil)
+		err := c.Get("/apps/app1/releases/release2/diff", ro, d2)
+		require.NoError(t, err)
+		require.Equal(t, d1, d2)
+	})
+}
+
+func TestReleaseDiffError(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		var d1 *structs.ReleaseDiff
+		p.On("ReleaseDiff", "app1", "release2", structs.ReleaseDiffOptions{}).Return(nil, fmt.Errorf("err1"))
+		err := c.Get("/apps/app1/releases/release2/diff", stdsdk.RequestOptions{}, d1)
+		require.Nil(t, d1)
+		require.EqualError(t, err, "err1")
+	})
+}
+
 func TestReleaseGet(t *testing.T) {
 	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
 		r1 := fxRelease
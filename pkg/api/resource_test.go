@@ -3,6 +3,7 @@ package api_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/convox/convox/pkg/options"
 	"github.com/convox/convox/pkg/structs"
@@ -19,6 +20,12 @@ var fxResource = structs.Resource{
 	Apps:       structs.Apps{fxApp, fxApp},
 }
 
+var fxResourceBackup = structs.ResourceBackup{
+	Created: time.Now().UTC(),
+	Id:      "20200101030000",
+	Size:    1024,
+}
+
 var fxResourceType = structs.ResourceType{
 	Name: "name",
 	Parameters: structs.ResourceParameters{
@@ -27,6 +34,27 @@ var fxResourceType = structs.ResourceType{
 	},
 }
 
+func TestResourceBackupList(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		r1 := structs.ResourceBackups{fxResourceBackup, fxResourceBackup}
+		r2 := structs.ResourceBackups{}
+		p.On("ResourceBackupList", "app1", "resource1").Return(r1, nil)
+		err := c.Get("/apps/app1/resources/resource1/backups", stdsdk.RequestOptions{}, &r2)
+		require.NoError(t, err)
+		require.Equal(t, r1, r2)
+	})
+}
+
+func TestResourceBackupListError(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		var r1 structs.ResourceBackups
+		p.On("ResourceBackupList", "app1", "resource1").Return(nil, fmt.Errorf("err1"))
+		err := c.Get("/apps/app1/resources/resource1/backups", stdsdk.RequestOptions{}, &r1)
+		require.EqualError(t, err, "err1")
+		require.Nil(t, r1)
+	})
+}
+
 func TestResourceGet(t *testing.T) {
 	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
 		r1 := fxResource
@@ -69,6 +97,55 @@ func TestResourceListError(t *testing.T) {
 	})
 }
 
+func TestResourceRestore(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		p.On("ResourceRestore", "app1", "resource1", "20200101030000").Return(nil)
+		err := c.Post("/apps/app1/resources/resource1/backups/20200101030000/restore", stdsdk.RequestOptions{}, nil)
+		require.NoError(t, err)
+	})
+}
+
+func TestResourceRestoreError(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		p.On("ResourceRestore", "app1", "resource1", "20200101030000").Return(fmt.Errorf("err1"))
+		err := c.Post("/apps/app1/resources/resource1/backups/20200101030000/restore", stdsdk.RequestOptions{}, nil)
+		require.EqualError(t, err, "err1")
+	})
+}
+
+func TestResourceUpdate(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		cs1 := structs.ResourceChanges{
+			{Action: "migrate", Current: "10.5", Desired: "11.2", Parameter: "version"},
+			{Action: "expand", Current: "10Gi", Desired: "20Gi", Parameter: "storage"},
+		}
+		cs2 := structs.ResourceChanges{}
+		opts := structs.ResourceUpdateOptions{
+			DryRun:  options.Bool(true),
+			Release: options.String("release1"),
+		}
+		ro := stdsdk.RequestOptions{
+			Params: stdsdk.Params{
+				"dry-run": "true",
+				"release": "release1",
+			},
+		}
+		p.On("ResourceUpdate", "app1", "resource1", opts).Return(cs1, nil)
+		err := c.Put("/apps/app1/resources/resource1", ro, &cs2)
+		require.NoError(t, err)
+		require.Equal(t, cs1, cs2)
+	})
+}
+
+func TestResourceUpdateError(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		var cs1 structs.ResourceChanges
+		p.On("ResourceUpdate", "app1", "resource1", structs.ResourceUpdateOptions{}).Return(nil, fmt.Errorf("err1"))
+		err := c.Put("/apps/app1/resources/resource1", stdsdk.RequestOptions{}, &cs1)
+		require.EqualError(t, err, "err1")
+	})
+}
+
 func TestSystemResourceCreate(t *testing.T) {
 	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
 		r1 := fxResource
@@ -11,6 +11,8 @@ func (s *Server) setupRoutes(r stdapi.Router) {
 	r.Route("SOCKET", "/apps/{name}/logs", s.AppLogs)
 	r.Route("GET", "/apps/{name}/metrics", s.AppMetrics)
 	r.Route("PUT", "/apps/{name}", s.AppUpdate)
+	r.Route("GET", "/system/audit", s.AuditList)
+	r.Route("", "", s.AuditRecord)
 	r.Route("GET", "/apps/{app}/balancers", s.BalancerList)
 	r.Route("POST", "/apps/{app}/builds", s.BuildCreate)
 	r.Route("GET", "/apps/{app}/builds/{id}.tgz", s.BuildExport)
@@ -25,7 +27,9 @@ func (s *Server) setupRoutes(r stdapi.Router) {
 	r.Route("DELETE", "/certificates/{id}", s.CertificateDelete)
 	r.Route("POST", "/certificates/generate", s.CertificateGenerate)
 	r.Route("GET", "/certificates", s.CertificateList)
+	r.Route("GET", "/apps/{app}/environment/secrets", s.EnvironmentSecretList)
 	r.Route("POST", "/events", s.EventSend)
+	r.Route("GET", "/apps/{app}/processes/{pid}/files/checksums", s.FilesChecksums)
 	r.Route("DELETE", "/apps/{app}/processes/{pid}/files", s.FilesDelete)
 	r.Route("GET", "/apps/{app}/processes/{pid}/files", s.FilesDownload)
 	r.Route("POST", "/apps/{app}/processes/{pid}/files", s.FilesUpload)
@@ -39,7 +43,9 @@ func (s *Server) setupRoutes(r stdapi.Router) {
 	r.Route("GET", "/apps/{app}/objects/{key:.*}", s.ObjectFetch)
 	r.Route("GET", "/apps/{app}/objects", s.ObjectList)
 	r.Route("POST", "/apps/{app}/objects/{key:.*}", s.ObjectStore)
+	r.Route("SOCKET", "/apps/{app}/processes/{pid}/debug", s.ProcessDebug)
 	r.Route("SOCKET", "/apps/{app}/processes/{pid}/exec", s.ProcessExec)
+	r.Route("SOCKET", "/apps/{app}/processes/{pid}/forward/{port}", s.ProcessForward)
 	r.Route("GET", "/apps/{app}/processes/{pid}", s.ProcessGet)
 	r.Route("GET", "/apps/{app}/processes", s.ProcessList)
 	r.Route("SOCKET", "/apps/{app}/processes/{pid}/logs", s.ProcessLogs)
@@ -50,16 +56,23 @@ func (s *Server) setupRoutes(r stdapi.Router) {
 	r.Route("GET", "/registries", s.RegistryList)
 	r.Route("DELETE", "/registries/{server:.*}", s.RegistryRemove)
 	r.Route("POST", "/apps/{app}/releases", s.ReleaseCreate)
+	r.Route("GET", "/apps/{app}/releases/{id}/diff", s.ReleaseDiff)
 	r.Route("GET", "/apps/{app}/releases/{id}", s.ReleaseGet)
 	r.Route("GET", "/apps/{app}/releases", s.ReleaseList)
 	r.Route("POST", "/apps/{app}/releases/{id}/promote", s.ReleasePromote)
+	r.Route("GET", "/apps/{app}/resources/{name}/backups", s.ResourceBackupList)
 	r.Route("SOCKET", "/apps/{app}/resources/{name}/console", s.ResourceConsole)
 	r.Route("GET", "/apps/{app}/resources/{name}/data", s.ResourceExport)
 	r.Route("GET", "/apps/{app}/resources/{name}", s.ResourceGet)
 	r.Route("PUT", "/apps/{app}/resources/{name}/data", s.ResourceImport)
 	r.Route("GET", "/apps/{app}/resources", s.ResourceList)
+	r.Route("POST", "/apps/{app}/resources/{name}/backups/{id}/restore", s.ResourceRestore)
+	r.Route("PUT", "/apps/{app}/resources/{name}", s.ResourceUpdate)
+	r.Route("SOCKET", "/apps/{app}/services/{name}/forward/{port}", s.ServiceForward)
 	r.Route("GET", "/apps/{app}/services", s.ServiceList)
 	r.Route("POST", "/apps/{app}/services/{name}/restart", s.ServiceRestart)
+	r.Route("DELETE", "/apps/{app}/services/{name}/schedules", s.ServiceScheduleDelete)
+	r.Route("POST", "/apps/{app}/services/{name}/schedules", s.ServiceScheduleSet)
 	r.Route("PUT", "/apps/{app}/services/{name}", s.ServiceUpdate)
 	r.Route("", "", s.Start)
 	r.Route("GET", "/system", s.SystemGet)
@@ -78,6 +91,18 @@ func (s *Server) setupRoutes(r stdapi.Router) {
 	r.Route("PUT", "/resources/{name}", s.SystemResourceUpdate)
 	r.Route("", "", s.SystemUninstall)
 	r.Route("PUT", "/system", s.SystemUpdate)
+	r.Route("GET", "/apps/{app}/timers", s.TimerList)
+	r.Route("POST", "/apps/{app}/timers/{name}/resume", s.TimerResume)
+	r.Route("POST", "/apps/{app}/timers/{name}/run", s.TimerRun)
+	r.Route("POST", "/apps/{app}/timers/{name}/suspend", s.TimerSuspend)
+	r.Route("", "", s.TokenAuthenticate)
+	r.Route("POST", "/system/tokens", s.TokenCreate)
+	r.Route("DELETE", "/system/tokens/{id}", s.TokenDelete)
+	r.Route("GET", "/system/tokens", s.TokenList)
+	r.Route("GET", "/apps/{app}/volumes", s.VolumeList)
+	r.Route("POST", "/apps/{app}/volumes/{name}/snapshots/{id}/restore", s.VolumeRestore)
+	r.Route("POST", "/apps/{app}/volumes/{name}/snapshots", s.VolumeSnapshot)
+	r.Route("GET", "/apps/{app}/volumes/{name}/snapshots", s.VolumeSnapshotList)
 	r.Route("", "", s.Workers)
 }
 
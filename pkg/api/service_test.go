@@ -2,11 +2,15 @@ package api_test
 
 import (
 	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
 	"testing"
 
 	"github.com/convox/convox/pkg/options"
 	"github.com/convox/convox/pkg/structs"
 	"github.com/convox/stdsdk"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -22,6 +26,37 @@ var fxService = structs.Service{
 	},
 }
 
+func TestServiceForward(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		ro := stdsdk.RequestOptions{
+			Body: strings.NewReader("in"),
+		}
+		p.On("ServiceForward", "app1", "service1", 5000, mock.Anything, structs.ServiceForwardOptions{Protocol: options.String("tcp")}).Return(nil).Run(func(args mock.Arguments) {
+			rw := args.Get(3).(io.ReadWriter)
+			rw.Write([]byte("out"))
+			data, err := ioutil.ReadAll(rw)
+			require.NoError(t, err)
+			require.Equal(t, "in", string(data))
+		})
+		r, err := c.Websocket("/apps/app1/services/service1/forward/5000", ro)
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, "out", string(data))
+	})
+}
+
+func TestServiceForwardError(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		p.On("ServiceForward", "app1", "service1", 5000, mock.Anything, structs.ServiceForwardOptions{Protocol: options.String("tcp")}).Return(fmt.Errorf("err1"))
+		r, err := c.Websocket("/apps/app1/services/service1/forward/5000", stdsdk.RequestOptions{})
+		require.NoError(t, err)
+		d, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, []byte("ERROR: err1\n"), d)
+	})
+}
+
 func TestServiceList(t *testing.T) {
 	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
 		s1 := structs.Services{fxService, fxService}
@@ -70,3 +105,49 @@ func TestServiceUpdateError(t *testing.T) {
 		require.EqualError(t, err, "err1")
 	})
 }
+
+func TestServiceScheduleDelete(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		ro := stdsdk.RequestOptions{
+			Query: stdsdk.Query{
+				"schedule": "0 8 * * 1-5",
+			},
+		}
+		p.On("ServiceScheduleDelete", "app1", "service1", "0 8 * * 1-5").Return(nil)
+		err := c.Delete("/apps/app1/services/service1/schedules", ro, nil)
+		require.NoError(t, err)
+	})
+}
+
+func TestServiceScheduleDeleteError(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		p.On("ServiceScheduleDelete", "app1", "service1", "").Return(fmt.Errorf("err1"))
+		err := c.Delete("/apps/app1/services/service1/schedules", stdsdk.RequestOptions{}, nil)
+		require.EqualError(t, err, "err1")
+	})
+}
+
+func TestServiceScheduleSet(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		opts := structs.ServiceScheduleSetOptions{
+			Count: options.Int(10),
+		}
+		ro := stdsdk.RequestOptions{
+			Params: stdsdk.Params{
+				"count":    "10",
+				"schedule": "0 8 * * 1-5",
+			},
+		}
+		p.On("ServiceScheduleSet", "app1", "service1", "0 8 * * 1-5", opts).Return(nil)
+		err := c.Post("/apps/app1/services/service1/schedules", ro, nil)
+		require.NoError(t, err)
+	})
+}
+
+func TestServiceScheduleSetError(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		p.On("ServiceScheduleSet", "app1", "service1", "", structs.ServiceScheduleSetOptions{}).Return(fmt.Errorf("err1"))
+		err := c.Post("/apps/app1/services/service1/schedules", stdsdk.RequestOptions{}, nil)
+		require.EqualError(t, err, "err1")
+	})
+}
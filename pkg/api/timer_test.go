@@ -0,0 +1,94 @@
+package api_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/convox/convox/pkg/structs"
+	"github.com/convox/stdsdk"
+	"github.com/stretchr/testify/require"
+)
+
+var fxTimer = structs.Timer{
+	Command:     "bin/cleanup",
+	Concurrency: "forbid",
+	LastRun:     &fxTimerLastRun,
+	LastStatus:  "succeeded",
+	Name:        "timer1",
+	Schedule:    "0 3 * * *",
+	Service:     "service1",
+	Suspended:   false,
+	Timezone:    "America/New_York",
+}
+
+var fxTimerLastRun = time.Now().UTC()
+
+func TestTimerList(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		t1 := structs.Timers{fxTimer, fxTimer}
+		t2 := structs.Timers{}
+		p.On("TimerList", "app1").Return(t1, nil)
+		err := c.Get("/apps/app1/timers", stdsdk.RequestOptions{}, &t2)
+		require.NoError(t, err)
+		require.Equal(t, t1, t2)
+	})
+}
+
+func TestTimerListError(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		var t1 structs.Timers
+		p.On("TimerList", "app1").Return(nil, fmt.Errorf("err1"))
+		err := c.Get("/apps/app1/timers", stdsdk.RequestOptions{}, &t1)
+		require.EqualError(t, err, "err1")
+		require.Nil(t, t1)
+	})
+}
+
+func TestTimerResume(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		p.On("TimerResume", "app1", "timer1").Return(nil)
+		err := c.Post("/apps/app1/timers/timer1/resume", stdsdk.RequestOptions{}, nil)
+		require.NoError(t, err)
+	})
+}
+
+func TestTimerResumeError(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		p.On("TimerResume", "app1", "timer1").Return(fmt.Errorf("err1"))
+		err := c.Post("/apps/app1/timers/timer1/resume", stdsdk.RequestOptions{}, nil)
+		require.EqualError(t, err, "err1")
+	})
+}
+
+func TestTimerRun(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		p.On("TimerRun", "app1", "timer1").Return(nil)
+		err := c.Post("/apps/app1/timers/timer1/run", stdsdk.RequestOptions{}, nil)
+		require.NoError(t, err)
+	})
+}
+
+func TestTimerRunError(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		p.On("TimerRun", "app1", "timer1").Return(fmt.Errorf("err1"))
+		err := c.Post("/apps/app1/timers/timer1/run", stdsdk.RequestOptions{}, nil)
+		require.EqualError(t, err, "err1")
+	})
+}
+
+func TestTimerSuspend(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		p.On("TimerSuspend", "app1", "timer1").Return(nil)
+		err := c.Post("/apps/app1/timers/timer1/suspend", stdsdk.RequestOptions{}, nil)
+		require.NoError(t, err)
+	})
+}
+
+func TestTimerSuspendError(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		p.On("TimerSuspend", "app1", "timer1").Return(fmt.Errorf("err1"))
+		err := c.Post("/apps/app1/timers/timer1/suspend", stdsdk.RequestOptions{}, nil)
+		require.EqualError(t, err, "err1")
+	})
+}
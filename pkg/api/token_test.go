@@ -0,0 +1,98 @@
+package api_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/convox/convox/pkg/options"
+	"github.com/convox/convox/pkg/structs"
+	"github.com/convox/stdsdk"
+	"github.com/stretchr/testify/require"
+)
+
+var fxToken = structs.Token{
+	Id:      "TABCDEFGHI",
+	Name:    "ci",
+	Role:    "deployer",
+	Apps:    []string{"app1", "app2"},
+	Created: time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC),
+}
+
+func TestTokenCreate(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		t1 := fxToken
+		t1.Secret = "secret1"
+		t2 := structs.Token{}
+		opts := structs.TokenCreateOptions{
+			Apps: []string{"app1", "app2"},
+			Role: options.String("deployer"),
+		}
+		ro := stdsdk.RequestOptions{
+			Params: stdsdk.Params{
+				"apps": "app1,app2",
+				"name": "ci",
+				"role": "deployer",
+			},
+		}
+		p.On("TokenCreate", "ci", opts).Return(&t1, nil)
+		err := c.Post("/system/tokens", ro, &t2)
+		require.NoError(t, err)
+		require.Equal(t, t1, t2)
+	})
+}
+
+func TestTokenCreateError(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		var t1 *structs.Token
+		opts := structs.TokenCreateOptions{
+			Role: options.String("read-only"),
+		}
+		ro := stdsdk.RequestOptions{
+			Params: stdsdk.Params{
+				"name": "ci",
+			},
+		}
+		p.On("TokenCreate", "ci", opts).Return(nil, fmt.Errorf("err1"))
+		err := c.Post("/system/tokens", ro, &t1)
+		require.EqualError(t, err, "err1")
+		require.Nil(t, t1)
+	})
+}
+
+func TestTokenDelete(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		p.On("TokenDelete", "TABCDEFGHI").Return(nil)
+		err := c.Delete("/system/tokens/TABCDEFGHI", stdsdk.RequestOptions{}, nil)
+		require.NoError(t, err)
+	})
+}
+
+func TestTokenDeleteError(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		p.On("TokenDelete", "TABCDEFGHI").Return(fmt.Errorf("err1"))
+		err := c.Delete("/system/tokens/TABCDEFGHI", stdsdk.RequestOptions{}, nil)
+		require.EqualError(t, err, "err1")
+	})
+}
+
+func TestTokenList(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		t1 := structs.Tokens{fxToken, fxToken}
+		t2 := structs.Tokens{}
+		p.On("TokenList").Return(t1, nil)
+		err := c.Get("/system/tokens", stdsdk.RequestOptions{}, &t2)
+		require.NoError(t, err)
+		require.Equal(t, t1, t2)
+	})
+}
+
+func TestTokenListError(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		var t1 structs.Tokens
+		p.On("TokenList").Return(nil, fmt.Errorf("err1"))
+		err := c.Get("/system/tokens", stdsdk.RequestOptions{}, &t1)
+		require.EqualError(t, err, "err1")
+		require.Nil(t, t1)
+	})
+}
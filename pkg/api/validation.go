@@ -20,6 +20,14 @@ func (s *Server) AppCancelValidate(c *stdapi.Context) error {
 	return nil
 }
 
+func (s *Server) ProcessDebugValidate(c *stdapi.Context) error {
+	if _, err := s.Provider.AppGet(c.Var("app")); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (s *Server) ProcessExecValidate(c *stdapi.Context) error {
 	if _, err := s.Provider.AppGet(c.Var("app")); err != nil {
 		return err
@@ -33,6 +41,9 @@ func (s *Server) ProcessRunValidate(c *stdapi.Context) error {
 		return err
 	}
 
+	// recorded in process history, always set here so that clients can not supply their own
+	c.Request().Header.Set("Identity", identity(c))
+
 	return nil
 }
 
@@ -0,0 +1,117 @@
+package api_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/convox/convox/pkg/options"
+	"github.com/convox/convox/pkg/structs"
+	"github.com/convox/stdsdk"
+	"github.com/stretchr/testify/require"
+)
+
+var fxVolume = structs.Volume{
+	Access:   "ReadWriteOnce",
+	Class:    "standard",
+	Name:     "data",
+	Services: []string{"web"},
+	Size:     10737418240,
+	Status:   "bound",
+	Used:     1024,
+}
+
+var fxVolumeSnapshot = structs.VolumeSnapshot{
+	Created: time.Now().UTC(),
+	Id:      "data-20200101030000",
+	Size:    10737418240,
+	Status:  "ready",
+	Volume:  "data",
+}
+
+func TestVolumeList(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		v1 := structs.Volumes{fxVolume, fxVolume}
+		v2 := structs.Volumes{}
+		p.On("VolumeList", "app1").Return(v1, nil)
+		err := c.Get("/apps/app1/volumes", stdsdk.RequestOptions{}, &v2)
+		require.NoError(t, err)
+		require.Equal(t, v1, v2)
+	})
+}
+
+func TestVolumeListError(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		var v1 structs.Volumes
+		p.On("VolumeList", "app1").Return(nil, fmt.Errorf("err1"))
+		err := c.Get("/apps/app1/volumes", stdsdk.RequestOptions{}, &v1)
+		require.EqualError(t, err, "err1")
+		require.Nil(t, v1)
+	})
+}
+
+func TestVolumeRestore(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		opts := structs.VolumeRestoreOptions{
+			SourceApp: options.String("app2"),
+		}
+		ro := stdsdk.RequestOptions{
+			Params: stdsdk.Params{
+				"source-app": "app2",
+			},
+		}
+		p.On("VolumeRestore", "app1", "data", "data-20200101030000", opts).Return(nil)
+		err := c.Post("/apps/app1/volumes/data/snapshots/data-20200101030000/restore", ro, nil)
+		require.NoError(t, err)
+	})
+}
+
+func TestVolumeRestoreError(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		p.On("VolumeRestore", "app1", "data", "data-20200101030000", structs.VolumeRestoreOptions{}).Return(fmt.Errorf("err1"))
+		err := c.Post("/apps/app1/volumes/data/snapshots/data-20200101030000/restore", stdsdk.RequestOptions{}, nil)
+		require.EqualError(t, err, "err1")
+	})
+}
+
+func TestVolumeSnapshot(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		v1 := fxVolumeSnapshot
+		v2 := structs.VolumeSnapshot{}
+		p.On("VolumeSnapshot", "app1", "data").Return(&v1, nil)
+		err := c.Post("/apps/app1/volumes/data/snapshots", stdsdk.RequestOptions{}, &v2)
+		require.NoError(t, err)
+		require.Equal(t, v1, v2)
+	})
+}
+
+func TestVolumeSnapshotError(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		var v1 *structs.VolumeSnapshot
+		p.On("VolumeSnapshot", "app1", "data").Return(nil, fmt.Errorf("err1"))
+		err := c.Post("/apps/app1/volumes/data/snapshots", stdsdk.RequestOptions{}, v1)
+		require.EqualError(t, err, "err1")
+		require.Nil(t, v1)
+	})
+}
+
+func TestVolumeSnapshotList(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		v1 := structs.VolumeSnapshots{fxVolumeSnapshot, fxVolumeSnapshot}
+		v2 := structs.VolumeSnapshots{}
+		p.On("VolumeSnapshotList", "app1", "data").Return(v1, nil)
+		err := c.Get("/apps/app1/volumes/data/snapshots", stdsdk.RequestOptions{}, &v2)
+		require.NoError(t, err)
+		require.Equal(t, v1, v2)
+	})
+}
+
+func TestVolumeSnapshotListError(t *testing.T) {
+	testServer(t, func(c *stdsdk.Client, p *structs.MockProvider) {
+		var v1 structs.VolumeSnapshots
+		p.On("VolumeSnapshotList", "app1", "data").Return(nil, fmt.Errorf("err1"))
+		err := c.Get("/apps/app1/volumes/data/snapshots", stdsdk.RequestOptions{}, &v1)
+		require.EqualError(t, err, "err1")
+		require.Nil(t, v1)
+	})
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"bytes"
 	"compress/gzip"
 	"encoding/json"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/convox/convox/pkg/common"
 	"github.com/convox/convox/pkg/options"
@@ -20,7 +22,7 @@ import (
 
 func init() {
 	register("apps", "list apps", Apps, stdcli.CommandOptions{
-		Flags:    []stdcli.Flag{flagRack},
+		Flags:    []stdcli.Flag{flagRack, flagFormat},
 		Validate: stdcli.Args(0),
 	})
 
@@ -63,7 +65,7 @@ func init() {
 	})
 
 	register("apps info", "get information about an app", AppsInfo, stdcli.CommandOptions{
-		Flags:    []stdcli.Flag{flagApp, flagRack},
+		Flags:    []stdcli.Flag{flagApp, flagRack, flagFormat},
 		Usage:    "[app]",
 		Validate: stdcli.ArgsMax(1),
 	})
@@ -74,8 +76,26 @@ func init() {
 		Validate: stdcli.ArgsMax(1),
 	})
 
-	register("apps params", "display app parameters", AppsParams, stdcli.CommandOptions{
+	register("apps maintenance", "serve a maintenance page instead of the app", AppsMaintenance, stdcli.CommandOptions{
+		Flags:    []stdcli.Flag{flagApp, flagRack},
+		Usage:    "<on|off>",
+		Validate: stdcli.Args(1),
+	})
+
+	register("apps pages set", "set a custom page served by the router", AppsPagesSet, stdcli.CommandOptions{
 		Flags:    []stdcli.Flag{flagApp, flagRack},
+		Usage:    "<502|504|maintenance|waking> <file>",
+		Validate: stdcli.Args(2),
+	})
+
+	register("apps pages unset", "remove a custom page served by the router", AppsPagesUnset, stdcli.CommandOptions{
+		Flags:    []stdcli.Flag{flagApp, flagRack},
+		Usage:    "<502|504|maintenance|waking>",
+		Validate: stdcli.Args(1),
+	})
+
+	register("apps params", "display app parameters", AppsParams, stdcli.CommandOptions{
+		Flags:    []stdcli.Flag{flagApp, flagRack, flagFormat},
 		Usage:    "[app]",
 		Validate: stdcli.ArgsMax(1),
 	})
@@ -105,10 +125,32 @@ func Apps(rack sdk.Interface, c *stdcli.Context) error {
 		return err
 	}
 
-	t := c.Table("APP", "STATUS", "RELEASE")
+	if formatted(c) {
+		return printFormatted(c, as)
+	}
+
+	expiring := false
 
 	for _, a := range as {
-		t.AddRow(a.Name, a.Status, a.Release)
+		if !a.Expires().IsZero() {
+			expiring = true
+		}
+	}
+
+	if !expiring {
+		t := c.Table("APP", "STATUS", "RELEASE")
+
+		for _, a := range as {
+			t.AddRow(a.Name, a.Status, a.Release)
+		}
+
+		return t.Print()
+	}
+
+	t := c.Table("APP", "STATUS", "RELEASE", "EXPIRES")
+
+	for _, a := range as {
+		t.AddRow(a.Name, a.Status, a.Release, common.Ago(a.Expires()))
 	}
 
 	return t.Print()
@@ -135,7 +177,11 @@ func AppsCreate(rack sdk.Interface, c *stdcli.Context) error {
 		return err
 	}
 
-	c.Startf("Creating <app>%s</app>", app)
+	if opts.CloneFrom != nil {
+		c.Startf("Creating <app>%s</app> from <app>%s</app>", app, *opts.CloneFrom)
+	} else {
+		c.Startf("Creating <app>%s</app>", app)
+	}
 
 	if _, err := rack.AppCreate(app, opts); err != nil {
 		return err
@@ -228,6 +274,10 @@ func AppsInfo(rack sdk.Interface, c *stdcli.Context) error {
 		return err
 	}
 
+	if formatted(c) {
+		return printFormatted(c, a)
+	}
+
 	i := c.Info()
 
 	i.Add("Name", a.Name)
@@ -235,12 +285,21 @@ func AppsInfo(rack sdk.Interface, c *stdcli.Context) error {
 
 	i.Add("Generation", a.Generation)
 	i.Add("Locked", fmt.Sprintf("%t", a.Locked))
+
+	if a.Maintenance {
+		i.Add("Maintenance", "on")
+	}
+
 	i.Add("Release", a.Release)
 
 	if a.Router != "" {
 		i.Add("Router", a.Router)
 	}
 
+	if e := a.Expires(); !e.IsZero() {
+		i.Add("Expires", fmt.Sprintf("%s (%s)", e.Format(time.RFC3339), common.Ago(e)))
+	}
+
 	return i.Print()
 }
 
@@ -256,6 +315,77 @@ func AppsLock(rack sdk.Interface, c *stdcli.Context) error {
 	return c.OK()
 }
 
+func AppsMaintenance(rack sdk.Interface, c *stdcli.Context) error {
+	var on bool
+
+	switch c.Arg(0) {
+	case "on":
+		on = true
+	case "off":
+		on = false
+	default:
+		return fmt.Errorf("maintenance must be on or off")
+	}
+
+	if on {
+		c.Startf("Enabling maintenance for <app>%s</app>", app(c))
+	} else {
+		c.Startf("Disabling maintenance for <app>%s</app>", app(c))
+	}
+
+	if err := rack.AppUpdate(app(c), structs.AppUpdateOptions{Maintenance: options.Bool(on)}); err != nil {
+		return err
+	}
+
+	return c.OK()
+}
+
+func AppsPagesSet(rack sdk.Interface, c *stdcli.Context) error {
+	page := c.Arg(0)
+
+	if err := validatePage(page); err != nil {
+		return err
+	}
+
+	data, err := ioutil.ReadFile(c.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	c.Startf("Setting <id>%s</id> page", page)
+
+	if _, err := rack.ObjectStore(app(c), structs.AppPageKey(page), bytes.NewReader(data), structs.ObjectStoreOptions{}); err != nil {
+		return err
+	}
+
+	// pages are given to the router when the app is promoted
+	if err := rack.AppUpdate(app(c), structs.AppUpdateOptions{}); err != nil {
+		return err
+	}
+
+	return c.OK()
+}
+
+func AppsPagesUnset(rack sdk.Interface, c *stdcli.Context) error {
+	page := c.Arg(0)
+
+	if err := validatePage(page); err != nil {
+		return err
+	}
+
+	c.Startf("Unsetting <id>%s</id> page", page)
+
+	if err := rack.ObjectDelete(app(c), structs.AppPageKey(page)); err != nil {
+		return err
+	}
+
+	if err := rack.AppUpdate(app(c), structs.AppUpdateOptions{}); err != nil {
+		return err
+	}
+
+	return c.OK()
+}
+
 func AppsParams(rack sdk.Interface, c *stdcli.Context) error {
 	s, err := rack.SystemGet()
 	if err != nil {
@@ -287,6 +417,10 @@ func AppsParams(rack sdk.Interface, c *stdcli.Context) error {
 
 	sort.Strings(keys)
 
+	if formatted(c) {
+		return printFormatted(c, params)
+	}
+
 	i := c.Info()
 
 	for _, k := range keys {
@@ -579,3 +713,13 @@ func appImport(rack sdk.Interface, c *stdcli.Context, app string, r io.Reader) e
 
 	return nil
 }
+
+func validatePage(page string) error {
+	for _, p := range structs.AppPages {
+		if p == page {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid page: %s, must be one of: %s", page, strings.Join(structs.AppPages, ", "))
+}
@@ -45,6 +45,69 @@ func TestApps(t *testing.T) {
 	})
 }
 
+func TestAppsJSON(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("AppList").Return(structs.Apps{*fxApp()}, nil)
+
+		res, err := testExecute(e, "apps --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			`[`,
+			`  {`,
+			`    "generation": "2",`,
+			`    "locked": false,`,
+			`    "name": "app1",`,
+			`    "release": "release1",`,
+			`    "router": "",`,
+			`    "status": "running",`,
+			`    "parameters": {`,
+			`      "ParamFoo": "value1",`,
+			`      "ParamOther": "value2",`,
+			`      "ParamPassword": "****"`,
+			`    }`,
+			`  }`,
+			`]`,
+		})
+	})
+}
+
+func TestAppsYAML(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("AppList").Return(structs.Apps{*fxApp()}, nil)
+
+		res, err := testExecute(e, "apps --format yaml", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"- generation: \"2\"",
+			"  locked: false",
+			"  name: app1",
+			"  parameters:",
+			"    ParamFoo: value1",
+			"    ParamOther: value2",
+			"    ParamPassword: '****'",
+			"  release: release1",
+			"  router: \"\"",
+			"  status: running",
+		})
+	})
+}
+
+func TestAppsFormatInvalid(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("AppList").Return(structs.Apps{*fxApp()}, nil)
+
+		res, err := testExecute(e, "apps --format xml", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: unknown format: xml"})
+		res.RequireStdout(t, []string{""})
+	})
+}
+
 func TestAppsError(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("AppList").Return(nil, fmt.Errorf("err1"))
@@ -93,6 +156,33 @@ func TestAppsCancelError(t *testing.T) {
 	})
 }
 
+func TestAppsExpiring(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		a1 := structs.Apps{
+			*fxApp(),
+			structs.App{
+				Name:    "app1-pr-12",
+				Release: "release2",
+				Status:  "running",
+				Tags: map[string]string{
+					structs.AppTagExpires: time.Now().Add(50 * time.Hour).UTC().Format(time.RFC3339),
+				},
+			},
+		}
+		i.On("AppList").Return(a1, nil)
+
+		res, err := testExecute(e, "apps", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"APP         STATUS   RELEASE   EXPIRES        ",
+			"app1        running  release1                 ",
+			"app1-pr-12  running  release2  2 days from now",
+		})
+	})
+}
+
 func TestAppsCreate(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		opts := structs.AppCreateOptions{}
@@ -108,6 +198,24 @@ func TestAppsCreate(t *testing.T) {
 	})
 }
 
+func TestAppsCreateClone(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		opts := structs.AppCreateOptions{
+			CloneFrom: options.String("app1"),
+			Ttl:       options.Duration(72 * time.Hour),
+		}
+		i.On("AppCreate", "app1-pr-12", opts).Return(fxApp(), nil)
+
+		res, err := testExecute(e, "apps create app1-pr-12 --clone-from app1 --ttl 72h", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"Creating app1-pr-12 from app1... OK",
+		})
+	})
+}
+
 func TestAppsCreateError(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		opts := structs.AppCreateOptions{}
@@ -391,6 +499,19 @@ func TestAppsInfo(t *testing.T) {
 	})
 }
 
+func TestAppsInfoJSON(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		v := fxAppRouter()
+		i.On("AppGet", "app1").Return(v, nil)
+
+		res, err := testExecute(e, "apps info app1 --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireJSON(t, v)
+	})
+}
+
 func TestAppsInfoRouter(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("AppGet", "app1").Return(fxApp(), nil)
@@ -434,6 +555,128 @@ func TestAppsInfoError(t *testing.T) {
 
 }
 
+func TestAppsInfoMaintenance(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		a := fxApp()
+		a.Maintenance = true
+		i.On("AppGet", "app1").Return(a, nil)
+
+		res, err := testExecute(e, "apps info app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"Name         app1",
+			"Status       running",
+			"Generation   2",
+			"Locked       false",
+			"Maintenance  on",
+			"Release      release1",
+		})
+	})
+}
+
+func TestAppsMaintenance(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("AppUpdate", "app1", structs.AppUpdateOptions{Maintenance: options.Bool(true)}).Return(nil)
+		i.On("AppUpdate", "app1", structs.AppUpdateOptions{Maintenance: options.Bool(false)}).Return(nil)
+
+		res, err := testExecute(e, "apps maintenance on -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{"Enabling maintenance for app1... OK"})
+
+		res, err = testExecute(e, "apps maintenance off -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{"Disabling maintenance for app1... OK"})
+	})
+}
+
+func TestAppsMaintenanceError(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("AppUpdate", "app1", structs.AppUpdateOptions{Maintenance: options.Bool(true)}).Return(fmt.Errorf("err1"))
+
+		res, err := testExecute(e, "apps maintenance on -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: err1"})
+		res.RequireStdout(t, []string{"Enabling maintenance for app1... "})
+	})
+}
+
+func TestAppsMaintenanceInvalid(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		res, err := testExecute(e, "apps maintenance maybe -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: maintenance must be on or off"})
+		res.RequireStdout(t, []string{""})
+	})
+}
+
+func TestAppsPagesSet(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		tmp, err := ioutil.TempFile("", "")
+		require.NoError(t, err)
+		defer os.Remove(tmp.Name())
+
+		_, err = tmp.WriteString("<p>back soon</p>")
+		require.NoError(t, err)
+		require.NoError(t, tmp.Close())
+
+		i.On("ObjectStore", "app1", "convox/pages/maintenance.html", mock.Anything, structs.ObjectStoreOptions{}).Return(&structs.Object{}, nil).Run(func(args mock.Arguments) {
+			data, err := ioutil.ReadAll(args.Get(2).(io.Reader))
+			require.NoError(t, err)
+			require.Equal(t, "<p>back soon</p>", string(data))
+		})
+		i.On("AppUpdate", "app1", structs.AppUpdateOptions{}).Return(nil)
+
+		res, err := testExecute(e, fmt.Sprintf("apps pages set maintenance %s -a app1", tmp.Name()), nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{"Setting maintenance page... OK"})
+	})
+}
+
+func TestAppsPagesSetInvalid(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		res, err := testExecute(e, "apps pages set 404 page.html -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: invalid page: 404, must be one of: 502, 504, maintenance, waking"})
+		res.RequireStdout(t, []string{""})
+	})
+}
+
+func TestAppsPagesUnset(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("ObjectDelete", "app1", "convox/pages/502.html").Return(nil)
+		i.On("AppUpdate", "app1", structs.AppUpdateOptions{}).Return(nil)
+
+		res, err := testExecute(e, "apps pages unset 502 -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{"Unsetting 502 page... OK"})
+	})
+}
+
+func TestAppsPagesUnsetError(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("ObjectDelete", "app1", "convox/pages/502.html").Return(fmt.Errorf("err1"))
+
+		res, err := testExecute(e, "apps pages unset 502 -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: err1"})
+		res.RequireStdout(t, []string{"Unsetting 502 page... "})
+	})
+}
+
 func TestAppsParams(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("SystemGet").Return(fxSystem(), nil)
@@ -461,6 +704,19 @@ func TestAppsParams(t *testing.T) {
 	})
 }
 
+func TestAppsParamsJSON(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("SystemGet").Return(fxSystem(), nil)
+		i.On("AppGet", "app1").Return(fxApp(), nil)
+
+		res, err := testExecute(e, "apps params app1 --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireJSON(t, fxParameters())
+	})
+}
+
 func TestAppsParamsError(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("SystemGet").Return(fxSystem(), nil)
@@ -25,7 +25,7 @@ func init() {
 	})
 
 	register("builds", "list builds", Builds, stdcli.CommandOptions{
-		Flags:    append(stdcli.OptionFlags(structs.BuildListOptions{}), flagRack, flagApp),
+		Flags:    append(stdcli.OptionFlags(structs.BuildListOptions{}), flagRack, flagApp, flagFormat),
 		Validate: stdcli.Args(0),
 	})
 
@@ -50,7 +50,7 @@ func init() {
 	})
 
 	register("builds info", "get information about a build", BuildsInfo, stdcli.CommandOptions{
-		Flags:    []stdcli.Flag{flagRack, flagApp},
+		Flags:    []stdcli.Flag{flagRack, flagApp, flagFormat},
 		Usage:    "<build>",
 		Validate: stdcli.Args(1),
 	})
@@ -213,6 +213,10 @@ func Builds(rack sdk.Interface, c *stdcli.Context) error {
 		return err
 	}
 
+	if formatted(c) {
+		return printFormatted(c, bs)
+	}
+
 	t := c.Table("ID", "STATUS", "RELEASE", "STARTED", "ELAPSED", "DESCRIPTION")
 
 	for _, b := range bs {
@@ -312,6 +316,10 @@ func BuildsInfo(rack sdk.Interface, c *stdcli.Context) error {
 		return err
 	}
 
+	if formatted(c) {
+		return printFormatted(c, b)
+	}
+
 	i := c.Info()
 
 	i.Add("Id", b.Id)
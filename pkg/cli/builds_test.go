@@ -137,6 +137,19 @@ func TestBuilds(t *testing.T) {
 	})
 }
 
+func TestBuildsJSON(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		v := structs.Builds{*fxBuild(), *fxBuildRunning(), *fxBuildFailed()}
+		i.On("BuildList", "app1", structs.BuildListOptions{}).Return(v, nil)
+
+		res, err := testExecute(e, "builds -a app1 --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireJSON(t, v)
+	})
+}
+
 func TestBuildsError(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("BuildList", "app1", structs.BuildListOptions{}).Return(nil, fmt.Errorf("err1"))
@@ -269,6 +282,19 @@ func TestBuildsInfo(t *testing.T) {
 	})
 }
 
+func TestBuildsInfoJSON(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		v := fxBuild()
+		i.On("BuildGet", "app1", "build1").Return(v, nil)
+
+		res, err := testExecute(e, "builds info build1 -a app1 --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireJSON(t, v)
+	})
+}
+
 func TestBuildsInfoError(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("BuildGet", "app1", "build1").Return(nil, fmt.Errorf("err1"))
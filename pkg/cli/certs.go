@@ -14,7 +14,7 @@ import (
 
 func init() {
 	register("certs", "list certificates", Certs, stdcli.CommandOptions{
-		Flags:    []stdcli.Flag{flagRack},
+		Flags:    []stdcli.Flag{flagRack, flagFormat},
 		Validate: stdcli.Args(0),
 	})
 
@@ -47,6 +47,10 @@ func Certs(rack sdk.Interface, c *stdcli.Context) error {
 		return err
 	}
 
+	if formatted(c) {
+		return printFormatted(c, cs)
+	}
+
 	t := c.Table("ID", "DOMAIN", "EXPIRES")
 
 	for _, c := range cs {
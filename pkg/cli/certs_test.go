@@ -27,6 +27,19 @@ func TestCerts(t *testing.T) {
 	})
 }
 
+func TestCertsJSON(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		v := structs.Certificates{*fxCertificate(), *fxCertificate()}
+		i.On("CertificateList").Return(v, nil)
+
+		res, err := testExecute(e, "certs --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireJSON(t, v)
+	})
+}
+
 func TestCertsError(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("CertificateList").Return(nil, fmt.Errorf("err1"))
@@ -19,6 +19,7 @@ var (
 
 var (
 	flagApp      = stdcli.StringFlag("app", "a", "app name")
+	flagFormat   = stdcli.StringFlag("format", "", "output format: json, yaml, table")
 	flagId       = stdcli.BoolFlag("id", "", "put logs on stderr, release id on stdout")
 	flagNoFollow = stdcli.BoolFlag("no-follow", "", "do not follow logs")
 	flagRack     = stdcli.StringFlag("rack", "r", "rack name")
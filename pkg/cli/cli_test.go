@@ -3,6 +3,7 @@ package cli_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -105,6 +106,13 @@ type result struct {
 	Stderr string
 }
 
+// RequireJSON asserts that stdout is v rendered by --format json
+func (r *result) RequireJSON(t *testing.T, v interface{}) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("%s\n", data), r.Stdout)
+}
+
 func (r *result) RequireStderr(t *testing.T, lines []string) {
 	stderr := strings.Split(strings.TrimSuffix(r.Stderr, "\n"), "\n")
 	require.Equal(t, lines, stderr)
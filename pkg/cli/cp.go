@@ -1,33 +1,59 @@
 package cli
 
 import (
+	"archive/tar"
 	"fmt"
 	"io"
+	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
 	"github.com/convox/convox/pkg/common"
 	"github.com/convox/convox/sdk"
 	"github.com/convox/stdcli"
+	"github.com/docker/docker/pkg/fileutils"
 )
 
 func init() {
 	register("cp", "copy files", Cp, stdcli.CommandOptions{
-		Flags:    []stdcli.Flag{flagApp, flagRack},
+		Flags: []stdcli.Flag{
+			flagApp,
+			flagRack,
+			stdcli.StringFlag("exclude", "x", "comma separated patterns to exclude"),
+			stdcli.BoolFlag("recursive", "R", "copy directories recursively (-r selects the rack)"),
+		},
 		Usage:    "<[pid:]src> <[pid:]dst>",
 		Validate: stdcli.Args(2),
 	})
 }
 
+type cpOptions struct {
+	Excludes  []string
+	Recursive bool
+}
+
 func Cp(rack sdk.Interface, c *stdcli.Context) error {
 	src := c.Arg(0)
 	dst := c.Arg(1)
 
-	r, err := cpSource(rack, c, src)
+	opts := cpOptions{
+		Excludes:  cpExcludes(c.String("exclude")),
+		Recursive: c.Bool("recursive"),
+	}
+
+	target, err := cpTarget(dst)
 	if err != nil {
 		return err
 	}
 
+	r, err := cpSource(rack, c, src, target, opts)
+	if err != nil {
+		return err
+	}
+
+	defer r.Close()
+
 	if err := cpDestination(rack, c, r, dst); err != nil {
 		return err
 	}
@@ -38,63 +64,216 @@ func Cp(rack sdk.Interface, c *stdcli.Context) error {
 func cpDestination(rack sdk.Interface, c *stdcli.Context, r io.Reader, dst string) error {
 	parts := strings.SplitN(dst, ":", 2)
 
+	switch len(parts) {
+	case 1:
+		return common.Unarchive(r, "/")
+	case 2:
+		return rack.FilesUpload(app(c), parts[0], r)
+	default:
+		return fmt.Errorf("unknown destination: %s", dst)
+	}
+}
+
+func cpSource(rack sdk.Interface, c *stdcli.Context, src, target string, opts cpOptions) (io.ReadCloser, error) {
+	parts := strings.SplitN(src, ":", 2)
+
 	switch len(parts) {
 	case 1:
 		abs, err := filepath.Abs(parts[0])
 		if err != nil {
-			return err
+			return nil, err
+		}
+
+		base, pattern := cpGlob(filepath.ToSlash(abs))
+
+		files := []string{abs}
+
+		if pattern != "" {
+			if files, err = filepath.Glob(abs); err != nil {
+				return nil, err
+			}
+
+			if len(files) == 0 {
+				return nil, fmt.Errorf("no files match: %s", abs)
+			}
+		}
+
+		// local files are checked up front so that nothing is sent when they can not be copied
+		if !opts.Recursive {
+			for _, f := range files {
+				if fi, err := os.Stat(f); err == nil && fi.IsDir() {
+					return nil, fmt.Errorf("%s is a directory, use -R", filepath.ToSlash(f))
+				}
+			}
 		}
 
-		rr, err := common.RebaseArchive(r, "/base", abs)
+		r, err := common.Archive(files...)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		return common.Unarchive(rr, "/")
+		return cpArchive(r, base, pattern, target, opts), nil
 	case 2:
 		if !strings.HasPrefix(parts[1], "/") {
-			return fmt.Errorf("must specify absolute paths for processes")
+			return nil, fmt.Errorf("must specify absolute paths for processes")
 		}
 
-		rr, err := common.RebaseArchive(r, "/base", parts[1])
+		base, pattern := cpGlob(path.Clean(parts[1]))
+
+		r, err := rack.FilesDownload(app(c), parts[0], base)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		return rack.FilesUpload(app(c), parts[0], rr)
+		return cpArchive(r, base, pattern, target, opts), nil
 	default:
-		return fmt.Errorf("unknown destination: %s", dst)
+		return nil, fmt.Errorf("unknown source: %s", src)
 	}
 }
 
-func cpSource(rack sdk.Interface, c *stdcli.Context, src string) (io.Reader, error) {
-	parts := strings.SplitN(src, ":", 2)
+// cpTarget returns the absolute path that copied files are placed below
+func cpTarget(dst string) (string, error) {
+	parts := strings.SplitN(dst, ":", 2)
 
 	switch len(parts) {
 	case 1:
 		abs, err := filepath.Abs(parts[0])
 		if err != nil {
-			return nil, err
-		}
-
-		r, err := common.Archive(abs)
-		if err != nil {
-			return nil, err
+			return "", err
 		}
 
-		return common.RebaseArchive(r, abs, "/base")
+		return filepath.ToSlash(abs), nil
 	case 2:
 		if !strings.HasPrefix(parts[1], "/") {
-			return nil, fmt.Errorf("must specify absolute paths for processes")
+			return "", fmt.Errorf("must specify absolute paths for processes")
 		}
 
-		r, err := rack.FilesDownload(app(c), parts[0], parts[1])
+		return parts[1], nil
+	default:
+		return "", fmt.Errorf("unknown destination: %s", dst)
+	}
+}
+
+// cpArchive streams an archive of base with the copied files moved below target,
+// files matching a glob pattern are placed below target by their matched name
+func cpArchive(r io.Reader, base, pattern, target string, opts cpOptions) io.ReadCloser {
+	rr, ww := io.Pipe()
+
+	go func() {
+		ww.CloseWithError(cpArchiveWrite(ww, r, base, pattern, target, opts))
+	}()
+
+	return rr
+}
+
+func cpArchiveWrite(w io.Writer, r io.Reader, base, pattern, target string, opts cpOptions) error {
+	tr := tar.NewReader(r)
+	tw := tar.NewWriter(w)
+
+	matches := 0
+
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		return common.RebaseArchive(r, parts[1], "/base")
-	default:
-		return nil, fmt.Errorf("unknown source: %s", src)
+		name := path.Clean(fmt.Sprintf("/%s", h.Name))
+
+		if name != base && !strings.HasPrefix(name, strings.TrimSuffix(base, "/")+"/") {
+			continue
+		}
+
+		rel := strings.TrimPrefix(strings.TrimPrefix(name, base), "/")
+
+		if pattern != "" {
+			match, rest, ok := cpMatch(pattern, rel)
+			if !ok {
+				continue
+			}
+
+			if (rest != "" || h.Typeflag == tar.TypeDir) && !opts.Recursive {
+				return fmt.Errorf("%s is a directory, use -R", path.Join(base, match))
+			}
+
+			rel = path.Join(match, rest)
+		} else if rel != "" && !opts.Recursive {
+			return fmt.Errorf("%s is a directory, use -R", base)
+		} else if h.Typeflag == tar.TypeDir && !opts.Recursive {
+			return fmt.Errorf("%s is a directory, use -R", base)
+		}
+
+		if rel != "" {
+			if excluded, err := fileutils.Matches(rel, opts.Excludes); err != nil {
+				return err
+			} else if excluded {
+				continue
+			}
+		}
+
+		matches++
+
+		h.Name = path.Join(target, rel)
+
+		if err := tw.WriteHeader(h); err != nil {
+			return err
+		}
+
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+
+	if pattern != "" && matches == 0 {
+		return fmt.Errorf("no files match: %s", path.Join(base, pattern))
+	}
+
+	return tw.Close()
+}
+
+func cpExcludes(value string) []string {
+	excludes := []string{}
+
+	for _, e := range strings.Split(value, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			excludes = append(excludes, e)
+		}
+	}
+
+	return excludes
+}
+
+// cpGlob splits a path into the directory before its first glob and the remaining pattern
+func cpGlob(file string) (string, string) {
+	parts := strings.Split(file, "/")
+
+	for i, p := range parts {
+		if strings.ContainsAny(p, "*?[") {
+			return common.CoalesceString(strings.Join(parts[0:i], "/"), "/"), strings.Join(parts[i:], "/")
+		}
+	}
+
+	return file, ""
+}
+
+// cpMatch matches the leading components of rel against pattern and returns the
+// last matched component along with anything below it
+func cpMatch(pattern, rel string) (string, string, bool) {
+	pp := strings.Split(pattern, "/")
+	rp := strings.Split(rel, "/")
+
+	if rel == "" || len(rp) < len(pp) {
+		return "", "", false
+	}
+
+	for i := range pp {
+		if ok, _ := path.Match(pp[i], rp[i]); !ok {
+			return "", "", false
+		}
 	}
+
+	return rp[len(pp)-1], strings.Join(rp[len(pp):], "/"), true
 }
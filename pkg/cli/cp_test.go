@@ -1,9 +1,12 @@
 package cli_test
 
 import (
+	"archive/tar"
 	"bytes"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -73,3 +76,144 @@ func TestCpDownloadError(t *testing.T) {
 		res.RequireStdout(t, []string{""})
 	})
 }
+
+func testCpTree(t *testing.T) string {
+	tmpd, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpd, "sub"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(tmpd, "a.txt"), []byte("a"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(tmpd, "b.log"), []byte("b"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(tmpd, "sub", "c.txt"), []byte("c"), 0644))
+	return tmpd
+}
+
+func testTarNames(t *testing.T, r io.Reader) []string {
+	names := []string{}
+	tr := tar.NewReader(r)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return names
+		}
+		require.NoError(t, err)
+		names = append(names, h.Name)
+	}
+}
+
+func TestCpUploadDirectory(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		tmpd := testCpTree(t)
+		defer os.RemoveAll(tmpd)
+
+		res, err := testExecute(e, fmt.Sprintf("cp -a app1 %s 0123456789:/app", tmpd), nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{fmt.Sprintf("ERROR: %s is a directory, use -R", tmpd)})
+		res.RequireStdout(t, []string{""})
+	})
+}
+
+func TestCpUploadRecursiveExclude(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		tmpd := testCpTree(t)
+		defer os.RemoveAll(tmpd)
+
+		var names []string
+
+		i.On("FilesUpload", "app1", "0123456789", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			names = testTarNames(t, args.Get(2).(io.Reader))
+		})
+
+		res, err := testExecute(e, fmt.Sprintf("cp -a app1 -R --exclude '*.log' %s 0123456789:/app", tmpd), nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{""})
+		require.Equal(t, []string{"/app", "/app/a.txt", "/app/sub", "/app/sub/c.txt"}, names)
+	})
+}
+
+func TestCpUploadGlob(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		tmpd := testCpTree(t)
+		defer os.RemoveAll(tmpd)
+
+		var names []string
+
+		i.On("FilesUpload", "app1", "0123456789", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			names = testTarNames(t, args.Get(2).(io.Reader))
+		})
+
+		res, err := testExecute(e, fmt.Sprintf("cp -a app1 '%s/*.txt' 0123456789:/app", tmpd), nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{""})
+		require.Equal(t, []string{"/app/a.txt"}, names)
+	})
+}
+
+func TestCpUploadGlobNoMatch(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		tmpd := testCpTree(t)
+		defer os.RemoveAll(tmpd)
+
+		res, err := testExecute(e, fmt.Sprintf("cp -a app1 '%s/*.json' 0123456789:/app", tmpd), nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{fmt.Sprintf("ERROR: no files match: %s/*.json", tmpd)})
+		res.RequireStdout(t, []string{""})
+	})
+}
+
+func TestCpDownloadGlob(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		tmpd, err := ioutil.TempDir("", "")
+		require.NoError(t, err)
+		defer os.RemoveAll(tmpd)
+
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for name, data := range map[string]string{"tmp/a.txt": "a", "tmp/b.log": "b"} {
+			require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), Typeflag: tar.TypeReg}))
+			tw.Write([]byte(data))
+		}
+		require.NoError(t, tw.Close())
+
+		i.On("FilesDownload", "app1", "0123456789", "/tmp").Return(&buf, nil)
+
+		res, err := testExecute(e, fmt.Sprintf("cp -a app1 '0123456789:/tmp/*.txt' %s", tmpd), nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{""})
+
+		data, err := ioutil.ReadFile(filepath.Join(tmpd, "a.txt"))
+		require.NoError(t, err)
+		require.Equal(t, "a", string(data))
+		_, err = os.Stat(filepath.Join(tmpd, "b.log"))
+		require.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestCpDownloadDirectory(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		tmpd, err := ioutil.TempDir("", "")
+		require.NoError(t, err)
+		defer os.RemoveAll(tmpd)
+
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "tmp/dir/a.txt", Mode: 0644, Size: 1, Typeflag: tar.TypeReg}))
+		tw.Write([]byte("a"))
+		require.NoError(t, tw.Close())
+
+		i.On("FilesDownload", "app1", "0123456789", "/tmp/dir").Return(&buf, nil)
+
+		res, err := testExecute(e, fmt.Sprintf("cp -a app1 0123456789:/tmp/dir %s", tmpd), nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: /tmp/dir is a directory, use -R"})
+		res.RequireStdout(t, []string{""})
+	})
+}
@@ -10,9 +10,12 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/convox/convox/pkg/common"
+	"github.com/convox/convox/pkg/diff"
 	"github.com/convox/convox/pkg/options"
+	"github.com/convox/convox/pkg/secret"
 	"github.com/convox/convox/pkg/structs"
 	"github.com/convox/convox/sdk"
 	"github.com/convox/stdcli"
@@ -20,7 +23,11 @@ import (
 
 func init() {
 	register("env", "list env vars", Env, stdcli.CommandOptions{
-		Flags:    []stdcli.Flag{flagRack, flagApp},
+		Flags: []stdcli.Flag{
+			flagRack,
+			flagApp,
+			stdcli.BoolFlag("reveal", "", "show values of sensitive env vars"),
+		},
 		Validate: stdcli.Args(0),
 	})
 
@@ -40,14 +47,45 @@ func init() {
 		Validate: stdcli.Args(1),
 	})
 
+	register("env history", "show env var changes across releases", EnvHistory, stdcli.CommandOptions{
+		Flags: append(stdcli.OptionFlags(structs.ReleaseListOptions{}),
+			flagApp,
+			flagFormat,
+			flagRack,
+			stdcli.BoolFlag("reveal", "", "show values of sensitive env vars"),
+		),
+		Usage:    "[var]",
+		Validate: stdcli.ArgsMax(1),
+	})
+
+	register("env rollback", "restore an env var to its value in a previous release", EnvRollback, stdcli.CommandOptions{
+		Flags: []stdcli.Flag{
+			flagApp,
+			flagId,
+			flagRack,
+			flagWait,
+			stdcli.StringFlag("release", "", "release to restore the value from"),
+			stdcli.BoolFlag("promote", "p", "promote the release"),
+		},
+		Usage:    "<var>",
+		Validate: stdcli.Args(1),
+	})
+
+	register("env secrets", "show the status of secret references", EnvSecrets, stdcli.CommandOptions{
+		Flags:    []stdcli.Flag{flagApp, flagFormat, flagRack},
+		Validate: stdcli.Args(0),
+	})
+
 	register("env set", "set env var(s)", EnvSet, stdcli.CommandOptions{
 		Flags: []stdcli.Flag{
 			flagApp,
 			flagId,
 			flagRack,
 			flagWait,
+			stdcli.StringFlag("description", "", "describe the env var(s) being set"),
 			stdcli.BoolFlag("replace", "", "replace all environment variables with given ones"),
 			stdcli.BoolFlag("promote", "p", "promote the release"),
+			stdcli.BoolFlag("sensitive", "", "mask the value(s) in env output"),
 		},
 		Usage: "<key=value> [key=value]...",
 	})
@@ -65,23 +103,55 @@ func init() {
 	})
 }
 
+const envMask = "********"
+
+type envChange struct {
+	Release string    `json:"release"`
+	Created time.Time `json:"created"`
+	Change  string    `json:"change"`
+	Name    string    `json:"name"`
+	From    string    `json:"from,omitempty"`
+	To      string    `json:"to,omitempty"`
+}
+
 func Env(rack sdk.Interface, c *stdcli.Context) error {
-	env, err := common.AppEnvironment(rack, app(c))
+	env, meta, err := common.AppEnvironmentMeta(rack, app(c))
 	if err != nil {
 		return err
 	}
 
+	refs := secret.References(env)
+
+	if !c.Bool("reveal") {
+		env = envMasked(env, meta)
+	}
+
 	c.Writef("%s\n", env.String())
 
+	if len(refs) > 0 {
+		ss, err := rack.EnvironmentSecretList(app(c))
+		if err != nil {
+			return err
+		}
+
+		for _, s := range ss {
+			if s.Status == structs.EnvironmentSecretStale {
+				fmt.Fprintf(c.Writer().Stderr, "WARNING: %s is stale, its secret has changed since the last promote\n", s.Name)
+			}
+		}
+	}
+
 	return nil
 }
 
 func EnvEdit(rack sdk.Interface, c *stdcli.Context) error {
-	env, err := common.AppEnvironment(rack, app(c))
+	env, meta, err := common.AppEnvironmentMeta(rack, app(c))
 	if err != nil {
 		return err
 	}
 
+	original := meta.String()
+
 	tmp, err := ioutil.TempDir("", "")
 	if err != nil {
 		return err
@@ -144,7 +214,7 @@ func EnvEdit(rack sdk.Interface, c *stdcli.Context) error {
 			return err
 		}
 	} else {
-		r, err = rack.ReleaseCreate(app(c), structs.ReleaseCreateOptions{Env: options.String(nenv.String())})
+		r, err = rack.ReleaseCreate(app(c), envReleaseOptions(nenv, meta, original))
 		if err != nil {
 			return err
 		}
@@ -181,6 +251,170 @@ func EnvGet(rack sdk.Interface, c *stdcli.Context) error {
 	return nil
 }
 
+func EnvHistory(rack sdk.Interface, c *stdcli.Context) error {
+	var opts structs.ReleaseListOptions
+
+	if err := c.Options(&opts); err != nil {
+		return err
+	}
+
+	limit := common.DefaultInt(opts.Limit, 10)
+
+	// fetch one extra release so the oldest one shown has something to compare against
+	rs, err := rack.ReleaseList(app(c), structs.ReleaseListOptions{Limit: options.Int(limit + 1)})
+	if err != nil {
+		return err
+	}
+
+	if len(rs) <= limit {
+		rs = append(rs, structs.Release{})
+	}
+
+	changes := []envChange{}
+
+	for i := 0; i < len(rs)-1; i++ {
+		r, prev := rs[i], rs[i+1]
+
+		cs, err := diff.Env(prev.Env, r.Env, true)
+		if err != nil {
+			return err
+		}
+
+		rm, err := structs.NewEnvironmentMeta([]byte(r.EnvMeta))
+		if err != nil {
+			return err
+		}
+
+		pm, err := structs.NewEnvironmentMeta([]byte(prev.EnvMeta))
+		if err != nil {
+			return err
+		}
+
+		for _, ch := range cs {
+			if key := c.Arg(0); key != "" && ch.Name != key {
+				continue
+			}
+
+			if !c.Bool("reveal") && (rm.Sensitive(ch.Name) || pm.Sensitive(ch.Name)) {
+				if ch.From != "" {
+					ch.From = envMask
+				}
+				if ch.To != "" {
+					ch.To = envMask
+				}
+			}
+
+			changes = append(changes, envChange{
+				Release: r.Id,
+				Created: r.Created,
+				Change:  ch.Change,
+				Name:    ch.Name,
+				From:    ch.From,
+				To:      ch.To,
+			})
+		}
+	}
+
+	if formatted(c) {
+		return printFormatted(c, changes)
+	}
+
+	t := c.Table("RELEASE", "CREATED", "CHANGE", "VAR", "VALUE")
+
+	for _, ch := range changes {
+		t.AddRow(ch.Release, common.Ago(ch.Created), ch.Change, ch.Name, envChangeValue(ch))
+	}
+
+	return t.Print()
+}
+
+func EnvRollback(rack sdk.Interface, c *stdcli.Context) error {
+	var stdout io.Writer
+
+	if c.Bool("id") {
+		stdout = c.Writer().Stdout
+		c.Writer().Stdout = c.Writer().Stderr
+	}
+
+	key := c.Arg(0)
+	release := c.String("release")
+
+	if release == "" {
+		return fmt.Errorf("--release is required")
+	}
+
+	rr, err := rack.ReleaseGet(app(c), release)
+	if err != nil {
+		return err
+	}
+
+	renv, err := structs.NewEnvironment([]byte(rr.Env))
+	if err != nil {
+		return err
+	}
+
+	env, meta, err := common.AppEnvironmentMeta(rack, app(c))
+	if err != nil {
+		return err
+	}
+
+	original := meta.String()
+
+	if v, ok := renv[key]; ok {
+		env[key] = v
+	} else {
+		delete(env, key)
+	}
+
+	c.Startf("Rolling back <info>%s</info> to <release>%s</release>", key, release)
+
+	r, err := rack.ReleaseCreate(app(c), envReleaseOptions(env, meta, original))
+	if err != nil {
+		return err
+	}
+
+	c.OK()
+
+	c.Writef("Release: <release>%s</release>\n", r.Id)
+
+	if c.Bool("promote") {
+		if err := releasePromote(rack, c, app(c), r.Id); err != nil {
+			return err
+		}
+	}
+
+	if c.Bool("id") {
+		fmt.Fprintf(stdout, r.Id)
+	}
+
+	return nil
+}
+
+func EnvSecrets(rack sdk.Interface, c *stdcli.Context) error {
+	ss, err := rack.EnvironmentSecretList(app(c))
+	if err != nil {
+		return err
+	}
+
+	if formatted(c) {
+		return printFormatted(c, ss)
+	}
+
+	t := c.Table("NAME", "STATUS", "REFERENCE")
+
+	for _, s := range ss {
+		status := s.Status
+
+		if s.Error != "" {
+			status = fmt.Sprintf("%s: %s", s.Status, s.Error)
+		}
+
+		t.AddRow(s.Name, status, s.Reference)
+	}
+
+	return t.Print()
+}
+
 func EnvSet(rack sdk.Interface, c *stdcli.Context) error {
 	var stdout io.Writer
 
@@ -190,15 +424,18 @@ func EnvSet(rack sdk.Interface, c *stdcli.Context) error {
 	}
 
 	env := structs.Environment{}
+	meta := structs.EnvironmentMeta{}
 	var err error
 
 	if !c.Bool("replace") {
-		env, err = common.AppEnvironment(rack, app(c))
+		env, meta, err = common.AppEnvironmentMeta(rack, app(c))
 		if err != nil {
 			return err
 		}
 	}
 
+	original := meta.String()
+
 	args := []string(c.Args)
 	keys := []string{}
 
@@ -214,6 +451,7 @@ func EnvSet(rack sdk.Interface, c *stdcli.Context) error {
 		if len(parts) == 2 {
 			keys = append(keys, fmt.Sprintf("<info>%s</info>", parts[0]))
 			env[parts[0]] = parts[1]
+			meta[parts[0]] = envKeyMeta(c, meta[parts[0]])
 		}
 	}
 
@@ -234,7 +472,14 @@ func EnvSet(rack sdk.Interface, c *stdcli.Context) error {
 			return err
 		}
 	} else {
-		r, err = rack.ReleaseCreate(app(c), structs.ReleaseCreateOptions{Env: options.String(env.String())})
+		opts := envReleaseOptions(env, meta, original)
+
+		// do not carry metadata forward from the replaced environment
+		if c.Bool("replace") {
+			opts.EnvMeta = options.String(meta.String())
+		}
+
+		r, err = rack.ReleaseCreate(app(c), opts)
 		if err != nil {
 			return err
 		}
@@ -265,11 +510,13 @@ func EnvUnset(rack sdk.Interface, c *stdcli.Context) error {
 		c.Writer().Stdout = c.Writer().Stderr
 	}
 
-	env, err := common.AppEnvironment(rack, app(c))
+	env, meta, err := common.AppEnvironmentMeta(rack, app(c))
 	if err != nil {
 		return err
 	}
 
+	original := meta.String()
+
 	keys := []string{}
 
 	for _, arg := range c.Args {
@@ -296,7 +543,7 @@ func EnvUnset(rack sdk.Interface, c *stdcli.Context) error {
 			}
 		}
 	} else {
-		r, err = rack.ReleaseCreate(app(c), structs.ReleaseCreateOptions{Env: options.String(env.String())})
+		r, err = rack.ReleaseCreate(app(c), envReleaseOptions(env, meta, original))
 		if err != nil {
 			return err
 		}
@@ -318,3 +565,58 @@ func EnvUnset(rack sdk.Interface, c *stdcli.Context) error {
 
 	return nil
 }
+
+func envChangeValue(ch envChange) string {
+	switch ch.Change {
+	case diff.Added:
+		return ch.To
+	case diff.Removed:
+		return ch.From
+	default:
+		return fmt.Sprintf("%s -> %s", ch.From, ch.To)
+	}
+}
+
+// envKeyMeta applies the --description and --sensitive flags to the metadata of a var being set
+func envKeyMeta(c *stdcli.Context, m structs.EnvironmentKeyMeta) structs.EnvironmentKeyMeta {
+	if c.Value("description") != nil {
+		m.Description = c.String("description")
+	}
+
+	if c.Value("sensitive") != nil {
+		m.Sensitive = c.Bool("sensitive")
+	}
+
+	return m
+}
+
+func envMasked(env structs.Environment, meta structs.EnvironmentMeta) structs.Environment {
+	me := structs.Environment{}
+
+	for k, v := range env {
+		if meta.Sensitive(k) {
+			v = envMask
+		}
+
+		me[k] = v
+	}
+
+	return me
+}
+
+// envReleaseOptions only sends metadata when it has changed, otherwise the rack carries it forward
+func envReleaseOptions(env structs.Environment, meta structs.EnvironmentMeta, original string) structs.ReleaseCreateOptions {
+	for k := range meta {
+		if _, ok := env[k]; !ok {
+			delete(meta, k)
+		}
+	}
+
+	opts := structs.ReleaseCreateOptions{Env: options.String(env.String())}
+
+	if m := meta.String(); m != original {
+		opts.EnvMeta = options.String(m)
+	}
+
+	return opts
+}
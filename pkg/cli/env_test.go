@@ -3,6 +3,7 @@ package cli_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/convox/convox/pkg/cli"
 	mocksdk "github.com/convox/convox/pkg/mock/sdk"
@@ -42,6 +43,54 @@ func TestEnvError(t *testing.T) {
 	})
 }
 
+func TestEnvSensitive(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		opts := structs.ReleaseListOptions{Limit: options.Int(1)}
+		i.On("ReleaseList", "app1", opts).Return(structs.Releases{*fxReleaseSensitive()}, nil)
+		i.On("ReleaseGet", "app1", "release1").Return(fxReleaseSensitive(), nil)
+
+		res, err := testExecute(e, "env -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"BAZ=quux",
+			"FOO=********",
+		})
+
+		res, err = testExecute(e, "env -a app1 --reveal", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"BAZ=quux",
+			"FOO=bar",
+		})
+	})
+}
+
+func TestEnvSecretStale(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		r := fxRelease()
+		r.Env = "DB_PASSWORD=secret://vault/db#password\nFOO=bar"
+		opts := structs.ReleaseListOptions{Limit: options.Int(1)}
+		i.On("ReleaseList", "app1", opts).Return(structs.Releases{*r}, nil)
+		i.On("ReleaseGet", "app1", "release1").Return(r, nil)
+		i.On("EnvironmentSecretList", "app1").Return(structs.EnvironmentSecrets{
+			{Name: "DB_PASSWORD", Reference: "secret://vault/db#password", Status: "stale"},
+		}, nil)
+
+		res, err := testExecute(e, "env -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{"WARNING: DB_PASSWORD is stale, its secret has changed since the last promote"})
+		res.RequireStdout(t, []string{
+			"DB_PASSWORD=secret://vault/db#password",
+			"FOO=bar",
+		})
+	})
+}
+
 func TestEnvGet(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		opts := structs.ReleaseListOptions{Limit: options.Int(1)}
@@ -84,6 +133,193 @@ func TestEnvGetMissing(t *testing.T) {
 	})
 }
 
+func TestEnvHistory(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		rs := structs.Releases{
+			{Id: "release3", Env: "NEW=2", EnvMeta: `{"FOO":{"sensitive":true}}`, Created: fxStarted},
+			{Id: "release2", Env: "FOO=baz\nNEW=1", EnvMeta: `{"FOO":{"sensitive":true}}`, Created: fxStarted},
+			{Id: "release1", Env: "FOO=bar", Created: fxStarted},
+		}
+		i.On("ReleaseList", "app1", structs.ReleaseListOptions{Limit: options.Int(11)}).Return(rs, nil)
+
+		res, err := testExecute(e, "env history -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"RELEASE   CREATED     CHANGE   VAR  VALUE               ",
+			"release3  2 days ago  removed  FOO  ********            ",
+			"release3  2 days ago  changed  NEW  1 -> 2              ",
+			"release2  2 days ago  changed  FOO  ******** -> ********",
+			"release2  2 days ago  added    NEW  1                   ",
+			"release1  2 days ago  added    FOO  bar                 ",
+		})
+	})
+}
+
+func TestEnvHistoryKey(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		rs := structs.Releases{
+			{Id: "release3", Env: "FOO=qux\nNEW=2", Created: fxStarted},
+			{Id: "release2", Env: "FOO=baz\nNEW=1", Created: fxStarted},
+			{Id: "release1", Env: "FOO=bar", Created: fxStarted},
+		}
+		i.On("ReleaseList", "app1", structs.ReleaseListOptions{Limit: options.Int(2)}).Return(rs, nil)
+
+		res, err := testExecute(e, "env history FOO -a app1 --limit 1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"RELEASE   CREATED     CHANGE   VAR  VALUE     ",
+			"release3  2 days ago  changed  FOO  baz -> qux",
+			"release2  2 days ago  changed  FOO  bar -> baz",
+		})
+	})
+}
+
+func TestEnvHistoryReveal(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		rs := structs.Releases{
+			{Id: "release2", Env: "FOO=baz", EnvMeta: `{"FOO":{"sensitive":true}}`, Created: fxStarted},
+			{Id: "release1", Env: "FOO=bar", Created: fxStarted},
+		}
+		i.On("ReleaseList", "app1", structs.ReleaseListOptions{Limit: options.Int(11)}).Return(rs, nil)
+
+		res, err := testExecute(e, "env history -a app1 --reveal --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		created := fxStarted.Format(time.RFC3339Nano)
+		res.RequireStdout(t, []string{
+			`[`,
+			`  {`,
+			`    "release": "release2",`,
+			fmt.Sprintf(`    "created": "%s",`, created),
+			`    "change": "changed",`,
+			`    "name": "FOO",`,
+			`    "from": "bar",`,
+			`    "to": "baz"`,
+			`  },`,
+			`  {`,
+			`    "release": "release1",`,
+			fmt.Sprintf(`    "created": "%s",`, created),
+			`    "change": "added",`,
+			`    "name": "FOO",`,
+			`    "to": "bar"`,
+			`  }`,
+			`]`,
+		})
+	})
+}
+
+func TestEnvHistoryError(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("ReleaseList", "app1", structs.ReleaseListOptions{Limit: options.Int(11)}).Return(nil, fmt.Errorf("err1"))
+
+		res, err := testExecute(e, "env history -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: err1"})
+		res.RequireStdout(t, []string{""})
+	})
+}
+
+func TestEnvRollback(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		opts := structs.ReleaseListOptions{Limit: options.Int(1)}
+		i.On("ReleaseList", "app1", opts).Return(structs.Releases{*fxRelease()}, nil)
+		i.On("ReleaseGet", "app1", "release1").Return(fxRelease(), nil)
+		i.On("ReleaseGet", "app1", "release2").Return(&structs.Release{Id: "release2", Env: "FOO=old"}, nil)
+		ropts := structs.ReleaseCreateOptions{Env: options.String("BAZ=quux\nFOO=old")}
+		i.On("ReleaseCreate", "app1", ropts).Return(fxRelease3(), nil)
+
+		res, err := testExecute(e, "env rollback FOO --release release2 -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"Rolling back FOO to release2... OK",
+			"Release: release3",
+		})
+	})
+}
+
+func TestEnvRollbackRemoved(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		opts := structs.ReleaseListOptions{Limit: options.Int(1)}
+		i.On("ReleaseList", "app1", opts).Return(structs.Releases{*fxReleaseSensitive()}, nil)
+		i.On("ReleaseGet", "app1", "release1").Return(fxReleaseSensitive(), nil)
+		i.On("ReleaseGet", "app1", "release2").Return(&structs.Release{Id: "release2", Env: "BAZ=quux"}, nil)
+		ropts := structs.ReleaseCreateOptions{Env: options.String("BAZ=quux"), EnvMeta: options.String("")}
+		i.On("ReleaseCreate", "app1", ropts).Return(fxRelease3(), nil)
+
+		res, err := testExecute(e, "env rollback FOO --release release2 -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"Rolling back FOO to release2... OK",
+			"Release: release3",
+		})
+	})
+}
+
+func TestEnvRollbackNoRelease(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		res, err := testExecute(e, "env rollback FOO -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: --release is required"})
+		res.RequireStdout(t, []string{""})
+	})
+}
+
+func TestEnvRollbackError(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("ReleaseGet", "app1", "release2").Return(nil, fmt.Errorf("err1"))
+
+		res, err := testExecute(e, "env rollback FOO --release release2 -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: err1"})
+		res.RequireStdout(t, []string{""})
+	})
+}
+
+func TestEnvSecrets(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("EnvironmentSecretList", "app1").Return(structs.EnvironmentSecrets{
+			{Name: "API_KEY", Reference: "secret://k8s/shared/api#key", Status: "current"},
+			{Name: "DB_PASSWORD", Reference: "secret://vault/db#password", Status: "stale"},
+			{Name: "TOKEN", Reference: "secret://vault/token#value", Status: "error", Error: "key not found: value"},
+		}, nil)
+
+		res, err := testExecute(e, "env secrets -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"NAME         STATUS                       REFERENCE                  ",
+			"API_KEY      current                      secret://k8s/shared/api#key",
+			"DB_PASSWORD  stale                        secret://vault/db#password ",
+			"TOKEN        error: key not found: value  secret://vault/token#value ",
+		})
+	})
+}
+
+func TestEnvSecretsError(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("EnvironmentSecretList", "app1").Return(nil, fmt.Errorf("err1"))
+
+		res, err := testExecute(e, "env secrets -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: err1"})
+		res.RequireStdout(t, []string{""})
+	})
+}
+
 func TestEnvSet(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("SystemGet").Return(fxSystem(), nil)
@@ -143,7 +379,7 @@ func TestEnvSetClassic(t *testing.T) {
 func TestEnvSetReplace(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("SystemGet").Return(fxSystem(), nil)
-		ropts := structs.ReleaseCreateOptions{Env: options.String("AAA=bbb\nCCC=ddd")}
+		ropts := structs.ReleaseCreateOptions{Env: options.String("AAA=bbb\nCCC=ddd"), EnvMeta: options.String("")}
 		i.On("ReleaseCreate", "app1", ropts).Return(fxRelease(), nil)
 
 		res, err := testExecute(e, "env set AAA=bbb CCC=ddd -a app1 --replace", nil)
@@ -160,7 +396,7 @@ func TestEnvSetReplace(t *testing.T) {
 func TestEnvSetReplaceError(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("SystemGet").Return(fxSystem(), nil)
-		ropts := structs.ReleaseCreateOptions{Env: options.String("AAA=bbb\nCCC=ddd")}
+		ropts := structs.ReleaseCreateOptions{Env: options.String("AAA=bbb\nCCC=ddd"), EnvMeta: options.String("")}
 		i.On("ReleaseCreate", "app1", ropts).Return(nil, fmt.Errorf("err1"))
 
 		res, err := testExecute(e, "env set AAA=bbb CCC=ddd -a app1 --replace", nil)
@@ -171,6 +407,29 @@ func TestEnvSetReplaceError(t *testing.T) {
 	})
 }
 
+func TestEnvSetSensitive(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("SystemGet").Return(fxSystem(), nil)
+		opts := structs.ReleaseListOptions{Limit: options.Int(1)}
+		i.On("ReleaseList", "app1", opts).Return(structs.Releases{*fxReleaseSensitive()}, nil)
+		i.On("ReleaseGet", "app1", "release1").Return(fxReleaseSensitive(), nil)
+		ropts := structs.ReleaseCreateOptions{
+			Env:     options.String("AAA=bbb\nBAZ=quux\nFOO=bar"),
+			EnvMeta: options.String(`{"AAA":{"description":"token","sensitive":true},"FOO":{"description":"api key","sensitive":true}}`),
+		}
+		i.On("ReleaseCreate", "app1", ropts).Return(fxRelease(), nil)
+
+		res, err := testExecute(e, "env set AAA=bbb -a app1 --sensitive --description token", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"Setting AAA... OK",
+			"Release: release1",
+		})
+	})
+}
+
 func TestEnvUnset(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("SystemGet").Return(fxSystem(), nil)
@@ -191,6 +450,26 @@ func TestEnvUnset(t *testing.T) {
 	})
 }
 
+func TestEnvUnsetSensitive(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("SystemGet").Return(fxSystem(), nil)
+		opts := structs.ReleaseListOptions{Limit: options.Int(1)}
+		i.On("ReleaseList", "app1", opts).Return(structs.Releases{*fxReleaseSensitive()}, nil)
+		i.On("ReleaseGet", "app1", "release1").Return(fxReleaseSensitive(), nil)
+		ropts := structs.ReleaseCreateOptions{Env: options.String("BAZ=quux"), EnvMeta: options.String("")}
+		i.On("ReleaseCreate", "app1", ropts).Return(fxRelease(), nil)
+
+		res, err := testExecute(e, "env unset FOO -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"Unsetting FOO... OK",
+			"Release: release1",
+		})
+	})
+}
+
 func TestEnvUnsetError(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("SystemGet").Return(fxSystem(), nil)
@@ -47,6 +47,21 @@ func fxAppUpdating() *structs.App {
 	}
 }
 
+func fxAuditEntry() *structs.AuditEntry {
+	return &structs.AuditEntry{
+		Id:       "audit1",
+		App:      "app1",
+		Code:     200,
+		Duration: 2 * time.Second,
+		Identity: "ci",
+		Method:   "POST",
+		Path:     "/apps/app1/releases/release1/promote",
+		Route:    "ReleasePromote",
+		Time:     fxStarted,
+		Token:    "token1",
+	}
+}
+
 func fxBuild() *structs.Build {
 	return &structs.Build{
 		App:         "app1",
@@ -154,6 +169,25 @@ func fxProcess() *structs.Process {
 	}
 }
 
+func fxProcessCompleted() *structs.Process {
+	code := 1
+	ended := time.Now().UTC().Add(-48 * time.Hour)
+
+	return &structs.Process{
+		Id:       "pid2",
+		App:      "app1",
+		Command:  "rake db:migrate",
+		Ended:    &ended,
+		ExitCode: &code,
+		Identity: "deploy",
+		Image:    "image",
+		Name:     "web",
+		Release:  "release1",
+		Started:  time.Now().UTC().Add(-72 * time.Hour),
+		Status:   "failed",
+	}
+}
+
 func fxProcessPending() *structs.Process {
 	return &structs.Process{
 		Id:       "pid1",
@@ -192,6 +226,19 @@ func fxRelease() *structs.Release {
 	}
 }
 
+func fxReleaseSensitive() *structs.Release {
+	return &structs.Release{
+		Id:          "release1",
+		App:         "app1",
+		Build:       "build1",
+		Env:         "FOO=bar\nBAZ=quux",
+		EnvMeta:     `{"FOO":{"description":"api key","sensitive":true}}`,
+		Manifest:    "services:\n  web:\n    build: .\n    test: make test",
+		Created:     time.Now().UTC().Add(-49 * time.Hour),
+		Description: "description1",
+	}
+}
+
 func fxRelease2() *structs.Release {
 	return &structs.Release{
 		Id:       "release2",
@@ -225,6 +272,21 @@ func fxResource() *structs.Resource {
 	}
 }
 
+func fxResourceBackup() *structs.ResourceBackup {
+	return &structs.ResourceBackup{
+		Created: time.Now().UTC().Add(-49 * time.Hour),
+		Id:      "20200101030000",
+		Size:    2500000,
+	}
+}
+
+func fxResourceChanges() structs.ResourceChanges {
+	return structs.ResourceChanges{
+		{Action: "migrate", Current: "10.5", Desired: "11.2", Parameter: "version"},
+		{Action: "expand", Current: "10Gi", Desired: "20Gi", Parameter: "storage"},
+	}
+}
+
 func fxResourceType() structs.ResourceType {
 	return structs.ResourceType{
 		Name: "type1",
@@ -302,3 +364,61 @@ func fxSystemInternal() *structs.System {
 		Version:    "20180901000000",
 	}
 }
+
+func fxTimer() *structs.Timer {
+	last := time.Now().UTC().Add(-2 * time.Hour)
+
+	return &structs.Timer{
+		Command:     "bin/cleanup",
+		Concurrency: "forbid",
+		LastRun:     &last,
+		LastStatus:  "succeeded",
+		Name:        "cleanup",
+		Schedule:    "0 * * * *",
+		Service:     "worker",
+		Timezone:    "America/New_York",
+	}
+}
+
+func fxTimerSuspended() *structs.Timer {
+	return &structs.Timer{
+		Command:     "bin/report",
+		Concurrency: "allow",
+		Name:        "report",
+		Schedule:    "0 3 * * *",
+		Service:     "worker",
+		Suspended:   true,
+	}
+}
+
+func fxToken() *structs.Token {
+	return &structs.Token{
+		Id:      "token1",
+		Name:    "ci",
+		Role:    "deployer",
+		Apps:    []string{"app1", "app2"},
+		Created: fxStarted,
+	}
+}
+
+func fxVolume() *structs.Volume {
+	return &structs.Volume{
+		Access:   "ReadWriteOnce",
+		Class:    "standard",
+		Name:     "data",
+		Services: []string{"web", "worker"},
+		Size:     10737418240,
+		Status:   "bound",
+		Used:     1073741824,
+	}
+}
+
+func fxVolumeSnapshot() *structs.VolumeSnapshot {
+	return &structs.VolumeSnapshot{
+		Created: time.Now().UTC().Add(-49 * time.Hour),
+		Id:      "data-20200101030000",
+		Size:    10737418240,
+		Status:  "ready",
+		Volume:  "data",
+	}
+}
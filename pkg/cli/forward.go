@@ -0,0 +1,203 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/convox/convox/pkg/common"
+	"github.com/convox/stdcli"
+)
+
+// forwardDialer streams rw to a remote port, the rw for udp carries datagrams framed with common.WriteDatagram
+type forwardDialer func(port int, rw io.ReadWriter, protocol string) error
+
+type forwardPort struct {
+	Local  int
+	Remote int
+}
+
+func parseForwardPort(arg string) (*forwardPort, error) {
+	parts := strings.SplitN(arg, ":", 2)
+
+	remote, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid port: %s", arg)
+	}
+
+	fp := &forwardPort{Local: remote, Remote: remote}
+
+	if len(parts) == 2 {
+		local, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid port: %s", arg)
+		}
+
+		fp.Local = local
+	}
+
+	return fp, nil
+}
+
+func forward(c *stdcli.Context, target string, args []string, dial forwardDialer) error {
+	fps := []forwardPort{}
+
+	for _, arg := range args {
+		fp, err := parseForwardPort(arg)
+		if err != nil {
+			return err
+		}
+
+		fps = append(fps, *fp)
+	}
+
+	protocol := "tcp"
+
+	if c.Bool("udp") {
+		protocol = "udp"
+	}
+
+	lc := &net.ListenConfig{}
+
+	// bind every port before forwarding any of them so that a port in use stops the command
+	lns := []io.Closer{}
+
+	for _, fp := range fps {
+		var ln io.Closer
+		var err error
+
+		switch protocol {
+		case "udp":
+			ln, err = lc.ListenPacket(c.Context, "udp4", fmt.Sprintf("127.0.0.1:%d", fp.Local))
+		default:
+			ln, err = lc.Listen(c.Context, "tcp4", fmt.Sprintf("127.0.0.1:%d", fp.Local))
+		}
+		if err != nil {
+			for _, ln := range lns {
+				ln.Close()
+			}
+			return err
+		}
+
+		lns = append(lns, ln)
+	}
+
+	for i, fp := range fps {
+		switch ln := lns[i].(type) {
+		case net.PacketConn:
+			go forwardUDP(c, target, fp, ln, dial)
+		case net.Listener:
+			go forwardTCP(c, target, fp, ln, dial)
+		}
+	}
+
+	<-c.Done()
+
+	return nil
+}
+
+func forwardTCP(c *stdcli.Context, target string, fp forwardPort, ln net.Listener, dial forwardDialer) {
+	c.Writef("forwarding localhost:%d to %s:%d\n", fp.Local, target, fp.Remote)
+
+	defer ln.Close()
+
+	ch := make(chan net.Conn)
+
+	go proxyAccept(c, ln, ch)
+
+	for {
+		select {
+		case <-c.Done():
+			return
+		case cn := <-ch:
+			c.Writef("connect: %d\n", fp.Local)
+			go forwardConnection(c, cn, fp, dial)
+		}
+	}
+}
+
+func forwardConnection(c *stdcli.Context, cn net.Conn, fp forwardPort, dial forwardDialer) {
+	defer cn.Close()
+
+	if err := dial(fp.Remote, cn, "tcp"); err != nil {
+		c.Error(err)
+	}
+}
+
+// forwardUDP keeps a session per client address, a session that ends is
+// dialed again on the next datagram so forwarding survives pod replacement
+func forwardUDP(c *stdcli.Context, target string, fp forwardPort, pc net.PacketConn, dial forwardDialer) {
+	c.Writef("forwarding localhost:%d to %s:%d (udp)\n", fp.Local, target, fp.Remote)
+
+	go func() {
+		<-c.Done()
+		pc.Close()
+	}()
+
+	var lock sync.Mutex
+	sessions := map[string]*io.PipeWriter{}
+
+	buf := make([]byte, common.MaxDatagram)
+
+	for {
+		n, addr, err := pc.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+
+		lock.Lock()
+
+		w, ok := sessions[addr.String()]
+		if !ok {
+			w = forwardSession(c, pc, addr, fp, dial, func() {
+				lock.Lock()
+				delete(sessions, addr.String())
+				lock.Unlock()
+			})
+			sessions[addr.String()] = w
+		}
+
+		lock.Unlock()
+
+		// datagrams arriving while a finished session is being removed are dropped
+		if err := common.WriteDatagram(w, buf[0:n]); err != nil && err != io.ErrClosedPipe {
+			c.Error(err)
+		}
+	}
+}
+
+func forwardSession(c *stdcli.Context, pc net.PacketConn, addr net.Addr, fp forwardPort, dial forwardDialer, done func()) *io.PipeWriter {
+	inr, inw := io.Pipe()
+	outr, outw := io.Pipe()
+
+	go func() {
+		for {
+			data, err := common.ReadDatagram(outr)
+			if err != nil {
+				return
+			}
+
+			pc.WriteTo(data, addr)
+		}
+	}()
+
+	go func() {
+		defer done()
+		defer outw.Close()
+		defer inr.Close()
+
+		rw := struct {
+			io.Reader
+			io.Writer
+		}{inr, outw}
+
+		if err := dial(fp.Remote, rw, "udp"); err != nil {
+			c.Error(err)
+		}
+	}()
+
+	return inw
+}
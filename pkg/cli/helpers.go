@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"bytes"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
@@ -19,6 +20,7 @@ import (
 	"github.com/convox/convox/sdk"
 	"github.com/convox/stdcli"
 	"github.com/convox/stdsdk"
+	"github.com/ghodss/yaml"
 )
 
 type rack struct {
@@ -146,6 +148,16 @@ func executableName() string {
 	}
 }
 
+// formatted returns true if --format asks for something other than a table
+func formatted(c *stdcli.Context) bool {
+	switch c.String("format") {
+	case "", "table":
+		return false
+	default:
+		return true
+	}
+}
+
 func generateTempKey() (string, error) {
 	data := make([]byte, 1024)
 
@@ -235,6 +247,29 @@ func matchRack(c *stdcli.Context, name string) (*rack, error) {
 	return nil, fmt.Errorf("could not find rack: %s", name)
 }
 
+// printFormatted writes v to stdout in the format given by --format
+func printFormatted(c *stdcli.Context, v interface{}) error {
+	var data []byte
+	var err error
+
+	switch f := c.String("format"); f {
+	case "json":
+		data, err = json.MarshalIndent(v, "", "  ")
+	case "yaml":
+		data, err = yaml.Marshal(v)
+	default:
+		return fmt.Errorf("unknown format: %s", f)
+	}
+	if err != nil {
+		return err
+	}
+
+	// bypass the tag rendering in the writer
+	fmt.Fprintf(c.Writer().Stdout, "%s\n", bytes.TrimSpace(data))
+
+	return nil
+}
+
 func racks(c *stdcli.Context) ([]rack, error) {
 	rs := []rack{}
 
@@ -13,7 +13,7 @@ import (
 
 func init() {
 	register("instances", "list instances", Instances, stdcli.CommandOptions{
-		Flags:    []stdcli.Flag{flagRack},
+		Flags:    []stdcli.Flag{flagRack, flagFormat},
 		Validate: stdcli.Args(0),
 	})
 
@@ -39,6 +39,10 @@ func Instances(rack sdk.Interface, c *stdcli.Context) error {
 		return err
 	}
 
+	if formatted(c) {
+		return printFormatted(c, is)
+	}
+
 	t := c.Table("ID", "STATUS", "STARTED", "PS", "CPU", "MEM", "PUBLIC", "PRIVATE")
 
 	for _, i := range is {
@@ -30,6 +30,19 @@ func TestInstances(t *testing.T) {
 	})
 }
 
+func TestInstancesJSON(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		v := structs.Instances{*fxInstance(), *fxInstance()}
+		i.On("InstanceList").Return(v, nil)
+
+		res, err := testExecute(e, "instances --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireJSON(t, v)
+	})
+}
+
 func TestInstancesError(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("InstanceList").Return(nil, fmt.Errorf("err1"))
@@ -1,7 +1,14 @@
 package cli
 
 import (
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
 	"github.com/convox/convox/pkg/common"
+	"github.com/convox/convox/pkg/options"
 	"github.com/convox/convox/pkg/structs"
 	"github.com/convox/convox/sdk"
 	"github.com/convox/stdcli"
@@ -9,12 +16,38 @@ import (
 
 func init() {
 	register("ps", "list app processes", Ps, stdcli.CommandOptions{
-		Flags:    append(stdcli.OptionFlags(structs.ProcessListOptions{}), flagApp, flagRack),
+		Flags:    append(stdcli.OptionFlags(structs.ProcessListOptions{}), flagApp, flagRack, flagFormat),
 		Validate: stdcli.Args(0),
 	})
 
+	register("ps debug", "attach a debug container to a running process", PsDebug, stdcli.CommandOptions{
+		Flags: []stdcli.Flag{
+			flagApp,
+			flagRack,
+			stdcli.StringFlag("image", "i", "debug container image (default busybox)"),
+		},
+		Usage:    "<pid> [command]",
+		Validate: stdcli.ArgsMin(1),
+	})
+
+	register("ps forward", "forward local ports to a process", PsForward, stdcli.CommandOptions{
+		Flags: []stdcli.Flag{
+			flagApp,
+			flagRack,
+			stdcli.BoolFlag("udp", "u", "forward udp instead of tcp"),
+		},
+		Usage:    "<pid> <[port:]remoteport> [[port:]remoteport]...",
+		Validate: stdcli.ArgsMin(2),
+	})
+
 	register("ps info", "get information about a process", PsInfo, stdcli.CommandOptions{
-		Flags:    []stdcli.Flag{flagApp, flagRack},
+		Flags:    []stdcli.Flag{flagApp, flagRack, flagFormat},
+		Validate: stdcli.Args(1),
+	})
+
+	register("ps logs", "get logs for a process", PsLogs, stdcli.CommandOptions{
+		Flags:    append(stdcli.OptionFlags(structs.LogsOptions{}), flagApp, flagRack, flagNoFollow),
+		Usage:    "<pid>",
 		Validate: stdcli.Args(1),
 	})
 
@@ -36,6 +69,14 @@ func Ps(rack sdk.Interface, c *stdcli.Context) error {
 		return err
 	}
 
+	if formatted(c) {
+		return printFormatted(c, ps)
+	}
+
+	if common.DefaultBool(opts.History, false) {
+		return psHistory(c, ps)
+	}
+
 	t := c.Table("ID", "SERVICE", "STATUS", "RELEASE", "STARTED", "COMMAND")
 
 	for _, p := range ps {
@@ -45,14 +86,81 @@ func Ps(rack sdk.Interface, c *stdcli.Context) error {
 	return t.Print()
 }
 
-func PsInfo(rack sdk.Interface, c *stdcli.Context) error {
-	i := c.Info()
+func psHistory(c *stdcli.Context, ps structs.Processes) error {
+	sort.Slice(ps, func(i, j int) bool { return ps[i].Started.After(ps[j].Started) })
+
+	t := c.Table("ID", "SERVICE", "STATUS", "EXIT", "RELEASE", "STARTED", "ENDED", "IDENTITY", "COMMAND")
+
+	for _, p := range ps {
+		exit, ended := "", ""
+
+		if p.ExitCode != nil {
+			exit = strconv.Itoa(*p.ExitCode)
+		}
+
+		if p.Ended != nil {
+			ended = common.Ago(*p.Ended)
+		}
+
+		t.AddRow(p.Id, p.Name, p.Status, exit, p.Release, common.Ago(p.Started), ended, p.Identity, p.Command)
+	}
+
+	return t.Print()
+}
+
+func PsDebug(rack sdk.Interface, c *stdcli.Context) error {
+	pid := c.Arg(0)
+
+	opts := structs.ProcessDebugOptions{}
+
+	if command := strings.Join(c.Args[1:], " "); command != "" {
+		opts.Command = options.String(command)
+	}
+
+	if image := c.String("image"); image != "" {
+		opts.Image = options.String(image)
+	}
+
+	if w, h, err := c.TerminalSize(); err == nil {
+		opts.Height = options.Int(h)
+		opts.Width = options.Int(w)
+	}
+
+	if !stdcli.IsTerminal(os.Stdin) {
+		opts.Tty = options.Bool(false)
+	}
+
+	restore := c.TerminalRaw()
+	defer restore()
 
+	code, err := rack.ProcessDebug(app(c), pid, c, opts)
+	if err != nil {
+		return err
+	}
+
+	return stdcli.Exit(code)
+}
+
+func PsForward(rack sdk.Interface, c *stdcli.Context) error {
+	pid := c.Arg(0)
+
+	return forward(c, pid, c.Args[1:], func(port int, rw io.ReadWriter, protocol string) error {
+		return rack.WithContext(c.Context).ProcessForward(app(c), pid, port, rw, structs.ProcessForwardOptions{Protocol: options.String(protocol)})
+	})
+}
+
+func PsInfo(rack sdk.Interface, c *stdcli.Context) error {
 	ps, err := rack.ProcessGet(app(c), c.Arg(0))
 	if err != nil {
 		return err
 	}
 
+	if formatted(c) {
+		return printFormatted(c, ps)
+	}
+
+	i := c.Info()
+
 	i.Add("Id", ps.Id)
 	i.Add("App", ps.App)
 	i.Add("Command", ps.Command)
@@ -62,9 +170,44 @@ func PsInfo(rack sdk.Interface, c *stdcli.Context) error {
 	i.Add("Started", common.Ago(ps.Started))
 	i.Add("Status", ps.Status)
 
+	if ps.Ended != nil {
+		i.Add("Ended", common.Ago(*ps.Ended))
+	}
+
+	if ps.ExitCode != nil {
+		i.Add("Exit Code", strconv.Itoa(*ps.ExitCode))
+	}
+
+	if ps.Identity != "" {
+		i.Add("Identity", ps.Identity)
+	}
+
 	return i.Print()
 }
 
+func PsLogs(rack sdk.Interface, c *stdcli.Context) error {
+	var opts structs.LogsOptions
+
+	if err := c.Options(&opts); err != nil {
+		return err
+	}
+
+	if c.Bool("no-follow") {
+		opts.Follow = options.Bool(false)
+	}
+
+	opts.Prefix = options.Bool(true)
+
+	r, err := rack.ProcessLogs(app(c), c.Arg(0), opts)
+	if err != nil {
+		return err
+	}
+
+	io.Copy(c, r)
+
+	return nil
+}
+
 func PsStop(rack sdk.Interface, c *stdcli.Context) error {
 	c.Startf("Stopping <process>%s</process>", c.Arg(0))
 
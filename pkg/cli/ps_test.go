@@ -1,12 +1,22 @@
 package cli_test
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/convox/convox/pkg/cli"
+	"github.com/convox/convox/pkg/common"
 	mocksdk "github.com/convox/convox/pkg/mock/sdk"
+	"github.com/convox/convox/pkg/options"
 	"github.com/convox/convox/pkg/structs"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -26,6 +36,19 @@ func TestPs(t *testing.T) {
 	})
 }
 
+func TestPsJSON(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		v := structs.Processes{*fxProcess(), *fxProcessPending()}
+		i.On("ProcessList", "app1", structs.ProcessListOptions{}).Return(v, nil)
+
+		res, err := testExecute(e, "ps -a app1 --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireJSON(t, v)
+	})
+}
+
 func TestPsError(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("ProcessList", "app1", structs.ProcessListOptions{}).Return(nil, fmt.Errorf("err1"))
@@ -38,6 +61,26 @@ func TestPsError(t *testing.T) {
 	})
 }
 
+func TestPsHistory(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		p1 := fxProcess()
+		p1.Status = "complete"
+		p1.ExitCode = options.Int(0)
+		p1.Ended = &p1.Started
+		i.On("ProcessList", "app1", structs.ProcessListOptions{History: options.Bool(true)}).Return(structs.Processes{*fxProcessCompleted(), *p1}, nil)
+
+		res, err := testExecute(e, "ps -a app1 --history", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"ID    SERVICE  STATUS    EXIT  RELEASE   STARTED     ENDED       IDENTITY  COMMAND        ",
+			"pid1  name     complete  0     release1  2 days ago  2 days ago            command        ",
+			"pid2  web      failed    1     release1  3 days ago  2 days ago  deploy    rake db:migrate",
+		})
+	})
+}
+
 func TestPsInfo(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("ProcessGet", "app1", "pid1").Return(fxProcess(), nil)
@@ -59,6 +102,19 @@ func TestPsInfo(t *testing.T) {
 	})
 }
 
+func TestPsInfoJSON(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		v := fxProcess()
+		i.On("ProcessGet", "app1", "pid1").Return(v, nil)
+
+		res, err := testExecute(e, "ps info pid1 -a app1 --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireJSON(t, v)
+	})
+}
+
 func TestPsInfoError(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("ProcessGet", "app1", "pid1").Return(nil, fmt.Errorf("err1"))
@@ -71,6 +127,57 @@ func TestPsInfoError(t *testing.T) {
 	})
 }
 
+func TestPsInfoCompleted(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("ProcessGet", "app1", "pid2").Return(fxProcessCompleted(), nil)
+
+		res, err := testExecute(e, "ps info pid2 -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"Id         pid2",
+			"App        app1",
+			"Command    rake db:migrate",
+			"Instance   ",
+			"Release    release1",
+			"Service    web",
+			"Started    3 days ago",
+			"Status     failed",
+			"Ended      2 days ago",
+			"Exit Code  1",
+			"Identity   deploy",
+		})
+	})
+}
+
+func TestPsLogs(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("ProcessLogs", "app1", "pid2", structs.LogsOptions{Prefix: options.Bool(true)}).Return(testLogs(fxLogs()), nil)
+
+		res, err := testExecute(e, "ps logs pid2 -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			fxLogs()[0],
+			fxLogs()[1],
+		})
+	})
+}
+
+func TestPsLogsError(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("ProcessLogs", "app1", "pid2", structs.LogsOptions{Prefix: options.Bool(true)}).Return(nil, fmt.Errorf("err1"))
+
+		res, err := testExecute(e, "ps logs pid2 -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: err1"})
+		res.RequireStdout(t, []string{""})
+	})
+}
+
 func TestPsStop(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("ProcessStop", "app1", "pid1").Return(nil)
@@ -94,3 +201,150 @@ func TestPsStopError(t *testing.T) {
 		res.RequireStdout(t, []string{"Stopping pid1... "})
 	})
 }
+
+func TestPsForward(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		i.On("WithContext", ctx).Return(i)
+		i.On("ProcessForward", "app1", "pid1", 3000, mock.Anything, structs.ProcessForwardOptions{Protocol: options.String("tcp")}).Return(nil).Run(func(args mock.Arguments) {
+			buf := make([]byte, 2)
+			rwc := args.Get(3).(io.ReadWriteCloser)
+			n, err := rwc.Read(buf)
+			require.NoError(t, err)
+			require.Equal(t, 2, n)
+			require.Equal(t, "in", string(buf))
+			n, err = rwc.Write([]byte("out"))
+			require.NoError(t, err)
+			require.Equal(t, 3, n)
+			rwc.Close()
+		})
+
+		port := rand.Intn(30000) + 10000
+
+		ch := make(chan *result)
+
+		go func() {
+			res, _ := testExecuteContext(ctx, e, fmt.Sprintf("ps forward pid1 %d:3000 -a app1", port), nil)
+			ch <- res
+		}()
+
+		time.Sleep(500 * time.Millisecond)
+
+		cn, err := net.Dial("tcp", fmt.Sprintf("localhost:%d", port))
+		require.NoError(t, err)
+
+		cn.Write([]byte("in"))
+
+		data, err := ioutil.ReadAll(cn)
+		require.NoError(t, err)
+		require.Equal(t, "out", string(data))
+
+		cancel()
+
+		res := <-ch
+
+		require.NotNil(t, res)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			fmt.Sprintf("forwarding localhost:%d to pid1:3000", port),
+			fmt.Sprintf("connect: %d", port),
+		})
+	})
+}
+
+func TestPsForwardUDP(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		i.On("WithContext", ctx).Return(i)
+		i.On("ProcessForward", "app1", "pid1", 53, mock.Anything, structs.ProcessForwardOptions{Protocol: options.String("udp")}).Return(nil).Run(func(args mock.Arguments) {
+			rw := args.Get(3).(io.ReadWriter)
+			data, err := common.ReadDatagram(rw)
+			require.NoError(t, err)
+			require.Equal(t, "in", string(data))
+			require.NoError(t, common.WriteDatagram(rw, []byte("out")))
+		})
+
+		port := rand.Intn(30000) + 10000
+
+		ch := make(chan *result)
+
+		go func() {
+			res, _ := testExecuteContext(ctx, e, fmt.Sprintf("ps forward pid1 %d:53 --udp -a app1", port), nil)
+			ch <- res
+		}()
+
+		time.Sleep(500 * time.Millisecond)
+
+		cn, err := net.Dial("udp", fmt.Sprintf("127.0.0.1:%d", port))
+		require.NoError(t, err)
+
+		cn.Write([]byte("in"))
+		cn.SetReadDeadline(time.Now().Add(2 * time.Second))
+
+		buf := make([]byte, 10)
+		n, err := cn.Read(buf)
+		require.NoError(t, err)
+		require.Equal(t, "out", string(buf[0:n]))
+
+		cancel()
+
+		res := <-ch
+
+		require.NotNil(t, res)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			fmt.Sprintf("forwarding localhost:%d to pid1:53 (udp)", port),
+		})
+	})
+}
+
+func TestPsForwardInvalid(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		res, err := testExecute(e, "ps forward pid1 80:http -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: invalid port: 80:http"})
+		res.RequireStdout(t, []string{""})
+	})
+}
+
+func TestPsDebug(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		opts := structs.ProcessDebugOptions{
+			Command: options.String("ps aux"),
+			Image:   options.String("alpine"),
+			Tty:     options.Bool(false),
+		}
+		i.On("ProcessDebug", "app1", "pid1", mock.Anything, opts).Return(3, nil).Run(func(args mock.Arguments) {
+			data, err := ioutil.ReadAll(args.Get(2).(io.Reader))
+			require.NoError(t, err)
+			require.Equal(t, "in", string(data))
+			args.Get(2).(io.Writer).Write([]byte("out"))
+		})
+
+		res, err := testExecute(e, "ps debug pid1 ps aux --image alpine -a app1", strings.NewReader("in"))
+		require.NoError(t, err)
+		require.Equal(t, 3, res.Code)
+		res.RequireStderr(t, []string{""})
+		require.Equal(t, "out", res.Stdout)
+	})
+}
+
+func TestPsDebugError(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		opts := structs.ProcessDebugOptions{Tty: options.Bool(false)}
+		i.On("ProcessDebug", "app1", "pid1", mock.Anything, opts).Return(0, fmt.Errorf("err1"))
+
+		res, err := testExecute(e, "ps debug pid1 -a app1", strings.NewReader("in"))
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: err1"})
+		res.RequireStdout(t, []string{""})
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/convox/convox/pkg/common"
 	"github.com/convox/convox/pkg/options"
@@ -17,7 +18,16 @@ import (
 
 func init() {
 	register("rack", "get information about the rack", Rack, stdcli.CommandOptions{
-		Flags:    []stdcli.Flag{flagRack},
+		Flags:    []stdcli.Flag{flagRack, flagFormat},
+		Validate: stdcli.Args(0),
+	})
+
+	register("rack audit", "list audited api calls", RackAudit, stdcli.CommandOptions{
+		Flags: append(stdcli.OptionFlags(structs.AuditListOptions{}),
+			flagFormat,
+			flagRack,
+			stdcli.BoolFlag("follow", "f", "follow new entries"),
+		),
 		Validate: stdcli.Args(0),
 	})
 
@@ -27,7 +37,7 @@ func init() {
 	})
 
 	register("rack params", "display rack parameters", RackParams, stdcli.CommandOptions{
-		Flags:    []stdcli.Flag{flagRack},
+		Flags:    []stdcli.Flag{flagRack, flagFormat},
 		Validate: stdcli.Args(0),
 	})
 
@@ -38,12 +48,12 @@ func init() {
 	})
 
 	register("rack ps", "list rack processes", RackPs, stdcli.CommandOptions{
-		Flags:    append(stdcli.OptionFlags(structs.SystemProcessesOptions{}), flagRack),
+		Flags:    append(stdcli.OptionFlags(structs.SystemProcessesOptions{}), flagRack, flagFormat),
 		Validate: stdcli.Args(0),
 	})
 
 	register("rack releases", "list rack version history", RackReleases, stdcli.CommandOptions{
-		Flags:    []stdcli.Flag{flagRack},
+		Flags:    []stdcli.Flag{flagRack, flagFormat},
 		Validate: stdcli.Args(0),
 	})
 
@@ -56,6 +66,26 @@ func init() {
 		Validate: stdcli.Args(0),
 	})
 
+	register("rack tokens", "list api tokens", RackTokens, stdcli.CommandOptions{
+		Flags:    []stdcli.Flag{flagRack, flagFormat},
+		Validate: stdcli.Args(0),
+	})
+
+	register("rack tokens create", "create an api token", RackTokensCreate, stdcli.CommandOptions{
+		Flags: append(stdcli.OptionFlags(structs.TokenCreateOptions{}),
+			flagRack,
+			stdcli.StringFlag("apps", "", "comma-separated list of apps to scope the token to"),
+		),
+		Usage:    "<name>",
+		Validate: stdcli.Args(1),
+	})
+
+	register("rack tokens revoke", "revoke an api token", RackTokensRevoke, stdcli.CommandOptions{
+		Flags:    []stdcli.Flag{flagRack},
+		Usage:    "<id>",
+		Validate: stdcli.Args(1),
+	})
+
 	register("rack update", "update the rack", RackUpdate, stdcli.CommandOptions{
 		Flags:    []stdcli.Flag{flagRack, flagWait},
 		Validate: stdcli.ArgsMax(1),
@@ -73,6 +103,10 @@ func Rack(rack sdk.Interface, c *stdcli.Context) error {
 		return err
 	}
 
+	if formatted(c) {
+		return printFormatted(c, s)
+	}
+
 	i := c.Info()
 
 	i.Add("Name", s.Name)
@@ -96,6 +130,69 @@ func Rack(rack sdk.Interface, c *stdcli.Context) error {
 	return i.Print()
 }
 
+func RackAudit(rack sdk.Interface, c *stdcli.Context) error {
+	var opts structs.AuditListOptions
+
+	if err := c.Options(&opts); err != nil {
+		return err
+	}
+
+	es, err := rack.AuditList(opts)
+	if err != nil {
+		return err
+	}
+
+	if !c.Bool("follow") {
+		if formatted(c) {
+			return printFormatted(c, es)
+		}
+
+		t := c.Table("TIME", "IDENTITY", "ROUTE", "APP", "CODE", "DURATION")
+
+		for _, e := range es {
+			t.AddRow(common.Ago(e.Time), e.Identity, e.Route, e.App, fmt.Sprintf("%d", e.Code), e.Duration.String())
+		}
+
+		return t.Print()
+	}
+
+	seen := map[string]bool{}
+
+	for {
+		for i := len(es) - 1; i >= 0; i-- {
+			e := es[i]
+
+			if seen[e.Id] {
+				continue
+			}
+
+			seen[e.Id] = true
+
+			if formatted(c) {
+				if err := printFormatted(c, e); err != nil {
+					return err
+				}
+				continue
+			}
+
+			c.Writef("%s identity=%s route=%s app=%s path=%s code=%d duration=%s\n", e.Time.Format(time.RFC3339), e.Identity, e.Route, e.App, e.Path, e.Code, e.Duration)
+		}
+
+		select {
+		case <-c.Done():
+			return nil
+		case <-time.After(WaitDuration):
+		}
+
+		opts.Since = options.Duration(WaitDuration + time.Minute)
+
+		es, err = rack.AuditList(opts)
+		if err != nil {
+			return err
+		}
+	}
+}
+
 func RackLogs(rack sdk.Interface, c *stdcli.Context) error {
 	var opts structs.LogsOptions
 
@@ -133,6 +230,10 @@ func RackParams(rack sdk.Interface, c *stdcli.Context) error {
 
 	sort.Strings(keys)
 
+	if formatted(c) {
+		return printFormatted(c, s.Parameters)
+	}
+
 	i := c.Info()
 
 	for _, k := range keys {
@@ -197,6 +298,10 @@ func RackPs(rack sdk.Interface, c *stdcli.Context) error {
 		return err
 	}
 
+	if formatted(c) {
+		return printFormatted(c, ps)
+	}
+
 	t := c.Table("ID", "APP", "SERVICE", "STATUS", "RELEASE", "STARTED", "COMMAND")
 
 	for _, p := range ps {
@@ -212,6 +317,10 @@ func RackReleases(rack sdk.Interface, c *stdcli.Context) error {
 		return err
 	}
 
+	if formatted(c) {
+		return printFormatted(c, rs)
+	}
+
 	t := c.Table("VERSION", "UPDATED")
 
 	for _, r := range rs {
@@ -260,6 +369,60 @@ func RackScale(rack sdk.Interface, c *stdcli.Context) error {
 	return i.Print()
 }
 
+func RackTokens(rack sdk.Interface, c *stdcli.Context) error {
+	ts, err := rack.TokenList()
+	if err != nil {
+		return err
+	}
+
+	if formatted(c) {
+		return printFormatted(c, ts)
+	}
+
+	t := c.Table("ID", "NAME", "ROLE", "APPS", "CREATED")
+
+	for _, tk := range ts {
+		t.AddRow(tk.Id, tk.Name, tk.Role, strings.Join(tk.Apps, ","), common.Ago(tk.Created))
+	}
+
+	return t.Print()
+}
+
+func RackTokensCreate(rack sdk.Interface, c *stdcli.Context) error {
+	var opts structs.TokenCreateOptions
+
+	if err := c.Options(&opts); err != nil {
+		return err
+	}
+
+	if apps := c.String("apps"); apps != "" {
+		opts.Apps = strings.Split(apps, ",")
+	}
+
+	c.Startf("Creating token <setting>%s</setting>", c.Arg(0))
+
+	t, err := rack.TokenCreate(c.Arg(0), opts)
+	if err != nil {
+		return err
+	}
+
+	c.OK(t.Id)
+
+	c.Writef("Secret: %s\n", t.Secret)
+
+	return nil
+}
+
+func RackTokensRevoke(rack sdk.Interface, c *stdcli.Context) error {
+	c.Startf("Revoking token <setting>%s</setting>", c.Arg(0))
+
+	if err := rack.TokenDelete(c.Arg(0)); err != nil {
+		return err
+	}
+
+	return c.OK()
+}
+
 func RackUpdate(rack sdk.Interface, c *stdcli.Context) error {
 	target := c.Arg(0)
 
@@ -1,6 +1,7 @@
 package cli_test
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -31,6 +32,19 @@ func TestRack(t *testing.T) {
 	})
 }
 
+func TestRackJSON(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		v := fxSystem()
+		i.On("SystemGet").Return(v, nil)
+
+		res, err := testExecute(e, "rack --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireJSON(t, v)
+	})
+}
+
 func TestRackError(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("SystemGet").Return(nil, fmt.Errorf("err1"))
@@ -63,6 +77,71 @@ func TestRackInternal(t *testing.T) {
 	})
 }
 
+func TestRackAudit(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		opts := structs.AuditListOptions{App: options.String("app1")}
+		i.On("AuditList", opts).Return(structs.AuditEntries{*fxAuditEntry(), *fxAuditEntry()}, nil)
+
+		res, err := testExecute(e, "rack audit --app app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"TIME        IDENTITY  ROUTE           APP   CODE  DURATION",
+			"2 days ago  ci        ReleasePromote  app1  200   2s      ",
+			"2 days ago  ci        ReleasePromote  app1  200   2s      ",
+		})
+	})
+}
+
+func TestRackAuditJSON(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		v := structs.AuditEntries{*fxAuditEntry()}
+		i.On("AuditList", structs.AuditListOptions{}).Return(v, nil)
+
+		res, err := testExecute(e, "rack audit --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireJSON(t, v)
+	})
+}
+
+func TestRackAuditError(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("AuditList", structs.AuditListOptions{}).Return(nil, fmt.Errorf("err1"))
+
+		res, err := testExecute(e, "rack audit", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: err1"})
+		res.RequireStdout(t, []string{""})
+	})
+}
+
+func TestRackAuditFollow(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		a1 := *fxAuditEntry()
+		a2 := *fxAuditEntry()
+		a2.Id = "audit2"
+		a2.Route = "ServiceUpdate"
+		i.On("AuditList", structs.AuditListOptions{}).Return(structs.AuditEntries{a1}, nil).Once()
+		i.On("AuditList", structs.AuditListOptions{Since: options.Duration(time.Minute + 1)}).Return(structs.AuditEntries{a2, a1}, nil)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		res, err := testExecuteContext(ctx, e, "rack audit --follow", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			fmt.Sprintf("%s identity=ci route=ReleasePromote app=app1 path=/apps/app1/releases/release1/promote code=200 duration=2s", fxStarted.Format(time.RFC3339)),
+			fmt.Sprintf("%s identity=ci route=ServiceUpdate app=app1 path=/apps/app1/releases/release1/promote code=200 duration=2s", fxStarted.Format(time.RFC3339)),
+		})
+	})
+}
+
 func TestRackLogs(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("SystemLogs", structs.LogsOptions{Prefix: options.Bool(true)}).Return(testLogs(fxLogs()), nil)
@@ -106,6 +185,19 @@ func TestRackParams(t *testing.T) {
 	})
 }
 
+func TestRackParamsJSON(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		v := fxSystem()
+		i.On("SystemGet").Return(v, nil)
+
+		res, err := testExecute(e, "rack params --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireJSON(t, v.Parameters)
+	})
+}
+
 func TestRackParamsError(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("SystemGet").Return(nil, fmt.Errorf("err1"))
@@ -198,6 +290,19 @@ func TestRackPs(t *testing.T) {
 	})
 }
 
+func TestRackPsJSON(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		v := structs.Processes{*fxProcess(), *fxProcessPending()}
+		i.On("SystemProcesses", structs.SystemProcessesOptions{}).Return(v, nil)
+
+		res, err := testExecute(e, "rack ps --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireJSON(t, v)
+	})
+}
+
 func TestRackPsError(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("SystemProcesses", structs.SystemProcessesOptions{}).Return(nil, fmt.Errorf("err1"))
@@ -242,6 +347,19 @@ func TestRackReleases(t *testing.T) {
 	})
 }
 
+func TestRackReleasesJSON(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		v := structs.Releases{*fxRelease(), *fxRelease()}
+		i.On("SystemReleases").Return(v, nil)
+
+		res, err := testExecute(e, "rack releases --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireJSON(t, v)
+	})
+}
+
 func TestRackReleasesError(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("SystemReleases").Return(nil, fmt.Errorf("err1"))
@@ -309,6 +427,105 @@ func TestRackScaleUpdateError(t *testing.T) {
 	})
 }
 
+func TestRackTokens(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("TokenList").Return(structs.Tokens{*fxToken(), *fxToken()}, nil)
+
+		res, err := testExecute(e, "rack tokens", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"ID      NAME  ROLE      APPS       CREATED   ",
+			"token1  ci    deployer  app1,app2  2 days ago",
+			"token1  ci    deployer  app1,app2  2 days ago",
+		})
+	})
+}
+
+func TestRackTokensJSON(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		v := structs.Tokens{*fxToken()}
+		i.On("TokenList").Return(v, nil)
+
+		res, err := testExecute(e, "rack tokens --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireJSON(t, v)
+	})
+}
+
+func TestRackTokensError(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("TokenList").Return(nil, fmt.Errorf("err1"))
+
+		res, err := testExecute(e, "rack tokens", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: err1"})
+		res.RequireStdout(t, []string{""})
+	})
+}
+
+func TestRackTokensCreate(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		tk := fxToken()
+		tk.Secret = "secret1"
+		opts := structs.TokenCreateOptions{
+			Apps: []string{"app1", "app2"},
+			Role: options.String("deployer"),
+		}
+		i.On("TokenCreate", "ci", opts).Return(tk, nil)
+
+		res, err := testExecute(e, "rack tokens create ci --role deployer --apps app1,app2", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"Creating token ci... OK, token1",
+			"Secret: secret1",
+		})
+	})
+}
+
+func TestRackTokensCreateError(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		opts := structs.TokenCreateOptions{}
+		i.On("TokenCreate", "ci", opts).Return(nil, fmt.Errorf("err1"))
+
+		res, err := testExecute(e, "rack tokens create ci", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: err1"})
+		res.RequireStdout(t, []string{"Creating token ci... "})
+	})
+}
+
+func TestRackTokensRevoke(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("TokenDelete", "token1").Return(nil)
+
+		res, err := testExecute(e, "rack tokens revoke token1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{"Revoking token token1... OK"})
+	})
+}
+
+func TestRackTokensRevokeError(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("TokenDelete", "token1").Return(fmt.Errorf("err1"))
+
+		res, err := testExecute(e, "rack tokens revoke token1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: err1"})
+		res.RequireStdout(t, []string{"Revoking token token1... "})
+	})
+}
+
 func TestRackUpdate(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("SystemUpdate", structs.SystemUpdateOptions{Version: options.String("version1")}).Return(nil)
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/convox/convox/pkg/common"
+	"github.com/convox/convox/pkg/diff"
 	"github.com/convox/convox/pkg/options"
 	"github.com/convox/convox/pkg/structs"
 	"github.com/convox/convox/sdk"
@@ -15,12 +16,18 @@ import (
 
 func init() {
 	register("releases", "list releases for an app", Releases, stdcli.CommandOptions{
-		Flags:    append(stdcli.OptionFlags(structs.ReleaseListOptions{}), flagRack, flagApp),
+		Flags:    append(stdcli.OptionFlags(structs.ReleaseListOptions{}), flagRack, flagApp, flagFormat),
 		Validate: stdcli.Args(0),
 	})
 
+	register("releases diff", "compare two releases", ReleasesDiff, stdcli.CommandOptions{
+		Flags:    append(stdcli.OptionFlags(structs.ReleaseDiffOptions{}), flagApp, flagRack, flagFormat),
+		Usage:    "[from] <to>",
+		Validate: stdcli.ArgsBetween(1, 2),
+	})
+
 	register("releases info", "get information about a release", ReleasesInfo, stdcli.CommandOptions{
-		Flags:    []stdcli.Flag{flagApp, flagRack},
+		Flags:    []stdcli.Flag{flagApp, flagRack, flagFormat},
 		Validate: stdcli.Args(1),
 	})
 
@@ -57,6 +64,10 @@ func Releases(rack sdk.Interface, c *stdcli.Context) error {
 		return err
 	}
 
+	if formatted(c) {
+		return printFormatted(c, rs)
+	}
+
 	t := c.Table("ID", "STATUS", "BUILD", "CREATED", "DESCRIPTION")
 
 	for _, r := range rs {
@@ -72,12 +83,74 @@ func Releases(rack sdk.Interface, c *stdcli.Context) error {
 	return t.Print()
 }
 
+func ReleasesDiff(rack sdk.Interface, c *stdcli.Context) error {
+	var opts structs.ReleaseDiffOptions
+
+	if err := c.Options(&opts); err != nil {
+		return err
+	}
+
+	to := c.Arg(0)
+
+	if len(c.Args) > 1 {
+		opts.From = options.String(c.Arg(0))
+		to = c.Arg(1)
+	}
+
+	d, err := rack.ReleaseDiff(app(c), to, opts)
+	if err != nil {
+		return err
+	}
+
+	if formatted(c) {
+		return printFormatted(c, d)
+	}
+
+	c.Writef("<release>%s</release> -> <release>%s</release>\n", coalesce(d.From, "none"), d.To)
+
+	if d.Empty() {
+		c.Writef("\nNo differences\n")
+		return nil
+	}
+
+	if b := d.Build; b != nil {
+		c.Writef("\n<h1>BUILD</h1>\n")
+		c.Writef("  <build>%s</build> -> <build>%s</build>  %s\n", coalesce(b.From, "none"), coalesce(b.To, "none"), b.Description)
+	}
+
+	if len(d.Env) > 0 {
+		c.Writef("\n<h1>ENV</h1>\n")
+
+		for _, e := range d.Env {
+			c.Writef("  %s\n", releaseDiffEnv(e))
+		}
+	}
+
+	if len(d.Services) > 0 {
+		c.Writef("\n<h1>SERVICES</h1>\n")
+
+		for _, s := range d.Services {
+			c.Writef("  %s <service>%s</service>\n", releaseDiffSymbol(s.Change), s.Name)
+
+			for _, sc := range s.Changes {
+				c.Writef("      %s: %s -> %s\n", sc.Name, coalesce(sc.From, `""`), coalesce(sc.To, `""`))
+			}
+		}
+	}
+
+	return nil
+}
+
 func ReleasesInfo(rack sdk.Interface, c *stdcli.Context) error {
 	r, err := rack.ReleaseGet(app(c), c.Arg(0))
 	if err != nil {
 		return err
 	}
 
+	if formatted(c) {
+		return printFormatted(c, r)
+	}
+
 	i := c.Info()
 
 	i.Add("Id", r.Id)
@@ -232,3 +305,29 @@ func ReleasesRollback(rack sdk.Interface, c *stdcli.Context) error {
 
 	return c.OK()
 }
+
+func releaseDiffEnv(c structs.ReleaseDiffChange) string {
+	line := fmt.Sprintf("%s %s", releaseDiffSymbol(c.Change), c.Name)
+
+	switch {
+	case c.Change == diff.Changed && (c.From != "" || c.To != ""):
+		line += fmt.Sprintf(": %s -> %s", c.From, c.To)
+	case c.From != "":
+		line += "=" + c.From
+	case c.To != "":
+		line += "=" + c.To
+	}
+
+	return line
+}
+
+func releaseDiffSymbol(change string) string {
+	switch change {
+	case diff.Added:
+		return "+"
+	case diff.Removed:
+		return "-"
+	default:
+		return "~"
+	}
+}
@@ -29,6 +29,20 @@ func TestReleases(t *testing.T) {
 	})
 }
 
+func TestReleasesJSON(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("AppGet", "app1").Return(fxApp(), nil)
+		v := structs.Releases{*fxRelease(), *fxRelease2()}
+		i.On("ReleaseList", "app1", structs.ReleaseListOptions{}).Return(v, nil)
+
+		res, err := testExecute(e, "releases -a app1 --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireJSON(t, v)
+	})
+}
+
 func TestReleasesError(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("AppGet", "app1").Return(fxApp(), nil)
@@ -42,6 +56,123 @@ func TestReleasesError(t *testing.T) {
 	})
 }
 
+func fxReleaseDiff() *structs.ReleaseDiff {
+	return &structs.ReleaseDiff{
+		App:   "app1",
+		From:  "release1",
+		To:    "release2",
+		Build: &structs.ReleaseDiffBuild{From: "build1", To: "build2", Description: "description2"},
+		Env: []structs.ReleaseDiffChange{
+			{Change: "changed", Name: "FOO"},
+			{Change: "added", Name: "NEW"},
+		},
+		Services: []structs.ReleaseDiffService{
+			{Change: "added", Name: "clock"},
+			{Change: "changed", Name: "web", Changes: []structs.ReleaseDiffChange{
+				{Change: "changed", Name: "health.path", From: "/", To: "/check"},
+				{Change: "changed", Name: "port", From: "http:3000", To: "https:3000"},
+			}},
+		},
+	}
+}
+
+func TestReleasesDiff(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("ReleaseDiff", "app1", "release2", structs.ReleaseDiffOptions{From: options.String("release1")}).Return(fxReleaseDiff(), nil)
+
+		res, err := testExecute(e, "releases diff release1 release2 -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"release1 -> release2",
+			"",
+			"BUILD",
+			"  build1 -> build2  description2",
+			"",
+			"ENV",
+			"  ~ FOO",
+			"  + NEW",
+			"",
+			"SERVICES",
+			"  + clock",
+			"  ~ web",
+			"      health.path: / -> /check",
+			"      port: http:3000 -> https:3000",
+		})
+	})
+}
+
+func TestReleasesDiffActive(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("ReleaseDiff", "app1", "release2", structs.ReleaseDiffOptions{}).Return(&structs.ReleaseDiff{App: "app1", From: "release1", To: "release2"}, nil)
+
+		res, err := testExecute(e, "releases diff release2 -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"release1 -> release2",
+			"",
+			"No differences",
+		})
+	})
+}
+
+func TestReleasesDiffReveal(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		d := &structs.ReleaseDiff{
+			App:  "app1",
+			From: "release1",
+			To:   "release2",
+			Env: []structs.ReleaseDiffChange{
+				{Change: "changed", Name: "FOO", From: "bar", To: "baz"},
+				{Change: "added", Name: "NEW", To: "2"},
+				{Change: "removed", Name: "OLD", From: "1"},
+			},
+		}
+		i.On("ReleaseDiff", "app1", "release2", structs.ReleaseDiffOptions{From: options.String("release1"), Reveal: options.Bool(true)}).Return(d, nil)
+
+		res, err := testExecute(e, "releases diff release1 release2 -a app1 --reveal", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"release1 -> release2",
+			"",
+			"ENV",
+			"  ~ FOO: bar -> baz",
+			"  + NEW=2",
+			"  - OLD=1",
+		})
+	})
+}
+
+func TestReleasesDiffJSON(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		v := fxReleaseDiff()
+		i.On("ReleaseDiff", "app1", "release2", structs.ReleaseDiffOptions{From: options.String("release1")}).Return(v, nil)
+
+		res, err := testExecute(e, "releases diff release1 release2 -a app1 --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireJSON(t, v)
+	})
+}
+
+func TestReleasesDiffError(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("ReleaseDiff", "app1", "release2", structs.ReleaseDiffOptions{}).Return(nil, fmt.Errorf("err1"))
+
+		res, err := testExecute(e, "releases diff release2 -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: err1"})
+		res.RequireStdout(t, []string{""})
+	})
+}
+
 func TestReleasesInfo(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("ReleaseGet", "app1", "release1").Return(fxRelease(), nil)
@@ -61,6 +192,19 @@ func TestReleasesInfo(t *testing.T) {
 	})
 }
 
+func TestReleasesInfoJSON(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		v := fxRelease()
+		i.On("ReleaseGet", "app1", "release1").Return(v, nil)
+
+		res, err := testExecute(e, "releases info release1 -a app1 --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireJSON(t, v)
+	})
+}
+
 func TestReleasesInfoError(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("ReleaseGet", "app1", "release1").Return(nil, fmt.Errorf("err1"))
@@ -9,18 +9,26 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/convox/convox/pkg/common"
 	"github.com/convox/convox/pkg/options"
 	"github.com/convox/convox/pkg/structs"
 	"github.com/convox/convox/sdk"
 	"github.com/convox/stdcli"
+	humanize "github.com/dustin/go-humanize"
 )
 
 func init() {
 	register("resources", "list resources", Resources, stdcli.CommandOptions{
-		Flags:    []stdcli.Flag{flagRack, flagApp},
+		Flags:    []stdcli.Flag{flagRack, flagApp, flagFormat},
 		Validate: stdcli.Args(0),
 	})
 
+	register("resources backups", "list backups of a resource", ResourcesBackups, stdcli.CommandOptions{
+		Flags:    []stdcli.Flag{flagRack, flagApp, flagFormat},
+		Usage:    "<resource>",
+		Validate: stdcli.Args(1),
+	})
+
 	register("resources console", "start a console for a resource", ResourcesConsole, stdcli.CommandOptions{
 		Flags:    []stdcli.Flag{flagRack, flagApp},
 		Usage:    "<resource>",
@@ -47,7 +55,7 @@ func init() {
 	})
 
 	register("resources info", "get information about a resource", ResourcesInfo, stdcli.CommandOptions{
-		Flags:    []stdcli.Flag{flagRack, flagApp},
+		Flags:    []stdcli.Flag{flagRack, flagApp, flagFormat},
 		Usage:    "<resource>",
 		Validate: stdcli.Args(1),
 	})
@@ -63,6 +71,24 @@ func init() {
 		Validate: stdcli.Args(1),
 	})
 
+	register("resources restore", "restore a resource from a backup", ResourcesRestore, stdcli.CommandOptions{
+		Flags:    []stdcli.Flag{flagRack, flagApp},
+		Usage:    "<resource> <backup>",
+		Validate: stdcli.Args(2),
+	})
+
+	register("resources update", "apply manifest changes to a resource", ResourcesUpdate, stdcli.CommandOptions{
+		Flags: []stdcli.Flag{
+			flagRack,
+			flagApp,
+			flagWait,
+			stdcli.BoolFlag("force", "", "apply changes that may lose data"),
+			stdcli.StringFlag("release", "", "update from this release instead of the current one"),
+		},
+		Usage:    "<resource>",
+		Validate: stdcli.Args(1),
+	})
+
 	register("resources url", "get url for a resource", ResourcesUrl, stdcli.CommandOptions{
 		Flags:    []stdcli.Flag{flagRack, flagApp},
 		Usage:    "<resource>",
@@ -70,7 +96,7 @@ func init() {
 	})
 
 	register("rack resources", "list resources", RackResources, stdcli.CommandOptions{
-		Flags:     []stdcli.Flag{flagRack},
+		Flags:     []stdcli.Flag{flagRack, flagFormat},
 		Invisible: true,
 		Validate:  stdcli.Args(0),
 	})
@@ -94,7 +120,7 @@ func init() {
 	})
 
 	register("rack resources info", "get information about a resource", RackResourcesInfo, stdcli.CommandOptions{
-		Flags:     []stdcli.Flag{flagRack},
+		Flags:     []stdcli.Flag{flagRack, flagFormat},
 		Invisible: true,
 		Usage:     "<resource>",
 		Validate:  stdcli.Args(1),
@@ -108,7 +134,7 @@ func init() {
 	})
 
 	register("rack resources options", "list options for a resource type", RackResourcesOptions, stdcli.CommandOptions{
-		Flags:     []stdcli.Flag{flagRack},
+		Flags:     []stdcli.Flag{flagRack, flagFormat},
 		Invisible: true,
 		Usage:     "<resource>",
 		Validate:  stdcli.Args(1),
@@ -126,7 +152,7 @@ func init() {
 	})
 
 	register("rack resources types", "list resource types", RackResourcesTypes, stdcli.CommandOptions{
-		Flags:     []stdcli.Flag{flagRack},
+		Flags:     []stdcli.Flag{flagRack, flagFormat},
 		Invisible: true,
 		Validate:  stdcli.Args(0),
 	})
@@ -168,6 +194,10 @@ func Resources(rack sdk.Interface, c *stdcli.Context) error {
 		return err
 	}
 
+	if formatted(c) {
+		return printFormatted(c, rs)
+	}
+
 	t := c.Table("NAME", "TYPE", "URL")
 
 	for _, r := range rs {
@@ -177,6 +207,25 @@ func Resources(rack sdk.Interface, c *stdcli.Context) error {
 	return t.Print()
 }
 
+func ResourcesBackups(rack sdk.Interface, c *stdcli.Context) error {
+	rbs, err := rack.ResourceBackupList(app(c), c.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	if formatted(c) {
+		return printFormatted(c, rbs)
+	}
+
+	t := c.Table("ID", "CREATED", "SIZE")
+
+	for _, rb := range rbs {
+		t.AddRow(rb.Id, common.Ago(rb.Created), humanize.Bytes(uint64(rb.Size)))
+	}
+
+	return t.Print()
+}
+
 func ResourcesConsole(rack sdk.Interface, c *stdcli.Context) error {
 	opts := structs.ResourceConsoleOptions{}
 
@@ -260,6 +309,10 @@ func ResourcesInfo(rack sdk.Interface, c *stdcli.Context) error {
 		return err
 	}
 
+	if formatted(c) {
+		return printFormatted(c, r)
+	}
+
 	i := c.Info()
 
 	i.Add("Name", r.Name)
@@ -328,6 +381,74 @@ func ResourcesProxy(rack sdk.Interface, c *stdcli.Context) error {
 	return nil
 }
 
+func ResourcesRestore(rack sdk.Interface, c *stdcli.Context) error {
+	c.Startf("Restoring <id>%s</id> from backup <id>%s</id>", c.Arg(0), c.Arg(1))
+
+	if err := rack.ResourceRestore(app(c), c.Arg(0), c.Arg(1)); err != nil {
+		return err
+	}
+
+	return c.OK()
+}
+
+func ResourcesUpdate(rack sdk.Interface, c *stdcli.Context) error {
+	resource := c.Arg(0)
+
+	opts := structs.ResourceUpdateOptions{
+		DryRun: options.Bool(true),
+	}
+
+	if v := c.String("release"); v != "" {
+		opts.Release = options.String(v)
+	}
+
+	chs, err := rack.ResourceUpdate(app(c), resource, opts)
+	if err != nil {
+		return err
+	}
+
+	if len(chs) == 0 {
+		c.Writef("no changes for <id>%s</id>\n", resource)
+		return nil
+	}
+
+	t := c.Table("PARAMETER", "CURRENT", "DESIRED", "ACTION")
+
+	for _, ch := range chs {
+		t.AddRow(ch.Parameter, ch.Current, ch.Desired, resourceChangeDescription(ch))
+	}
+
+	if err := t.Print(); err != nil {
+		return err
+	}
+
+	if chs.Destructive() && !c.Bool("force") {
+		return fmt.Errorf("changes may lose data, use --force to apply them")
+	}
+
+	opts.DryRun = nil
+
+	if c.Bool("force") {
+		opts.Force = options.Bool(true)
+	}
+
+	c.Startf("Updating <id>%s</id>", resource)
+
+	if _, err := rack.ResourceUpdate(app(c), resource, opts); err != nil {
+		return err
+	}
+
+	if c.Bool("wait") {
+		c.Writef("\n")
+
+		if err := common.WaitForAppWithLogs(rack, c, app(c)); err != nil {
+			return err
+		}
+	}
+
+	return c.OK()
+}
+
 func ResourcesUrl(rack sdk.Interface, c *stdcli.Context) error {
 	s, err := rack.SystemGet()
 	if err != nil {
@@ -369,6 +490,10 @@ func RackResources(rack sdk.Interface, c *stdcli.Context) error {
 		return err
 	}
 
+	if formatted(c) {
+		return printFormatted(c, rs)
+	}
+
 	t := c.Table("NAME", "TYPE", "STATUS")
 
 	for _, r := range rs {
@@ -473,6 +598,10 @@ func RackResourcesInfo(rack sdk.Interface, c *stdcli.Context) error {
 		return err
 	}
 
+	if formatted(c) {
+		return printFormatted(c, r)
+	}
+
 	// fmt.Printf("r = %+v\n", r)
 
 	i := c.Info()
@@ -567,10 +696,14 @@ func RackResourcesOptions(rack sdk.Interface, c *stdcli.Context) error {
 		return fmt.Errorf("no such resource type: %s", c.Arg(0))
 	}
 
-	t := c.Table("NAME", "DEFAULT", "DESCRIPTION")
-
 	sort.Slice(rt.Parameters, rt.Parameters.Less)
 
+	if formatted(c) {
+		return printFormatted(c, rt.Parameters)
+	}
+
+	t := c.Table("NAME", "DEFAULT", "DESCRIPTION")
+
 	for _, p := range rt.Parameters {
 		t.AddRow(p.Name, p.Default, p.Description)
 	}
@@ -653,6 +786,10 @@ func RackResourcesTypes(rack sdk.Interface, c *stdcli.Context) error {
 		return err
 	}
 
+	if formatted(c) {
+		return printFormatted(c, rts)
+	}
+
 	t := c.Table("TYPE")
 
 	for _, rt := range rts {
@@ -766,3 +903,26 @@ func RackResourcesUrl(rack sdk.Interface, c *stdcli.Context) error {
 
 	return nil
 }
+
+func resourceChangeDescription(ch structs.ResourceChange) string {
+	desc := ch.Action
+
+	switch ch.Action {
+	case "delete":
+		desc = "remove the resource"
+	case "expand":
+		desc = "expand the volume in place"
+	case "migrate":
+		desc = "back up and restore into a new volume"
+	case "replace":
+		desc = "replace the resource"
+	case "update":
+		desc = "restart with the new version"
+	}
+
+	if ch.Destructive {
+		desc += ", may lose data"
+	}
+
+	return desc
+}
@@ -35,6 +35,20 @@ func TestResources(t *testing.T) {
 	})
 }
 
+func TestResourcesJSON(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("SystemGet").Return(fxSystem(), nil)
+		v := structs.Resources{*fxResource(), *fxResource()}
+		i.On("ResourceList", "app1").Return(v, nil)
+
+		res, err := testExecute(e, "resources -a app1 --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireJSON(t, v)
+	})
+}
+
 func TestResourcesError(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("SystemGet").Return(fxSystem(), nil)
@@ -48,6 +62,33 @@ func TestResourcesError(t *testing.T) {
 	})
 }
 
+func TestResourcesBackups(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("ResourceBackupList", "app1", "resource1").Return(structs.ResourceBackups{*fxResourceBackup()}, nil)
+
+		res, err := testExecute(e, "resources backups resource1 -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"ID              CREATED     SIZE  ",
+			"20200101030000  2 days ago  2.5 MB",
+		})
+	})
+}
+
+func TestResourcesBackupsError(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("ResourceBackupList", "app1", "resource1").Return(nil, fmt.Errorf("err1"))
+
+		res, err := testExecute(e, "resources backups resource1 -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: err1"})
+		res.RequireStdout(t, []string{""})
+	})
+}
+
 func TestResourcesInfo(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("SystemGet").Return(fxSystem(), nil)
@@ -65,6 +106,20 @@ func TestResourcesInfo(t *testing.T) {
 	})
 }
 
+func TestResourcesInfoJSON(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("SystemGet").Return(fxSystem(), nil)
+		v := fxResource()
+		i.On("ResourceGet", "app1", "resource1").Return(v, nil)
+
+		res, err := testExecute(e, "resources info resource1 -a app1 --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireJSON(t, v)
+	})
+}
+
 func TestResourcesInfoError(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("SystemGet").Return(fxSystem(), nil)
@@ -133,6 +188,106 @@ func TestResourcesProxy(t *testing.T) {
 	})
 }
 
+func TestResourcesRestore(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("ResourceRestore", "app1", "resource1", "20200101030000").Return(nil)
+
+		res, err := testExecute(e, "resources restore resource1 20200101030000 -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{"Restoring resource1 from backup 20200101030000... OK"})
+	})
+}
+
+func TestResourcesRestoreError(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("ResourceRestore", "app1", "resource1", "20200101030000").Return(fmt.Errorf("err1"))
+
+		res, err := testExecute(e, "resources restore resource1 20200101030000 -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: err1"})
+		res.RequireStdout(t, []string{"Restoring resource1 from backup 20200101030000... "})
+	})
+}
+
+func TestResourcesUpdate(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("ResourceUpdate", "app1", "resource1", structs.ResourceUpdateOptions{DryRun: options.Bool(true)}).Return(fxResourceChanges(), nil)
+		i.On("ResourceUpdate", "app1", "resource1", structs.ResourceUpdateOptions{}).Return(fxResourceChanges(), nil)
+
+		res, err := testExecute(e, "resources update resource1 -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"PARAMETER  CURRENT  DESIRED  ACTION                               ",
+			"version    10.5     11.2     back up and restore into a new volume",
+			"storage    10Gi     20Gi     expand the volume in place           ",
+			"Updating resource1... OK",
+		})
+	})
+}
+
+func TestResourcesUpdateDestructive(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		chs := structs.ResourceChanges{{Action: "update", Current: "5.0.3", Desired: "4.0.10", Destructive: true, Parameter: "version"}}
+		i.On("ResourceUpdate", "app1", "resource1", structs.ResourceUpdateOptions{DryRun: options.Bool(true)}).Return(chs, nil)
+
+		res, err := testExecute(e, "resources update resource1 -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: changes may lose data, use --force to apply them"})
+		res.RequireStdout(t, []string{
+			"PARAMETER  CURRENT  DESIRED  ACTION                                     ",
+			"version    5.0.3    4.0.10   restart with the new version, may lose data",
+		})
+	})
+}
+
+func TestResourcesUpdateDestructiveForce(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		chs := structs.ResourceChanges{{Action: "replace", Current: "mysql", Desired: "postgres", Destructive: true, Parameter: "type"}}
+		i.On("ResourceUpdate", "app1", "resource1", structs.ResourceUpdateOptions{DryRun: options.Bool(true), Release: options.String("release1")}).Return(chs, nil)
+		i.On("ResourceUpdate", "app1", "resource1", structs.ResourceUpdateOptions{Force: options.Bool(true), Release: options.String("release1")}).Return(chs, nil)
+
+		res, err := testExecute(e, "resources update resource1 -a app1 --release release1 --force", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"PARAMETER  CURRENT  DESIRED   ACTION                             ",
+			"type       mysql    postgres  replace the resource, may lose data",
+			"Updating resource1... OK",
+		})
+	})
+}
+
+func TestResourcesUpdateNoChanges(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("ResourceUpdate", "app1", "resource1", structs.ResourceUpdateOptions{DryRun: options.Bool(true)}).Return(structs.ResourceChanges{}, nil)
+
+		res, err := testExecute(e, "resources update resource1 -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{"no changes for resource1"})
+	})
+}
+
+func TestResourcesUpdateError(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("ResourceUpdate", "app1", "resource1", structs.ResourceUpdateOptions{DryRun: options.Bool(true)}).Return(nil, fmt.Errorf("err1"))
+
+		res, err := testExecute(e, "resources update resource1 -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: err1"})
+		res.RequireStdout(t, []string{""})
+	})
+}
+
 func TestResourcesUrl(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("SystemGet").Return(fxSystem(), nil)
@@ -176,6 +331,20 @@ func TestRackResources(t *testing.T) {
 	})
 }
 
+func TestRackResourcesJSON(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("SystemGet").Return(fxSystem(), nil)
+		v := structs.Resources{*fxResource(), *fxResource()}
+		i.On("SystemResourceList").Return(v, nil)
+
+		res, err := testExecute(e, "rack resources --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireJSON(t, v)
+	})
+}
+
 func TestRackResourcesError(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("SystemGet").Return(fxSystem(), nil)
@@ -279,6 +448,20 @@ func TestRackResourcesInfo(t *testing.T) {
 	})
 }
 
+func TestRackResourcesInfoJSON(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("SystemGet").Return(fxSystem(), nil)
+		v := fxResource()
+		i.On("SystemResourceGet", "resource1").Return(v, nil)
+
+		res, err := testExecute(e, "rack resources info resource1 --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireJSON(t, v)
+	})
+}
+
 func TestRackResourcesInfoError(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("SystemGet").Return(fxSystem(), nil)
@@ -335,6 +518,20 @@ func TestRackResourcesOptions(t *testing.T) {
 	})
 }
 
+func TestRackResourcesOptionsJSON(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("SystemGet").Return(fxSystem(), nil)
+		v := fxResourceType()
+		i.On("SystemResourceTypes").Return(structs.ResourceTypes{v}, nil)
+
+		res, err := testExecute(e, "rack resources options type1 --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireJSON(t, v.Parameters)
+	})
+}
+
 func TestRackResourcesOptionsError(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("SystemGet").Return(fxSystem(), nil)
@@ -420,6 +617,20 @@ func TestRackResourcesTypes(t *testing.T) {
 	})
 }
 
+func TestRackResourcesTypesJSON(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("SystemGet").Return(fxSystem(), nil)
+		v := structs.ResourceTypes{fxResourceType(), fxResourceType()}
+		i.On("SystemResourceTypes").Return(v, nil)
+
+		res, err := testExecute(e, "rack resources types --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireJSON(t, v)
+	})
+}
+
 func TestRackResourcesTypesError(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("SystemGet").Return(fxSystem(), nil)
@@ -12,10 +12,16 @@ import (
 
 func init() {
 	register("scale", "scale a service", Scale, stdcli.CommandOptions{
-		Flags: append(stdcli.OptionFlags(structs.ServiceUpdateOptions{}), flagApp, flagRack, flagWait),
+		Flags: append(stdcli.OptionFlags(structs.ServiceUpdateOptions{}),
+			flagApp,
+			flagRack,
+			flagWait,
+			stdcli.BoolFlag("remove", "", "remove the schedule given by --schedule"),
+			stdcli.StringFlag("schedule", "", "scale on a cron schedule (UTC) instead of now"),
+		),
 		Usage: "<service>",
 		Validate: func(c *stdcli.Context) error {
-			if c.Value("count") != nil || c.Value("cpu") != nil || c.Value("memory") != nil {
+			if c.Value("count") != nil || c.Value("cpu") != nil || c.Value("memory") != nil || c.String("schedule") != "" {
 				if len(c.Args) < 1 {
 					return fmt.Errorf("service name required")
 				} else {
@@ -40,6 +46,10 @@ func Scale(rack sdk.Interface, c *stdcli.Context) error {
 		return err
 	}
 
+	if schedule := c.String("schedule"); schedule != "" {
+		return scaleSchedule(rack, c, c.Arg(0), schedule, opts)
+	}
+
 	if opts.Count != nil || opts.Cpu != nil || opts.Memory != nil {
 		service := c.Arg(0)
 
@@ -100,3 +110,31 @@ func Scale(rack sdk.Interface, c *stdcli.Context) error {
 
 	return t.Print()
 }
+
+func scaleSchedule(rack sdk.Interface, c *stdcli.Context, service, schedule string, opts structs.ServiceUpdateOptions) error {
+	if c.Bool("remove") {
+		c.Startf("Removing schedule <info>%s</info> from <service>%s</service>", schedule, service)
+
+		if err := rack.ServiceScheduleDelete(app(c), service, schedule); err != nil {
+			return err
+		}
+
+		return c.OK()
+	}
+
+	if opts.Count == nil {
+		return fmt.Errorf("--count required for a schedule")
+	}
+
+	if opts.Cpu != nil || opts.Memory != nil {
+		return fmt.Errorf("only --count can be scheduled")
+	}
+
+	c.Startf("Scheduling <service>%s</service> to scale to <info>%d</info> at <info>%s</info>", service, *opts.Count, schedule)
+
+	if err := rack.ServiceScheduleSet(app(c), service, schedule, structs.ServiceScheduleSetOptions{Count: opts.Count}); err != nil {
+		return err
+	}
+
+	return c.OK()
+}
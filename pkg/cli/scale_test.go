@@ -98,3 +98,54 @@ func TestScaleUpdateClassic(t *testing.T) {
 		res.RequireStdout(t, []string{"Scaling web... OK"})
 	})
 }
+
+func TestScaleSchedule(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("SystemGet").Return(fxSystem(), nil)
+		i.On("ServiceScheduleSet", "app1", "web", "0 8 * * 1-5", structs.ServiceScheduleSetOptions{Count: options.Int(10)}).Return(nil)
+
+		res, err := testExecute(e, "scale web --schedule '0 8 * * 1-5' --count 10 -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{"Scheduling web to scale to 10 at 0 8 * * 1-5... OK"})
+	})
+}
+
+func TestScaleScheduleError(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("SystemGet").Return(fxSystem(), nil)
+		i.On("ServiceScheduleSet", "app1", "web", "0 8 * * 1-5", structs.ServiceScheduleSetOptions{Count: options.Int(10)}).Return(fmt.Errorf("err1"))
+
+		res, err := testExecute(e, "scale web --schedule '0 8 * * 1-5' --count 10 -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: err1"})
+		res.RequireStdout(t, []string{"Scheduling web to scale to 10 at 0 8 * * 1-5... "})
+	})
+}
+
+func TestScaleScheduleNoCount(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("SystemGet").Return(fxSystem(), nil)
+
+		res, err := testExecute(e, "scale web --schedule '0 8 * * 1-5' --cpu 5 -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: --count required for a schedule"})
+		res.RequireStdout(t, []string{""})
+	})
+}
+
+func TestScaleScheduleRemove(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("SystemGet").Return(fxSystem(), nil)
+		i.On("ServiceScheduleDelete", "app1", "web", "0 8 * * 1-5").Return(nil)
+
+		res, err := testExecute(e, "scale web --schedule '0 8 * * 1-5' --remove -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{"Removing schedule 0 8 * * 1-5 from web... OK"})
+	})
+}
@@ -2,8 +2,11 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
+	"github.com/convox/convox/pkg/common"
+	"github.com/convox/convox/pkg/options"
 	"github.com/convox/convox/pkg/structs"
 	"github.com/convox/convox/sdk"
 	"github.com/convox/stdcli"
@@ -11,10 +14,20 @@ import (
 
 func init() {
 	register("services", "list services for an app", Services, stdcli.CommandOptions{
-		Flags:    []stdcli.Flag{flagApp, flagRack},
+		Flags:    []stdcli.Flag{flagApp, flagRack, flagFormat},
 		Validate: stdcli.Args(0),
 	})
 
+	register("services forward", "forward local ports to a healthy process of a service", ServicesForward, stdcli.CommandOptions{
+		Flags: []stdcli.Flag{
+			flagApp,
+			flagRack,
+			stdcli.BoolFlag("udp", "u", "forward udp instead of tcp"),
+		},
+		Usage:    "<service> <[port:]remoteport> [[port:]remoteport]...",
+		Validate: stdcli.ArgsMin(2),
+	})
+
 	register("services restart", "restart a service", ServicesRestart, stdcli.CommandOptions{
 		Flags:    []stdcli.Flag{flagApp, flagRack},
 		Validate: stdcli.Args(1),
@@ -41,6 +54,10 @@ func Services(rack sdk.Interface, c *stdcli.Context) error {
 		}
 	}
 
+	if formatted(c) {
+		return printFormatted(c, ss)
+	}
+
 	t := c.Table("SERVICE", "DOMAIN", "PORTS")
 
 	for _, s := range ss {
@@ -59,9 +76,43 @@ func Services(rack sdk.Interface, c *stdcli.Context) error {
 		t.AddRow(s.Name, s.Domain, strings.Join(ports, " "))
 	}
 
+	if err := t.Print(); err != nil {
+		return err
+	}
+
+	scheduled := false
+
+	for _, s := range ss {
+		if len(s.Schedules) > 0 {
+			scheduled = true
+		}
+	}
+
+	if !scheduled {
+		return nil
+	}
+
+	c.Writef("\n")
+
+	t = c.Table("SERVICE", "SCHEDULE", "COUNT", "NEXT")
+
+	for _, s := range ss {
+		for _, sc := range s.Schedules {
+			t.AddRow(s.Name, sc.Schedule, fmt.Sprintf("%d", sc.Count), common.Ago(sc.Next))
+		}
+	}
+
 	return t.Print()
 }
 
+func ServicesForward(rack sdk.Interface, c *stdcli.Context) error {
+	service := c.Arg(0)
+
+	return forward(c, service, c.Args[1:], func(port int, rw io.ReadWriter, protocol string) error {
+		return rack.WithContext(c.Context).ServiceForward(app(c), service, port, rw, structs.ServiceForwardOptions{Protocol: options.String(protocol)})
+	})
+}
+
 func ServicesRestart(rack sdk.Interface, c *stdcli.Context) error {
 	name := c.Arg(0)
 
@@ -1,12 +1,20 @@
 package cli_test
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/convox/convox/pkg/cli"
 	mocksdk "github.com/convox/convox/pkg/mock/sdk"
+	"github.com/convox/convox/pkg/options"
 	"github.com/convox/convox/pkg/structs"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -27,6 +35,20 @@ func TestServices(t *testing.T) {
 	})
 }
 
+func TestServicesJSON(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("SystemGet").Return(fxSystem(), nil)
+		v := structs.Services{*fxService(), *fxService()}
+		i.On("ServiceList", "app1").Return(v, nil)
+
+		res, err := testExecute(e, "services -a app1 --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireJSON(t, v)
+	})
+}
+
 func TestServicesError(t *testing.T) {
 	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
 		i.On("SystemGet").Return(fxSystem(), nil)
@@ -80,3 +102,115 @@ func TestServicesRestartError(t *testing.T) {
 		res.RequireStdout(t, []string{"Restarting service1... "})
 	})
 }
+
+func TestServicesSchedules(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("SystemGet").Return(fxSystem(), nil)
+		s := *fxService()
+		s.Schedules = []structs.ServiceSchedule{
+			{Count: 10, Schedule: "0 8 * * 1-5", Next: time.Now().Add(49 * time.Hour)},
+			{Count: 2, Schedule: "0 18 * * 1-5", Next: time.Now().Add(59 * time.Hour)},
+		}
+		i.On("ServiceList", "app1").Return(structs.Services{s, *fxService()}, nil)
+
+		res, err := testExecute(e, "services -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"SERVICE   DOMAIN  PORTS  ",
+			"service1  domain  1:2 1:2",
+			"service1  domain  1:2 1:2",
+			"",
+			"SERVICE   SCHEDULE      COUNT  NEXT           ",
+			"service1  0 8 * * 1-5   10     2 days from now",
+			"service1  0 18 * * 1-5  2      2 days from now",
+		})
+	})
+}
+
+func TestServicesForward(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		i.On("WithContext", ctx).Return(i)
+		i.On("ServiceForward", "app1", "web", 3000, mock.Anything, structs.ServiceForwardOptions{Protocol: options.String("tcp")}).Return(nil).Run(func(args mock.Arguments) {
+			buf := make([]byte, 2)
+			rwc := args.Get(3).(io.ReadWriteCloser)
+			n, err := rwc.Read(buf)
+			require.NoError(t, err)
+			require.Equal(t, 2, n)
+			require.Equal(t, "in", string(buf))
+			n, err = rwc.Write([]byte("out"))
+			require.NoError(t, err)
+			require.Equal(t, 3, n)
+			rwc.Close()
+		})
+
+		port := rand.Intn(30000) + 10000
+
+		ch := make(chan *result)
+
+		go func() {
+			res, _ := testExecuteContext(ctx, e, fmt.Sprintf("services forward web %d:3000 -a app1", port), nil)
+			ch <- res
+		}()
+
+		time.Sleep(500 * time.Millisecond)
+
+		cn, err := net.Dial("tcp", fmt.Sprintf("localhost:%d", port))
+		require.NoError(t, err)
+
+		cn.Write([]byte("in"))
+
+		data, err := ioutil.ReadAll(cn)
+		require.NoError(t, err)
+		require.Equal(t, "out", string(data))
+
+		cancel()
+
+		res := <-ch
+
+		require.NotNil(t, res)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			fmt.Sprintf("forwarding localhost:%d to web:3000", port),
+			fmt.Sprintf("connect: %d", port),
+		})
+	})
+}
+
+func TestServicesForwardListenError(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		ln, err := net.Listen("tcp4", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer ln.Close()
+
+		used := ln.Addr().(*net.TCPAddr).Port
+		port := rand.Intn(30000) + 10000
+
+		ch := make(chan *result)
+
+		go func() {
+			res, _ := testExecute(e, fmt.Sprintf("services forward web %d:3000 %d:3001 -a app1", port, used), nil)
+			ch <- res
+		}()
+
+		select {
+		case res := <-ch:
+			require.NotNil(t, res)
+			require.Equal(t, 1, res.Code)
+			res.RequireStderr(t, []string{fmt.Sprintf("ERROR: listen tcp4 127.0.0.1:%d: bind: address already in use", used)})
+			res.RequireStdout(t, []string{""})
+		case <-time.After(5 * time.Second):
+			t.Fatal("forward did not return")
+		}
+
+		// ports bound before the failure are released
+		pl, err := net.Listen("tcp4", fmt.Sprintf("127.0.0.1:%d", port))
+		require.NoError(t, err)
+		pl.Close()
+	})
+}
@@ -0,0 +1,250 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/convox/changes"
+	"github.com/convox/convox/pkg/start"
+	"github.com/convox/convox/sdk"
+	"github.com/convox/stdcli"
+	"github.com/docker/docker/pkg/fileutils"
+)
+
+func init() {
+	register("sync", "sync local files to a process", Sync, stdcli.CommandOptions{
+		Flags: []stdcli.Flag{
+			flagApp,
+			flagRack,
+			stdcli.BoolFlag("delete", "", "remove files on the process that do not exist locally"),
+			stdcli.StringFlag("exclude", "x", "comma separated patterns to exclude"),
+			stdcli.BoolFlag("watch", "", "keep syncing local changes until interrupted"),
+		},
+		Usage:    "<local> <pid>:<remote>",
+		Validate: stdcli.Args(2),
+	})
+}
+
+func Sync(rack sdk.Interface, c *stdcli.Context) error {
+	parts := strings.SplitN(c.Arg(1), ":", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("destination must be <pid>:<remote>")
+	}
+
+	pid, remote := parts[0], path.Clean(parts[1])
+
+	if !strings.HasPrefix(remote, "/") {
+		return fmt.Errorf("must specify absolute paths for processes")
+	}
+
+	local, err := filepath.Abs(c.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	if stat, err := os.Stat(local); err != nil {
+		return err
+	} else if !stat.IsDir() {
+		return fmt.Errorf("%s is not a directory", c.Arg(0))
+	}
+
+	excludes := cpExcludes(c.String("exclude"))
+
+	c.Startf("Syncing <dir>%s</dir> to <dir>%s</dir> on <id>%s</id>", c.Arg(0), remote, pid)
+
+	adds, removes, err := syncChanges(rack, app(c), pid, local, remote, excludes, c.Bool("delete"))
+	if err != nil {
+		return err
+	}
+
+	if err := start.SyncAdds(rack, app(c), pid, remote, adds); err != nil {
+		return err
+	}
+
+	if err := start.SyncRemoves(rack, app(c), pid, removes); err != nil {
+		return err
+	}
+
+	c.OK(fmt.Sprintf("%d changed, %d removed", len(adds), len(removes)))
+
+	if !c.Bool("watch") {
+		return nil
+	}
+
+	return syncWatch(rack, c, pid, local, remote, excludes, c.Bool("delete"))
+}
+
+// syncChanges compares checksums of the local files with those computed on the process
+func syncChanges(rack sdk.Interface, app, pid, local, remote string, excludes []string, delete bool) ([]changes.Change, []changes.Change, error) {
+	sums, err := syncLocalChecksums(local, excludes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fs, err := rack.FilesChecksums(app, pid, remote)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	remotes := map[string]string{}
+
+	for _, f := range fs {
+		if strings.HasPrefix(f.Path, remote+"/") {
+			remotes[strings.TrimPrefix(f.Path, remote+"/")] = f.Checksum
+		}
+	}
+
+	adds := []changes.Change{}
+	removes := []changes.Change{}
+
+	for _, rel := range syncSorted(sums) {
+		if remotes[rel] != sums[rel] {
+			adds = append(adds, changes.Change{Operation: "add", Base: local, Path: rel})
+		}
+	}
+
+	if delete {
+		for _, rel := range syncSorted(remotes) {
+			if _, ok := sums[rel]; ok {
+				continue
+			}
+
+			if excluded, err := fileutils.Matches(rel, excludes); err != nil {
+				return nil, nil, err
+			} else if excluded {
+				continue
+			}
+
+			removes = append(removes, changes.Change{Operation: "remove", Base: remote, Path: path.Join(remote, rel)})
+		}
+	}
+
+	return adds, removes, nil
+}
+
+func syncLocalChecksums(dir string, excludes []string) (map[string]string, error) {
+	sums := map[string]string{}
+
+	err := filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+
+		if rel == "." {
+			return nil
+		}
+
+		if excluded, err := fileutils.Matches(rel, excludes); err != nil {
+			return err
+		} else if excluded {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		fd, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer fd.Close()
+
+		h := sha256.New()
+
+		if _, err := io.Copy(h, fd); err != nil {
+			return err
+		}
+
+		sums[filepath.ToSlash(rel)] = fmt.Sprintf("%x", h.Sum(nil))
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sums, nil
+}
+
+func syncSorted(m map[string]string) []string {
+	keys := []string{}
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// syncWatch pushes local changes to the process as they happen using the same
+// change detection as convox start
+func syncWatch(rack sdk.Interface, c *stdcli.Context, pid, local, remote string, excludes []string, delete bool) error {
+	c.Writef("watching <dir>%s</dir> for changes\n", c.Arg(0))
+
+	cch := make(chan changes.Change, 1)
+
+	go changes.Watch(local, cch, changes.WatchOptions{Ignores: excludes})
+
+	tick := time.NewTicker(1 * time.Second)
+	defer tick.Stop()
+
+	chgs := []changes.Change{}
+
+	for {
+		select {
+		case <-c.Done():
+			return nil
+		case ch := <-cch:
+			chgs = append(chgs, ch)
+		case <-tick.C:
+			if len(chgs) == 0 {
+				continue
+			}
+
+			adds, removes := changes.Partition(chgs)
+			chgs = []changes.Change{}
+
+			for i := range removes {
+				removes[i].Path = path.Join(remote, filepath.ToSlash(removes[i].Path))
+			}
+
+			if !delete {
+				removes = nil
+			}
+
+			for _, a := range adds {
+				c.Writef("sync: <dir>%s</dir>\n", a.Path)
+			}
+
+			if err := start.SyncAdds(rack, app(c), pid, remote, adds); err != nil {
+				c.Error(fmt.Errorf("sync add error: %s", err))
+			}
+
+			for _, r := range removes {
+				c.Writef("remove: <dir>%s</dir>\n", r.Path)
+			}
+
+			if err := start.SyncRemoves(rack, app(c), pid, removes); err != nil {
+				c.Error(fmt.Errorf("sync remove error: %s", err))
+			}
+		}
+	}
+}
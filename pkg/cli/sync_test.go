@@ -0,0 +1,91 @@
+package cli_test
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/convox/convox/pkg/cli"
+	mocksdk "github.com/convox/convox/pkg/mock/sdk"
+	"github.com/convox/convox/pkg/structs"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSync(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		tmpd := testCpTree(t)
+		defer os.RemoveAll(tmpd)
+
+		fs := structs.FileChecksums{
+			{Checksum: "ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb", Path: "/app/a.txt"},
+			{Checksum: "0000", Path: "/app/old.txt"},
+		}
+
+		var names []string
+
+		i.On("FilesChecksums", "app1", "0123456789", "/app").Return(fs, nil)
+		i.On("FilesUpload", "app1", "0123456789", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			names = testTarNames(t, args.Get(2).(io.Reader))
+		})
+		i.On("FilesDelete", "app1", "0123456789", []string{"/app/old.txt"}).Return(nil)
+
+		res, err := testExecute(e, fmt.Sprintf("sync %s 0123456789:/app --delete --exclude '*.log' -a app1", tmpd), nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{fmt.Sprintf("Syncing %s to /app on 0123456789... OK, 1 changed, 1 removed", tmpd)})
+		require.Equal(t, []string{"/app/sub/c.txt"}, names)
+	})
+}
+
+func TestSyncNoDelete(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		tmpd := testCpTree(t)
+		defer os.RemoveAll(tmpd)
+
+		fs := structs.FileChecksums{
+			{Checksum: "0000", Path: "/app/old.txt"},
+		}
+
+		var names []string
+
+		i.On("FilesChecksums", "app1", "0123456789", "/app").Return(fs, nil)
+		i.On("FilesUpload", "app1", "0123456789", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			names = testTarNames(t, args.Get(2).(io.Reader))
+		})
+
+		res, err := testExecute(e, fmt.Sprintf("sync %s 0123456789:/app -a app1", tmpd), nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{fmt.Sprintf("Syncing %s to /app on 0123456789... OK, 3 changed, 0 removed", tmpd)})
+		require.Equal(t, []string{"/app/a.txt", "/app/b.log", "/app/sub/c.txt"}, names)
+	})
+}
+
+func TestSyncError(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		tmpd := testCpTree(t)
+		defer os.RemoveAll(tmpd)
+
+		i.On("FilesChecksums", "app1", "0123456789", "/app").Return(nil, fmt.Errorf("err1"))
+
+		res, err := testExecute(e, fmt.Sprintf("sync %s 0123456789:/app -a app1", tmpd), nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: err1"})
+		res.RequireStdout(t, []string{fmt.Sprintf("Syncing %s to /app on 0123456789... ", tmpd)})
+	})
+}
+
+func TestSyncRelativeRemote(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		res, err := testExecute(e, "sync . 0123456789:app -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: must specify absolute paths for processes"})
+		res.RequireStdout(t, []string{""})
+	})
+}
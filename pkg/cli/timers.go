@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"github.com/convox/convox/pkg/common"
+	"github.com/convox/convox/sdk"
+	"github.com/convox/stdcli"
+)
+
+func init() {
+	register("timers", "list timers for an app", Timers, stdcli.CommandOptions{
+		Flags:    []stdcli.Flag{flagApp, flagRack, flagFormat},
+		Validate: stdcli.Args(0),
+	})
+
+	register("timers resume", "resume a suspended timer", TimersResume, stdcli.CommandOptions{
+		Flags:    []stdcli.Flag{flagApp, flagRack},
+		Usage:    "<timer>",
+		Validate: stdcli.Args(1),
+	})
+
+	register("timers run", "run a timer now", TimersRun, stdcli.CommandOptions{
+		Flags:    []stdcli.Flag{flagApp, flagRack},
+		Usage:    "<timer>",
+		Validate: stdcli.Args(1),
+	})
+
+	register("timers suspend", "stop a timer from running on its schedule", TimersSuspend, stdcli.CommandOptions{
+		Flags:    []stdcli.Flag{flagApp, flagRack},
+		Usage:    "<timer>",
+		Validate: stdcli.Args(1),
+	})
+}
+
+func Timers(rack sdk.Interface, c *stdcli.Context) error {
+	ts, err := rack.TimerList(app(c))
+	if err != nil {
+		return err
+	}
+
+	if formatted(c) {
+		return printFormatted(c, ts)
+	}
+
+	t := c.Table("TIMER", "SERVICE", "SCHEDULE", "TIMEZONE", "CONCURRENCY", "STATUS", "LAST RUN", "LAST RESULT", "COMMAND")
+
+	for _, tm := range ts {
+		status, last := "active", ""
+
+		if tm.Suspended {
+			status = "suspended"
+		}
+
+		if tm.LastRun != nil {
+			last = common.Ago(*tm.LastRun)
+		}
+
+		t.AddRow(tm.Name, tm.Service, tm.Schedule, common.CoalesceString(tm.Timezone, "UTC"), tm.Concurrency, status, last, tm.LastStatus, tm.Command)
+	}
+
+	return t.Print()
+}
+
+func TimersResume(rack sdk.Interface, c *stdcli.Context) error {
+	c.Startf("Resuming timer <id>%s</id>", c.Arg(0))
+
+	if err := rack.TimerResume(app(c), c.Arg(0)); err != nil {
+		return err
+	}
+
+	return c.OK()
+}
+
+func TimersRun(rack sdk.Interface, c *stdcli.Context) error {
+	c.Startf("Running timer <id>%s</id>", c.Arg(0))
+
+	if err := rack.TimerRun(app(c), c.Arg(0)); err != nil {
+		return err
+	}
+
+	return c.OK()
+}
+
+func TimersSuspend(rack sdk.Interface, c *stdcli.Context) error {
+	c.Startf("Suspending timer <id>%s</id>", c.Arg(0))
+
+	if err := rack.TimerSuspend(app(c), c.Arg(0)); err != nil {
+		return err
+	}
+
+	return c.OK()
+}
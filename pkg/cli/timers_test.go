@@ -0,0 +1,124 @@
+package cli_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/convox/convox/pkg/cli"
+	mocksdk "github.com/convox/convox/pkg/mock/sdk"
+	"github.com/convox/convox/pkg/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimers(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("TimerList", "app1").Return(structs.Timers{*fxTimer(), *fxTimerSuspended()}, nil)
+
+		res, err := testExecute(e, "timers -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"TIMER    SERVICE  SCHEDULE   TIMEZONE          CONCURRENCY  STATUS     LAST RUN     LAST RESULT  COMMAND    ",
+			"cleanup  worker   0 * * * *  America/New_York  forbid       active     2 hours ago  succeeded    bin/cleanup",
+			"report   worker   0 3 * * *  UTC               allow        suspended                            bin/report ",
+		})
+	})
+}
+
+func TestTimersJSON(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		v := structs.Timers{*fxTimer(), *fxTimerSuspended()}
+		i.On("TimerList", "app1").Return(v, nil)
+
+		res, err := testExecute(e, "timers -a app1 --format json", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireJSON(t, v)
+	})
+}
+
+func TestTimersError(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("TimerList", "app1").Return(nil, fmt.Errorf("err1"))
+
+		res, err := testExecute(e, "timers -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: err1"})
+		res.RequireStdout(t, []string{""})
+	})
+}
+
+func TestTimersResume(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("TimerResume", "app1", "report").Return(nil)
+
+		res, err := testExecute(e, "timers resume report -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{"Resuming timer report... OK"})
+	})
+}
+
+func TestTimersResumeError(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("TimerResume", "app1", "report").Return(fmt.Errorf("err1"))
+
+		res, err := testExecute(e, "timers resume report -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: err1"})
+		res.RequireStdout(t, []string{"Resuming timer report... "})
+	})
+}
+
+func TestTimersRun(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("TimerRun", "app1", "cleanup").Return(nil)
+
+		res, err := testExecute(e, "timers run cleanup -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{"Running timer cleanup... OK"})
+	})
+}
+
+func TestTimersRunError(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("TimerRun", "app1", "cleanup").Return(fmt.Errorf("err1"))
+
+		res, err := testExecute(e, "timers run cleanup -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: err1"})
+		res.RequireStdout(t, []string{"Running timer cleanup... "})
+	})
+}
+
+func TestTimersSuspend(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("TimerSuspend", "app1", "cleanup").Return(nil)
+
+		res, err := testExecute(e, "timers suspend cleanup -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{"Suspending timer cleanup... OK"})
+	})
+}
+
+func TestTimersSuspendError(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("TimerSuspend", "app1", "cleanup").Return(fmt.Errorf("err1"))
+
+		res, err := testExecute(e, "timers suspend cleanup -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: err1"})
+		res.RequireStdout(t, []string{"Suspending timer cleanup... "})
+	})
+}
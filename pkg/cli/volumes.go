@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/convox/convox/pkg/common"
+	"github.com/convox/convox/pkg/options"
+	"github.com/convox/convox/pkg/structs"
+	"github.com/convox/convox/sdk"
+	"github.com/convox/stdcli"
+	humanize "github.com/dustin/go-humanize"
+)
+
+func init() {
+	register("volumes", "list volumes for an app", Volumes, stdcli.CommandOptions{
+		Flags:    []stdcli.Flag{flagApp, flagRack, flagFormat},
+		Validate: stdcli.Args(0),
+	})
+
+	register("volumes restore", "restore a volume from a snapshot", VolumesRestore, stdcli.CommandOptions{
+		Flags: []stdcli.Flag{
+			flagApp,
+			flagRack,
+			stdcli.StringFlag("source-app", "", "app that owns the snapshot"),
+		},
+		Usage:    "<volume> <snapshot>",
+		Validate: stdcli.Args(2),
+	})
+
+	register("volumes snapshot", "take a snapshot of a volume", VolumesSnapshot, stdcli.CommandOptions{
+		Flags:    []stdcli.Flag{flagApp, flagRack},
+		Usage:    "<volume>",
+		Validate: stdcli.Args(1),
+	})
+
+	register("volumes snapshots", "list snapshots of a volume", VolumesSnapshots, stdcli.CommandOptions{
+		Flags:    []stdcli.Flag{flagApp, flagRack, flagFormat},
+		Usage:    "<volume>",
+		Validate: stdcli.Args(1),
+	})
+}
+
+func Volumes(rack sdk.Interface, c *stdcli.Context) error {
+	vs, err := rack.VolumeList(app(c))
+	if err != nil {
+		return err
+	}
+
+	if formatted(c) {
+		return printFormatted(c, vs)
+	}
+
+	t := c.Table("VOLUME", "SERVICES", "SIZE", "USED", "CLASS", "ACCESS", "STATUS")
+
+	for _, v := range vs {
+		used := ""
+
+		if v.Used > 0 {
+			used = humanize.IBytes(uint64(v.Used))
+		}
+
+		t.AddRow(v.Name, strings.Join(v.Services, ","), humanize.IBytes(uint64(v.Size)), used, v.Class, v.Access, v.Status)
+	}
+
+	return t.Print()
+}
+
+func VolumesRestore(rack sdk.Interface, c *stdcli.Context) error {
+	opts := structs.VolumeRestoreOptions{}
+
+	if v := c.String("source-app"); v != "" {
+		opts.SourceApp = options.String(v)
+	}
+
+	c.Startf("Restoring <id>%s</id> from snapshot <id>%s</id>", c.Arg(0), c.Arg(1))
+
+	if err := rack.VolumeRestore(app(c), c.Arg(0), c.Arg(1), opts); err != nil {
+		return err
+	}
+
+	return c.OK()
+}
+
+func VolumesSnapshot(rack sdk.Interface, c *stdcli.Context) error {
+	c.Startf("Snapshotting <id>%s</id>", c.Arg(0))
+
+	vs, err := rack.VolumeSnapshot(app(c), c.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	return c.OK(vs.Id)
+}
+
+func VolumesSnapshots(rack sdk.Interface, c *stdcli.Context) error {
+	vss, err := rack.VolumeSnapshotList(app(c), c.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	if formatted(c) {
+		return printFormatted(c, vss)
+	}
+
+	t := c.Table("ID", "CREATED", "SIZE", "STATUS")
+
+	for _, vs := range vss {
+		size := ""
+
+		if vs.Size > 0 {
+			size = humanize.IBytes(uint64(vs.Size))
+		}
+
+		t.AddRow(vs.Id, common.Ago(vs.Created), size, vs.Status)
+	}
+
+	return t.Print()
+}
@@ -0,0 +1,127 @@
+package cli_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/convox/convox/pkg/cli"
+	mocksdk "github.com/convox/convox/pkg/mock/sdk"
+	"github.com/convox/convox/pkg/options"
+	"github.com/convox/convox/pkg/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVolumes(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("VolumeList", "app1").Return(structs.Volumes{*fxVolume()}, nil)
+
+		res, err := testExecute(e, "volumes -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"VOLUME  SERVICES    SIZE    USED     CLASS     ACCESS         STATUS",
+			"data    web,worker  10 GiB  1.0 GiB  standard  ReadWriteOnce  bound ",
+		})
+	})
+}
+
+func TestVolumesError(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("VolumeList", "app1").Return(nil, fmt.Errorf("err1"))
+
+		res, err := testExecute(e, "volumes -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: err1"})
+		res.RequireStdout(t, []string{""})
+	})
+}
+
+func TestVolumesRestore(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("VolumeRestore", "app1", "data", "data-20200101030000", structs.VolumeRestoreOptions{}).Return(nil)
+
+		res, err := testExecute(e, "volumes restore data data-20200101030000 -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{"Restoring data from snapshot data-20200101030000... OK"})
+	})
+}
+
+func TestVolumesRestoreSourceApp(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		opts := structs.VolumeRestoreOptions{SourceApp: options.String("app2")}
+		i.On("VolumeRestore", "app1", "data", "data-20200101030000", opts).Return(nil)
+
+		res, err := testExecute(e, "volumes restore data data-20200101030000 -a app1 --source-app app2", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{"Restoring data from snapshot data-20200101030000... OK"})
+	})
+}
+
+func TestVolumesRestoreError(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("VolumeRestore", "app1", "data", "data-20200101030000", structs.VolumeRestoreOptions{}).Return(fmt.Errorf("err1"))
+
+		res, err := testExecute(e, "volumes restore data data-20200101030000 -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: err1"})
+		res.RequireStdout(t, []string{"Restoring data from snapshot data-20200101030000... "})
+	})
+}
+
+func TestVolumesSnapshot(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("VolumeSnapshot", "app1", "data").Return(fxVolumeSnapshot(), nil)
+
+		res, err := testExecute(e, "volumes snapshot data -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{"Snapshotting data... OK, data-20200101030000"})
+	})
+}
+
+func TestVolumesSnapshotError(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("VolumeSnapshot", "app1", "data").Return(nil, fmt.Errorf("err1"))
+
+		res, err := testExecute(e, "volumes snapshot data -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: err1"})
+		res.RequireStdout(t, []string{"Snapshotting data... "})
+	})
+}
+
+func TestVolumesSnapshots(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("VolumeSnapshotList", "app1", "data").Return(structs.VolumeSnapshots{*fxVolumeSnapshot()}, nil)
+
+		res, err := testExecute(e, "volumes snapshots data -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Code)
+		res.RequireStderr(t, []string{""})
+		res.RequireStdout(t, []string{
+			"ID                   CREATED     SIZE    STATUS",
+			"data-20200101030000  2 days ago  10 GiB  ready ",
+		})
+	})
+}
+
+func TestVolumesSnapshotsError(t *testing.T) {
+	testClient(t, func(e *cli.Engine, i *mocksdk.Interface) {
+		i.On("VolumeSnapshotList", "app1", "data").Return(nil, fmt.Errorf("err1"))
+
+		res, err := testExecute(e, "volumes snapshots data -a app1", nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Code)
+		res.RequireStderr(t, []string{"ERROR: err1"})
+		res.RequireStdout(t, []string{""})
+	})
+}
@@ -0,0 +1,46 @@
+package common
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// MaxDatagram is the largest payload that can be framed by WriteDatagram
+const MaxDatagram = 65535
+
+// ReadDatagram reads a single length prefixed datagram written by WriteDatagram
+func ReadDatagram(r io.Reader) ([]byte, error) {
+	var size uint16
+
+	if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, size)
+
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// WriteDatagram writes data prefixed with its length so that datagram
+// boundaries survive being carried over a stream
+func WriteDatagram(w io.Writer, data []byte) error {
+	if len(data) > MaxDatagram {
+		return fmt.Errorf("datagram too large: %d", len(data))
+	}
+
+	buf := make([]byte, 2+len(data))
+
+	binary.BigEndian.PutUint16(buf, uint16(len(data)))
+	copy(buf[2:], data)
+
+	if _, err := w.Write(buf); err != nil {
+		return err
+	}
+
+	return nil
+}
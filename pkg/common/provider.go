@@ -19,21 +19,36 @@ var (
 )
 
 func AppEnvironment(p structs.Provider, app string) (structs.Environment, error) {
-	rs, err := ReleaseLatest(p, app)
+	env, _, err := AppEnvironmentMeta(p, app)
 	if err != nil {
 		return nil, err
 	}
+
+	return env, nil
+}
+
+// AppEnvironmentMeta returns the environment of the latest release along with its variable metadata
+func AppEnvironmentMeta(p structs.Provider, app string) (structs.Environment, structs.EnvironmentMeta, error) {
+	rs, err := ReleaseLatest(p, app)
+	if err != nil {
+		return nil, nil, err
+	}
 	if rs == nil {
-		return structs.Environment{}, nil
+		return structs.Environment{}, structs.EnvironmentMeta{}, nil
 	}
 
 	env := structs.Environment{}
 
 	if err := env.Load([]byte(rs.Env)); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return env, nil
+	meta, err := structs.NewEnvironmentMeta([]byte(rs.EnvMeta))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return env, meta, nil
 }
 
 func AppManifest(p structs.Provider, app string) (*manifest.Manifest, *structs.Release, error) {
@@ -14,9 +14,9 @@ import (
 	"github.com/docker/docker/pkg/archive"
 )
 
-func Archive(file string) (io.Reader, error) {
+func Archive(files ...string) (io.Reader, error) {
 	opts := &archive.TarOptions{
-		IncludeFiles: []string{file},
+		IncludeFiles: files,
 	}
 
 	r, err := archive.TarWithOptions("/", opts)
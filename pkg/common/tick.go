@@ -18,3 +18,21 @@ func Tick(d time.Duration, fn Ticker) {
 		}
 	}
 }
+
+// TickUntil calls fn every d until stop is closed
+func TickUntil(stop <-chan struct{}, d time.Duration, fn Ticker) {
+	t := time.NewTicker(d)
+	defer t.Stop()
+
+	for {
+		if err := fn(); err != nil {
+			fmt.Printf("ns=common at=tick error=%q\n", err)
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+		}
+	}
+}
@@ -0,0 +1,315 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed five field cron expression: minute hour day-of-month month day-of-week
+type Schedule struct {
+	minute [60]bool
+	hour   [24]bool
+	dom    [32]bool
+	month  [13]bool
+	dow    [7]bool
+
+	domAny bool
+	dowAny bool
+}
+
+type field struct {
+	min, max int
+	set      func(s *Schedule, i int)
+	any      func(s *Schedule)
+}
+
+var fields = []field{
+	{0, 59, func(s *Schedule, i int) { s.minute[i] = true }, nil},
+	{0, 23, func(s *Schedule, i int) { s.hour[i] = true }, nil},
+	{1, 31, func(s *Schedule, i int) { s.dom[i] = true }, func(s *Schedule) { s.domAny = true }},
+	{1, 12, func(s *Schedule, i int) { s.month[i] = true }, nil},
+	{0, 7, func(s *Schedule, i int) { s.dow[i%7] = true }, func(s *Schedule) { s.dowAny = true }},
+}
+
+func Parse(expr string) (*Schedule, error) {
+	parts := strings.Fields(expr)
+
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("invalid schedule expression: %s", expr)
+	}
+
+	s := &Schedule{}
+
+	for i, part := range parts {
+		if err := parseField(s, part, fields[i]); err != nil {
+			return nil, fmt.Errorf("invalid schedule expression: %s: %s", expr, err)
+		}
+	}
+
+	if !s.fires() {
+		return nil, fmt.Errorf("invalid schedule expression: %s: never fires", expr)
+	}
+
+	return s, nil
+}
+
+// Matches returns true if the schedule fires during the minute containing t
+func (s *Schedule) Matches(t time.Time) bool {
+	return s.minute[t.Minute()] && s.hour[t.Hour()] && s.day(t)
+}
+
+// day returns true if the schedule fires on the day containing t
+func (s *Schedule) day(t time.Time) bool {
+	if !s.month[int(t.Month())] {
+		return false
+	}
+
+	dom := s.dom[t.Day()]
+	dow := s.dow[int(t.Weekday())]
+
+	// like cron, when both day fields are restricted either one can match
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dow
+	case s.dowAny:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// Next returns the first time after t that the schedule fires, or a zero time if it does not fire within five years
+func (s *Schedule) Next(t time.Time) time.Time {
+	n := t.Truncate(time.Minute).Add(time.Minute)
+
+	for end := n.AddDate(5, 0, 0); n.Before(end); {
+		switch {
+		case !s.day(n):
+			n = time.Date(n.Year(), n.Month(), n.Day()+1, 0, 0, 0, 0, n.Location())
+		case !s.hour[n.Hour()]:
+			n = time.Date(n.Year(), n.Month(), n.Day(), n.Hour()+1, 0, 0, 0, n.Location())
+		case !s.minute[n.Minute()]:
+			n = n.Add(time.Minute)
+		default:
+			return n
+		}
+	}
+
+	return time.Time{}
+}
+
+// Prev returns the last time at or before t and after since that the schedule fired, or a zero time if it did not
+func (s *Schedule) Prev(t, since time.Time) time.Time {
+	for n := t.Truncate(time.Minute); n.After(since); n = n.Add(-time.Minute) {
+		if s.Matches(n) {
+			return n
+		}
+	}
+
+	return time.Time{}
+}
+
+// Shift returns an expression for the schedule with its times moved back by offset minutes, which runs a
+// schedule written for a time zone offset minutes east of UTC on a clock that uses UTC. It returns an
+// error if the moved times can not be written as a single expression.
+func (s *Schedule) Shift(offset int) (string, error) {
+	minutes := [60]bool{}
+	hours := [24]bool{}
+	days := map[int]bool{}
+	times := 0
+
+	for h := 0; h < 24; h++ {
+		for m := 0; m < 60 && s.hour[h]; m++ {
+			if !s.minute[m] {
+				continue
+			}
+
+			t, day := h*60+m-offset, 0
+
+			for ; t < 0; t += 1440 {
+				day--
+			}
+
+			for ; t >= 1440; t -= 1440 {
+				day++
+			}
+
+			minutes[t%60] = true
+			hours[t/60] = true
+			days[day] = true
+			times++
+		}
+	}
+
+	if count(minutes[:])*count(hours[:]) != times {
+		return "", fmt.Errorf("times can not be moved by %d minutes", offset)
+	}
+
+	daily := s.domAny && s.dowAny && count(s.month[1:]) == 12
+
+	dom, dow := s.dom, s.dow
+
+	if !daily {
+		if len(days) > 1 {
+			return "", fmt.Errorf("times can not be moved by %d minutes", offset)
+		}
+
+		for day := range days {
+			if day == 0 {
+				break
+			}
+
+			// days of the week can cross into another month
+			if count(s.month[1:]) < 12 && (s.domAny || !s.dowAny) {
+				return "", fmt.Errorf("days can not be moved by %d minutes", offset)
+			}
+
+			if !s.domAny {
+				dom = [32]bool{}
+
+				// days that every month has stay in the same month when moved
+				for i := 1; i < len(s.dom); i++ {
+					if !s.dom[i] {
+						continue
+					}
+
+					if i > 28 || i+day < 1 || i+day > 28 {
+						return "", fmt.Errorf("days can not be moved by %d minutes", offset)
+					}
+
+					dom[i+day] = true
+				}
+			}
+
+			for i := range s.dow {
+				dow[((i+day)%7+7)%7] = s.dow[i]
+			}
+		}
+	}
+
+	dm := formatField(dom[1:], 1)
+
+	if s.domAny {
+		dm = "*"
+	}
+
+	dw := formatField(dow[:], 0)
+
+	if s.dowAny {
+		dw = "*"
+	}
+
+	return strings.Join([]string{formatField(minutes[:], 0), formatField(hours[:], 0), dm, formatField(s.month[1:], 1), dw}, " "), nil
+}
+
+func count(values []bool) int {
+	n := 0
+
+	for _, v := range values {
+		if v {
+			n++
+		}
+	}
+
+	return n
+}
+
+// formatField writes the values that are set as a list, or * when all of them are
+func formatField(values []bool, min int) string {
+	if count(values) == len(values) {
+		return "*"
+	}
+
+	vs := []string{}
+
+	for i, v := range values {
+		if v {
+			vs = append(vs, strconv.Itoa(i+min))
+		}
+	}
+
+	return strings.Join(vs, ",")
+}
+
+// the most days each month can have
+var monthDays = [13]int{0, 31, 29, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+
+// fires returns false if no month has one of the days of the month the schedule is restricted to
+func (s *Schedule) fires() bool {
+	if s.domAny || !s.dowAny {
+		return true
+	}
+
+	for m := 1; m <= 12; m++ {
+		for d := 1; d <= monthDays[m] && s.month[m]; d++ {
+			if s.dom[d] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func parseField(s *Schedule, value string, f field) error {
+	for _, item := range strings.Split(value, ",") {
+		step := 1
+
+		if parts := strings.SplitN(item, "/", 2); len(parts) == 2 {
+			n, err := strconv.Atoi(parts[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid step: %s", item)
+			}
+			item, step = parts[0], n
+		}
+
+		lo, hi := f.min, f.max
+
+		switch {
+		case item == "*":
+			if step == 1 && f.any != nil {
+				f.any(s)
+			}
+		case strings.Contains(item, "-"):
+			parts := strings.SplitN(item, "-", 2)
+
+			a, err := strconv.Atoi(parts[0])
+			if err != nil {
+				return fmt.Errorf("invalid range: %s", item)
+			}
+
+			b, err := strconv.Atoi(parts[1])
+			if err != nil {
+				return fmt.Errorf("invalid range: %s", item)
+			}
+
+			lo, hi = a, b
+		default:
+			n, err := strconv.Atoi(item)
+			if err != nil {
+				return fmt.Errorf("invalid value: %s", item)
+			}
+
+			lo, hi = n, n
+
+			// a single value with a step runs from the value to the end of the range
+			if step > 1 {
+				hi = f.max
+			}
+		}
+
+		if lo < f.min || hi > f.max || lo > hi {
+			return fmt.Errorf("out of range: %s", item)
+		}
+
+		for i := lo; i <= hi; i += step {
+			f.set(s, i)
+		}
+	}
+
+	return nil
+}
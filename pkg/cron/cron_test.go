@@ -0,0 +1,153 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/convox/convox/pkg/cron"
+	"github.com/stretchr/testify/require"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestMatches(t *testing.T) {
+	tests := []struct {
+		expr    string
+		time    string
+		matches bool
+	}{
+		{"* * * * *", "2019-03-04 05:06", true},
+		{"0 8 * * 1-5", "2019-03-04 08:00", true},  // monday
+		{"0 8 * * 1-5", "2019-03-09 08:00", false}, // saturday
+		{"0 8 * * 1-5", "2019-03-04 08:01", false},
+		{"*/15 * * * *", "2019-03-04 08:45", true},
+		{"*/15 * * * *", "2019-03-04 08:46", false},
+		{"5/20 * * * *", "2019-03-04 08:25", true},
+		{"0 0 * * 7", "2019-03-10 00:00", true}, // sunday as 7
+		{"0 0 * * 0", "2019-03-10 00:00", true},
+		{"0 22,2 * * *", "2019-03-04 02:00", true},
+		{"0 0 1 * 1", "2019-03-01 00:00", true}, // day of month or day of week
+		{"0 0 1 * 1", "2019-03-04 00:00", true},
+		{"0 0 1 * 1", "2019-03-05 00:00", false},
+		{"0 0 * 2 *", "2019-03-01 00:00", false},
+	}
+
+	for _, tt := range tests {
+		s, err := cron.Parse(tt.expr)
+		require.NoError(t, err, tt.expr)
+		require.Equal(t, tt.matches, s.Matches(date(tt.time)), "%s at %s", tt.expr, tt.time)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := map[string]string{
+		"* * * *":       "invalid schedule expression: * * * *",
+		"60 * * * *":    "invalid schedule expression: 60 * * * *: out of range: 60",
+		"* * 0 * *":     "invalid schedule expression: * * 0 * *: out of range: 0",
+		"*/0 * * * *":   "invalid schedule expression: */0 * * * *: invalid step: */0",
+		"a * * * *":     "invalid schedule expression: a * * * *: invalid value: a",
+		"5-1 * * * *":   "invalid schedule expression: 5-1 * * * *: out of range: 5-1",
+		"* * * * 1-b":   "invalid schedule expression: * * * * 1-b: invalid range: 1-b",
+		"* * * * * *":   "invalid schedule expression: * * * * * *",
+		"* 24 * * *":    "invalid schedule expression: * 24 * * *: out of range: 24",
+		"* * * 13 *":    "invalid schedule expression: * * * 13 *: out of range: 13",
+		"* * * * 8":     "invalid schedule expression: * * * * 8: out of range: 8",
+		"1,x * * * *":   "invalid schedule expression: 1,x * * * *: invalid value: x",
+		"1/x * * * *":   "invalid schedule expression: 1/x * * * *: invalid step: 1/x",
+		"1-2/x * * * *": "invalid schedule expression: 1-2/x * * * *: invalid step: 1-2/x",
+		"0 0 30 2 *":    "invalid schedule expression: 0 0 30 2 *: never fires",
+		"0 0 31 4,6 *":  "invalid schedule expression: 0 0 31 4,6 *: never fires",
+	}
+
+	for expr, message := range tests {
+		_, err := cron.Parse(expr)
+		require.EqualError(t, err, message, expr)
+	}
+}
+
+func TestNext(t *testing.T) {
+	s, err := cron.Parse("0 8 * * 1-5")
+	require.NoError(t, err)
+
+	require.Equal(t, date("2019-03-11 08:00"), s.Next(date("2019-03-08 08:00")))
+	require.Equal(t, date("2019-03-04 08:00"), s.Next(date("2019-03-04 07:59")))
+
+	s, err = cron.Parse("15 6 29 2 *")
+	require.NoError(t, err)
+
+	require.Equal(t, date("2020-02-29 06:15"), s.Next(date("2019-03-01 00:00")))
+
+	s, err = cron.Parse("0 0 30 2 1")
+	require.NoError(t, err)
+
+	require.Equal(t, date("2020-02-03 00:00"), s.Next(date("2019-03-01 00:00")))
+}
+
+func TestPrev(t *testing.T) {
+	s, err := cron.Parse("30 * * * *")
+	require.NoError(t, err)
+
+	require.Equal(t, date("2019-03-04 08:30"), s.Prev(date("2019-03-04 09:10"), date("2019-03-04 08:00")))
+	require.Equal(t, date("2019-03-04 09:30"), s.Prev(date("2019-03-04 09:30"), date("2019-03-04 08:00")))
+	require.True(t, s.Prev(date("2019-03-04 09:10"), date("2019-03-04 08:30")).IsZero())
+}
+
+func TestShift(t *testing.T) {
+	tests := []struct {
+		expr   string
+		offset int
+		shift  string
+	}{
+		{"0 9 * * *", -300, "0 14 * * *"},
+		{"30 8 * * 1-5", -240, "30 12 * * 1,2,3,4,5"},
+		{"0 0 * * 1-5", 60, "0 23 * * 0,1,2,3,4"},
+		{"0 22 * * 5", -180, "0 1 * * 6"},
+		{"*/15 * * * *", 120, "0,15,30,45 * * * *"},
+		{"0 1 * 3 *", -120, "0 3 * 3 *"},
+		{"0 6 1 * *", 330, "30 0 1 * *"},
+		{"0 0,12 * * *", 60, "0 11,23 * * *"},
+		{"0 0 15 * *", 60, "0 23 14 * *"},
+		{"0 20 1,15 * *", -300, "0 1 2,16 * *"},
+		{"0 0 1 1 *", -300, "0 5 1 1 *"},
+		{"0 0 10 1 *", 60, "0 23 9 1 *"},
+	}
+
+	for _, tt := range tests {
+		s, err := cron.Parse(tt.expr)
+		require.NoError(t, err, tt.expr)
+
+		shift, err := s.Shift(tt.offset)
+		require.NoError(t, err, tt.expr)
+		require.Equal(t, tt.shift, shift, tt.expr)
+	}
+}
+
+func TestShiftInvalid(t *testing.T) {
+	tests := []struct {
+		expr    string
+		offset  int
+		message string
+	}{
+		{"0,45 9 * * *", 330, "times can not be moved by 330 minutes"},
+		{"0 0 1 * *", 60, "days can not be moved by 60 minutes"},
+		{"0 20 28 * *", -300, "days can not be moved by -300 minutes"},
+		{"0 0 31 * *", 60, "days can not be moved by 60 minutes"},
+		{"0 0 1 1 1", 60, "days can not be moved by 60 minutes"},
+		{"0 0 * 1 *", 60, "days can not be moved by 60 minutes"},
+		{"0 0,12 * * 1", 60, "times can not be moved by 60 minutes"},
+	}
+
+	for _, tt := range tests {
+		s, err := cron.Parse(tt.expr)
+		require.NoError(t, err, tt.expr)
+
+		_, err = s.Shift(tt.offset)
+		require.EqualError(t, err, tt.message, tt.expr)
+	}
+}
@@ -0,0 +1,213 @@
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/convox/convox/pkg/manifest"
+	"github.com/convox/convox/pkg/structs"
+)
+
+const (
+	Added   = "added"
+	Changed = "changed"
+	Removed = "removed"
+)
+
+// Releases compares two releases, build is the build of the to release and is used
+// to describe the build change when the releases have different builds
+func Releases(from, to *structs.Release, build *structs.Build, reveal bool) (*structs.ReleaseDiff, error) {
+	d := &structs.ReleaseDiff{
+		App:  to.App,
+		From: from.Id,
+		To:   to.Id,
+	}
+
+	if from.Build != to.Build {
+		d.Build = &structs.ReleaseDiffBuild{
+			From: from.Build,
+			To:   to.Build,
+		}
+
+		if build != nil {
+			d.Build.Description = build.Description
+		}
+	}
+
+	env, err := Env(from.Env, to.Env, reveal)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Env = env
+
+	fm, err := releaseManifest(from)
+	if err != nil {
+		return nil, err
+	}
+
+	tm, err := releaseManifest(to)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Services = Manifests(fm, tm)
+
+	return d, nil
+}
+
+// Env compares two environments in KEY=value form, values are only included if reveal is true
+func Env(from, to string, reveal bool) ([]structs.ReleaseDiffChange, error) {
+	fe, err := structs.NewEnvironment([]byte(from))
+	if err != nil {
+		return nil, err
+	}
+
+	te, err := structs.NewEnvironment([]byte(to))
+	if err != nil {
+		return nil, err
+	}
+
+	cs := Maps(fe, te)
+
+	if !reveal {
+		for i := range cs {
+			cs[i].From = ""
+			cs[i].To = ""
+		}
+	}
+
+	return cs, nil
+}
+
+// Manifests compares the services in two manifests
+func Manifests(from, to *manifest.Manifest) []structs.ReleaseDiffService {
+	fs := map[string]manifest.Service{}
+	ts := map[string]manifest.Service{}
+
+	names := []string{}
+
+	for _, s := range from.Services {
+		fs[s.Name] = s
+		names = append(names, s.Name)
+	}
+
+	for _, s := range to.Services {
+		ts[s.Name] = s
+
+		if _, ok := fs[s.Name]; !ok {
+			names = append(names, s.Name)
+		}
+	}
+
+	sort.Strings(names)
+
+	ss := []structs.ReleaseDiffService{}
+
+	for _, name := range names {
+		f, fok := fs[name]
+		t, tok := ts[name]
+
+		switch {
+		case !fok:
+			ss = append(ss, structs.ReleaseDiffService{Change: Added, Name: name})
+		case !tok:
+			ss = append(ss, structs.ReleaseDiffService{Change: Removed, Name: name})
+		default:
+			if cs := Maps(serviceFields(f), serviceFields(t)); len(cs) > 0 {
+				ss = append(ss, structs.ReleaseDiffService{Change: Changed, Name: name, Changes: cs})
+			}
+		}
+	}
+
+	if len(ss) == 0 {
+		return nil
+	}
+
+	return ss
+}
+
+// Maps compares two maps and returns the changes sorted by key
+func Maps(from, to map[string]string) []structs.ReleaseDiffChange {
+	keys := []string{}
+
+	for k := range from {
+		keys = append(keys, k)
+	}
+
+	for k := range to {
+		if _, ok := from[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	cs := []structs.ReleaseDiffChange{}
+
+	for _, k := range keys {
+		fv, fok := from[k]
+		tv, tok := to[k]
+
+		switch {
+		case !fok:
+			cs = append(cs, structs.ReleaseDiffChange{Change: Added, Name: k, To: tv})
+		case !tok:
+			cs = append(cs, structs.ReleaseDiffChange{Change: Removed, Name: k, From: fv})
+		case fv != tv:
+			cs = append(cs, structs.ReleaseDiffChange{Change: Changed, Name: k, From: fv, To: tv})
+		}
+	}
+
+	if len(cs) == 0 {
+		return nil
+	}
+
+	return cs
+}
+
+func releaseManifest(r *structs.Release) (*manifest.Manifest, error) {
+	if r.Manifest == "" {
+		return &manifest.Manifest{}, nil
+	}
+
+	env := structs.Environment{}
+
+	if err := env.Load([]byte(r.Env)); err != nil {
+		return nil, err
+	}
+
+	return manifest.Load([]byte(r.Manifest), env)
+}
+
+// serviceFields flattens the parts of a service that are worth reviewing before a promote
+func serviceFields(s manifest.Service) map[string]string {
+	fs := map[string]string{
+		"command":         s.Command,
+		"domain":          strings.Join(s.Domains, ","),
+		"environment":     strings.Join(s.Environment, ","),
+		"health.grace":    fmt.Sprintf("%d", s.Health.Grace),
+		"health.interval": fmt.Sprintf("%d", s.Health.Interval),
+		"health.path":     s.Health.Path,
+		"health.timeout":  fmt.Sprintf("%d", s.Health.Timeout),
+		"image":           s.Image,
+		"internal":        fmt.Sprintf("%t", s.Internal),
+		"port":            "",
+		"resources":       strings.Join(s.Resources, ","),
+		"scale.count":     fmt.Sprintf("%d", s.Scale.Count.Min),
+		"scale.cpu":       fmt.Sprintf("%d", s.Scale.Cpu),
+		"scale.memory":    fmt.Sprintf("%d", s.Scale.Memory),
+		"singleton":       fmt.Sprintf("%t", s.Singleton),
+	}
+
+	if s.Port.Port > 0 {
+		fs["port"] = fmt.Sprintf("%s:%d", s.Port.Scheme, s.Port.Port)
+	}
+
+	if s.Scale.Count.Max != s.Scale.Count.Min {
+		fs["scale.count"] = fmt.Sprintf("%d-%d", s.Scale.Count.Min, s.Scale.Count.Max)
+	}
+
+	return fs
+}
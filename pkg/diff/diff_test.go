@@ -0,0 +1,109 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/convox/convox/pkg/diff"
+	"github.com/convox/convox/pkg/structs"
+	"github.com/stretchr/testify/require"
+)
+
+var manifest1 = `
+services:
+  web:
+    build: .
+    port: 3000
+    scale: 2
+  worker:
+    build: .
+    command: bin/work
+`
+
+var manifest2 = `
+services:
+  web:
+    build: .
+    health: /check
+    port: https:3000
+    scale:
+      count: 2-4
+      memory: 1024
+  worker:
+    build: .
+    command: bin/work
+  clock:
+    build: .
+    command: bin/clock
+`
+
+func TestReleases(t *testing.T) {
+	from := &structs.Release{Id: "R1", App: "app1", Build: "B1", Env: "FOO=bar\nOLD=1", Manifest: manifest1}
+	to := &structs.Release{Id: "R2", App: "app1", Build: "B2", Env: "FOO=baz\nNEW=2", Manifest: manifest2}
+	b := &structs.Build{Id: "B2", Description: "new feature"}
+
+	d, err := diff.Releases(from, to, b, false)
+	require.NoError(t, err)
+
+	require.Equal(t, &structs.ReleaseDiff{
+		App:  "app1",
+		From: "R1",
+		To:   "R2",
+		Build: &structs.ReleaseDiffBuild{
+			From:        "B1",
+			To:          "B2",
+			Description: "new feature",
+		},
+		Env: []structs.ReleaseDiffChange{
+			{Change: "changed", Name: "FOO"},
+			{Change: "added", Name: "NEW"},
+			{Change: "removed", Name: "OLD"},
+		},
+		Services: []structs.ReleaseDiffService{
+			{Change: "added", Name: "clock"},
+			{Change: "changed", Name: "web", Changes: []structs.ReleaseDiffChange{
+				{Change: "changed", Name: "health.path", From: "/", To: "/check"},
+				{Change: "changed", Name: "port", From: "http:3000", To: "https:3000"},
+				{Change: "changed", Name: "scale.count", From: "2", To: "2-4"},
+				{Change: "changed", Name: "scale.memory", From: "512", To: "1024"},
+			}},
+		},
+	}, d)
+
+	require.False(t, d.Empty())
+}
+
+func TestReleasesIdentical(t *testing.T) {
+	from := &structs.Release{Id: "R1", App: "app1", Build: "B1", Env: "FOO=bar", Manifest: manifest1}
+	to := &structs.Release{Id: "R2", App: "app1", Build: "B1", Env: "FOO=bar", Manifest: manifest1}
+
+	d, err := diff.Releases(from, to, nil, false)
+	require.NoError(t, err)
+	require.True(t, d.Empty())
+}
+
+func TestReleasesFromEmpty(t *testing.T) {
+	from := &structs.Release{App: "app1"}
+	to := &structs.Release{Id: "R1", App: "app1", Build: "B1", Manifest: manifest1}
+
+	d, err := diff.Releases(from, to, &structs.Build{Id: "B1", Description: "first"}, false)
+	require.NoError(t, err)
+	require.Equal(t, &structs.ReleaseDiffBuild{To: "B1", Description: "first"}, d.Build)
+	require.Equal(t, []structs.ReleaseDiffService{
+		{Change: "added", Name: "web"},
+		{Change: "added", Name: "worker"},
+	}, d.Services)
+}
+
+func TestEnvReveal(t *testing.T) {
+	cs, err := diff.Env("FOO=bar\nOLD=1", "FOO=baz\nNEW=2", true)
+	require.NoError(t, err)
+	require.Equal(t, []structs.ReleaseDiffChange{
+		{Change: "changed", Name: "FOO", From: "bar", To: "baz"},
+		{Change: "added", Name: "NEW", To: "2"},
+		{Change: "removed", Name: "OLD", From: "1"},
+	}, cs)
+}
+
+func TestMapsEqual(t *testing.T) {
+	require.Nil(t, diff.Maps(map[string]string{"a": "1"}, map[string]string{"a": "1"}))
+}
@@ -0,0 +1,332 @@
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+var (
+	rePathPattern = regexp.MustCompile(`{([a-z]+):[^}]*}`)
+	reTag         = regexp.MustCompile(`^[A-Z][a-z]+`)
+)
+
+type openapiSpec struct {
+	OpenAPI    string                                 `json:"openapi"`
+	Info       map[string]string                      `json:"info"`
+	Paths      map[string]map[string]openapiOperation `json:"paths"`
+	Components map[string]map[string]*openapiSchema   `json:"components"`
+}
+
+type openapiOperation struct {
+	OperationId string                     `json:"operationId"`
+	Tags        []string                   `json:"tags"`
+	Description string                     `json:"description,omitempty"`
+	Parameters  []openapiParameter         `json:"parameters,omitempty"`
+	RequestBody *openapiBody               `json:"requestBody,omitempty"`
+	Responses   map[string]openapiResponse `json:"responses"`
+}
+
+type openapiParameter struct {
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required,omitempty"`
+	Schema   *openapiSchema `json:"schema"`
+}
+
+type openapiBody struct {
+	Content map[string]openapiMedia `json:"content"`
+}
+
+type openapiMedia struct {
+	Schema *openapiSchema `json:"schema"`
+}
+
+type openapiResponse struct {
+	Description string                  `json:"description"`
+	Content     map[string]openapiMedia `json:"content,omitempty"`
+}
+
+type openapiSchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Default              string                    `json:"default,omitempty"`
+	Items                *openapiSchema            `json:"items,omitempty"`
+	Properties           map[string]*openapiSchema `json:"properties,omitempty"`
+	AdditionalProperties *openapiSchema            `json:"additionalProperties,omitempty"`
+}
+
+// OpenAPI returns an OpenAPI 3 document describing every provider method with a route
+func OpenAPI() ([]byte, error) {
+	ms, err := Methods()
+	if err != nil {
+		return nil, err
+	}
+
+	spec := openapiSpec{
+		OpenAPI: "3.0.0",
+		Info: map[string]string{
+			"title":   "Convox Rack API",
+			"version": "3",
+		},
+		Paths: map[string]map[string]openapiOperation{},
+		Components: map[string]map[string]*openapiSchema{
+			"schemas": {},
+		},
+	}
+
+	for _, m := range ms {
+		if m.Route.Method == "" {
+			continue
+		}
+
+		op, err := openapiMethod(m, spec.Components["schemas"])
+		if err != nil {
+			return nil, err
+		}
+
+		path := rePathPattern.ReplaceAllString(m.Route.Path, "{$1}")
+		method := strings.ToLower(m.Route.Method)
+
+		if m.Socket() {
+			method = "get"
+			op.Description = "websocket"
+		}
+
+		if _, ok := spec.Paths[path]; !ok {
+			spec.Paths[path] = map[string]openapiOperation{}
+		}
+
+		spec.Paths[path][method] = *op
+	}
+
+	data, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{
+		"Spec": string(data),
+	}
+
+	data, err = renderTemplate("openapi", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return gofmt(data)
+}
+
+func openapiMethod(m Method, schemas map[string]*openapiSchema) (*openapiOperation, error) {
+	op := &openapiOperation{
+		OperationId: m.Name,
+		Tags:        []string{reTag.FindString(m.Name)},
+		Responses:   map[string]openapiResponse{},
+	}
+
+	location := "formData"
+
+	switch {
+	case m.Socket():
+		location = "header"
+	case m.Route.Method == "GET" || m.Route.Method == "DELETE":
+		location = "query"
+	}
+
+	form := &openapiSchema{Type: "object", Properties: map[string]*openapiSchema{}}
+
+	for _, a := range m.Args {
+		switch {
+		case a.Path(m):
+			op.Parameters = append(op.Parameters, openapiParameter{
+				Name:     a.Name,
+				In:       "path",
+				Required: true,
+				Schema:   openapiType(a.Type, schemas),
+			})
+		case a.Option():
+			for i := 0; i < a.Type.NumField(); i++ {
+				f := a.Type.Field(i)
+
+				s := openapiParam(f.Type, schemas)
+				s.Default = f.Tag.Get("default")
+
+				if n := f.Tag.Get("header"); n != "" {
+					op.Parameters = append(op.Parameters, openapiParameter{Name: n, In: "header", Schema: s})
+				}
+
+				if n := f.Tag.Get("param"); n != "" {
+					form.Properties[n] = s
+				}
+
+				if n := f.Tag.Get("query"); n != "" {
+					op.Parameters = append(op.Parameters, openapiParameter{Name: n, In: "query", Schema: s})
+				}
+			}
+		case a.Stream():
+			if a.Type.Implements(readerType) && !a.Type.Implements(readWriterType) {
+				op.RequestBody = &openapiBody{
+					Content: map[string]openapiMedia{
+						"application/octet-stream": {Schema: &openapiSchema{Type: "string", Format: "binary"}},
+					},
+				}
+			}
+		case location == "formData":
+			form.Properties[a.Name] = openapiParam(a.Type, schemas)
+		default:
+			op.Parameters = append(op.Parameters, openapiParameter{Name: a.Name, In: location, Schema: openapiParam(a.Type, schemas)})
+		}
+	}
+
+	if len(form.Properties) > 0 && op.RequestBody == nil {
+		op.RequestBody = &openapiBody{
+			Content: map[string]openapiMedia{
+				"application/x-www-form-urlencoded": {Schema: form},
+			},
+		}
+	}
+
+	res, err := openapiResponseFor(m, schemas)
+	if err != nil {
+		return nil, err
+	}
+
+	op.Responses["200"] = *res
+	op.Responses["default"] = openapiResponse{
+		Description: "error",
+		Content: map[string]openapiMedia{
+			"text/plain": {Schema: &openapiSchema{Type: "string"}},
+		},
+	}
+
+	return op, nil
+}
+
+func openapiResponseFor(m Method, schemas map[string]*openapiSchema) (*openapiResponse, error) {
+	binary := map[string]openapiMedia{
+		"application/octet-stream": {Schema: &openapiSchema{Type: "string", Format: "binary"}},
+	}
+
+	if m.Reader() || m.Writer() != "" {
+		return &openapiResponse{Description: "stream", Content: binary}, nil
+	}
+
+	rt, err := m.ReturnType()
+	if err != nil {
+		return nil, err
+	}
+
+	if rt == nil {
+		return &openapiResponse{Description: "ok"}, nil
+	}
+
+	switch rt.Kind() {
+	case reflect.Int, reflect.String:
+		return &openapiResponse{
+			Description: "ok",
+			Content: map[string]openapiMedia{
+				"text/plain": {Schema: openapiType(rt, schemas)},
+			},
+		}, nil
+	default:
+		return &openapiResponse{
+			Description: "ok",
+			Content: map[string]openapiMedia{
+				"application/json": {Schema: openapiType(rt, schemas)},
+			},
+		}, nil
+	}
+}
+
+// openapiParam describes the string encoding that stdapi expects for option values
+func openapiParam(t reflect.Type, schemas map[string]*openapiSchema) *openapiSchema {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == durationType:
+		return &openapiSchema{Type: "string", Format: "duration"}
+	case t == timeType:
+		return &openapiSchema{Type: "string", Format: "20060102.150405.000000000"}
+	case t.Kind() == reflect.Map:
+		return &openapiSchema{Type: "string", Format: "urlencoded"}
+	case t.Kind() == reflect.Slice:
+		return &openapiSchema{Type: "string", Format: "csv"}
+	default:
+		return openapiType(t, schemas)
+	}
+}
+
+func openapiType(t reflect.Type, schemas map[string]*openapiSchema) *openapiSchema {
+	switch t {
+	case durationType:
+		return &openapiSchema{Type: "integer", Format: "int64"}
+	case timeType:
+		return &openapiSchema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return openapiType(t.Elem(), schemas)
+	case reflect.Bool:
+		return &openapiSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int32:
+		return &openapiSchema{Type: "integer"}
+	case reflect.Int64:
+		return &openapiSchema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &openapiSchema{Type: "number"}
+	case reflect.Map:
+		return &openapiSchema{Type: "object", AdditionalProperties: openapiType(t.Elem(), schemas)}
+	case reflect.Slice:
+		return &openapiSchema{Type: "array", Items: openapiType(t.Elem(), schemas)}
+	case reflect.Struct:
+		return openapiStruct(t, schemas)
+	default:
+		return &openapiSchema{Type: "string"}
+	}
+}
+
+func openapiStruct(t reflect.Type, schemas map[string]*openapiSchema) *openapiSchema {
+	ref := &openapiSchema{Ref: fmt.Sprintf("#/components/schemas/%s", t.Name())}
+
+	if _, ok := schemas[t.Name()]; ok {
+		return ref
+	}
+
+	s := &openapiSchema{Type: "object", Properties: map[string]*openapiSchema{}}
+
+	// register before recursing to handle self-referencing types
+	schemas[t.Name()] = s
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		if f.PkgPath != "" {
+			continue
+		}
+
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+
+		s.Properties[name] = openapiType(f.Type, schemas)
+	}
+
+	return ref
+}
@@ -64,30 +64,71 @@ func (c *Controller) Run(informer cache.SharedInformer, ch chan error) {
 
 	c.recorder = eb.NewRecorder(scheme.Scheme, ac.EventSource{Component: c.Name})
 
+	el, err := leaderElector(c.Handler.Client(), c.Namespace, c.Name, c.Identifier, c.recorder, c.leaderStart(informer), nil)
+	if err != nil {
+		ch <- err
+		return
+	}
+
+	go el.Run()
+}
+
+// RunLeader calls fn once this process holds the leader lock for namespace/name, the stop
+// channel passed to fn is closed when the lock is lost and the lock is then contended again
+func RunLeader(client kubernetes.Interface, namespace, name string, fn func(stop <-chan struct{})) error {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+
+	eb := record.NewBroadcaster()
+	eb.StartRecordingToSink(&tc.EventSinkImpl{Interface: client.CoreV1().Events("")})
+
+	recorder := eb.NewRecorder(scheme.Scheme, ac.EventSource{Component: name})
+
+	start := func(stop <-chan struct{}) {
+		fmt.Printf("started leading: %s/%s (%s)\n", namespace, name, hostname)
+		fn(stop)
+	}
+
+	stopped := func() {
+		fmt.Printf("stopped leading: %s/%s (%s)\n", namespace, name, hostname)
+	}
+
+	el, err := leaderElector(client, namespace, name, hostname, recorder, start, stopped)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for {
+			el.Run()
+		}
+	}()
+
+	return nil
+}
+
+func leaderElector(client kubernetes.Interface, namespace, name, identity string, recorder record.EventRecorder, start func(<-chan struct{}), stop func()) (*leaderelection.LeaderElector, error) {
 	rl := &resourcelock.ConfigMapLock{
-		ConfigMapMeta: am.ObjectMeta{Namespace: c.Namespace, Name: c.Name},
-		Client:        c.Handler.Client().CoreV1(),
+		ConfigMapMeta: am.ObjectMeta{Namespace: namespace, Name: name},
+		Client:        client.CoreV1(),
 		LockConfig: resourcelock.ResourceLockConfig{
-			Identity:      c.Identifier,
-			EventRecorder: c.recorder,
+			Identity:      identity,
+			EventRecorder: recorder,
 		},
 	}
 
-	el, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+	return leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
 		Lock:          rl,
 		LeaseDuration: 15 * time.Second,
 		RenewDeadline: 10 * time.Second,
 		RetryPeriod:   2 * time.Second,
 		Callbacks: leaderelection.LeaderCallbacks{
-			OnStartedLeading: c.leaderStart(informer),
+			OnStartedLeading: start,
+			OnStoppedLeading: stop,
 		},
 	})
-	if err != nil {
-		ch <- err
-		return
-	}
-
-	go el.Run()
 }
 
 func (c *Controller) leaderStart(informer cache.SharedInformer) func(<-chan struct{}) {
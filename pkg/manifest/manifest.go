@@ -4,16 +4,21 @@ import (
 	"fmt"
 	"io"
 	"math/rand"
+	"net"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/convox/convox/pkg/cron"
 	yaml "gopkg.in/yaml.v2"
 )
 
 var (
-	DefaultCpu = 256
-	DefaultMem = 512
+	DefaultCpu          = 256
+	DefaultMem          = 512
+	DefaultRatePeriod   = 60
+	DefaultVolumeAccess = "ReadWriteMany"
+	DefaultVolumeSize   = 10
 )
 
 type Manifest struct {
@@ -21,6 +26,7 @@ type Manifest struct {
 	Environment Environment `yaml:"environment,omitempty"`
 	Params      Params      `yaml:"params,omitempty"`
 	Resources   Resources   `yaml:"resources,omitempty"`
+	Routes      Routes      `yaml:"routes,omitempty"`
 	Services    Services    `yaml:"services,omitempty"`
 	Timers      Timers      `yaml:"timers,omitempty"`
 
@@ -63,10 +69,34 @@ func Load(data []byte, env map[string]string) (*Manifest, error) {
 		return nil, err
 	}
 
+	if err := m.ValidateAccess(); err != nil {
+		return nil, err
+	}
+
 	if err := m.ValidateEnv(); err != nil {
 		return nil, err
 	}
 
+	if err := m.ValidateLimits(); err != nil {
+		return nil, err
+	}
+
+	if err := m.ValidateResources(); err != nil {
+		return nil, err
+	}
+
+	if err := m.ValidateRoutes(); err != nil {
+		return nil, err
+	}
+
+	if err := m.ValidateTimers(); err != nil {
+		return nil, err
+	}
+
+	if err := m.ValidateVolumes(); err != nil {
+		return nil, err
+	}
+
 	return &m, nil
 }
 
@@ -138,6 +168,16 @@ func (m *Manifest) CombineEnv() error {
 	return nil
 }
 
+func (m *Manifest) Resource(name string) (*Resource, error) {
+	for _, r := range m.Resources {
+		if r.Name == name {
+			return &r, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no such resource: %s", name)
+}
+
 func (m *Manifest) Service(name string) (*Service, error) {
 	for _, s := range m.Services {
 		if s.Name == name {
@@ -195,6 +235,35 @@ func (m *Manifest) ServiceEnvironment(service string) (map[string]string, error)
 	return env, nil
 }
 
+// ValidateAccess returns an error if the access rules for a service are invalid
+func (m *Manifest) ValidateAccess() error {
+	for _, s := range m.Services {
+		a := s.Access
+
+		if len(a.Allow) == 0 && a.Auth == "" {
+			continue
+		}
+
+		if s.Port.Port == 0 {
+			return fmt.Errorf("service %s requires a port for access rules", s.Name)
+		}
+
+		for _, cidr := range a.Allow {
+			if _, _, err := net.ParseCIDR(cidr); err != nil && net.ParseIP(cidr) == nil {
+				return fmt.Errorf("invalid allow for service %s: %s", s.Name, cidr)
+			}
+		}
+
+		switch a.Auth {
+		case "", "basic":
+		default:
+			return fmt.Errorf("invalid auth for service %s: %s", s.Name, a.Auth)
+		}
+	}
+
+	return nil
+}
+
 // ValidateEnv returns an error if required env vars for a service are not available
 // It also filters m.env to the union of all service env vars defined in the manifest
 func (m *Manifest) ValidateEnv() error {
@@ -220,7 +289,182 @@ func (m *Manifest) ValidateEnv() error {
 	return nil
 }
 
+// ValidateLimits returns an error if the request limits for a service are invalid
+func (m *Manifest) ValidateLimits() error {
+	for _, s := range m.Services {
+		l := s.Limits
+
+		if l.Body == 0 && l.Rate.Requests == 0 && l.Rate.By == "" && l.Rate.Period == 0 {
+			continue
+		}
+
+		if s.Port.Port == 0 {
+			return fmt.Errorf("service %s requires a port for limits", s.Name)
+		}
+
+		if l.Body < 0 {
+			return fmt.Errorf("invalid body limit for service %s: %d", s.Name, l.Body)
+		}
+
+		if l.Rate.Requests < 0 {
+			return fmt.Errorf("invalid rate limit for service %s: %d", s.Name, l.Rate.Requests)
+		}
+
+		if l.Rate.Requests == 0 {
+			continue
+		}
+
+		if l.Rate.Period < 1 {
+			return fmt.Errorf("invalid rate period for service %s: %d", s.Name, l.Rate.Period)
+		}
+
+		if by := l.Rate.By; by != "ip" && (!strings.HasPrefix(by, "header:") || by == "header:") {
+			return fmt.Errorf("invalid rate key for service %s: %s", s.Name, by)
+		}
+	}
+
+	return nil
+}
+
+func (m *Manifest) ValidateResources() error {
+	for _, r := range m.Resources {
+		if schedule := r.BackupSchedule(); schedule != "" {
+			if _, err := cron.Parse(schedule); err != nil {
+				return fmt.Errorf("resource %s: %s", r.Name, err)
+			}
+		}
+
+		if _, err := r.BackupRetention(); err != nil {
+			return err
+		}
+
+		if _, err := r.HA(); err != nil {
+			return err
+		}
+
+		if _, err := r.Replicas(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Manifest) ValidateRoutes() error {
+	seen := map[string]bool{}
+
+	for _, r := range m.Routes {
+		if r.Domain == "" {
+			return fmt.Errorf("route requires a domain")
+		}
+
+		if !strings.HasPrefix(r.Path, "/") {
+			return fmt.Errorf("invalid path for route %s: %s", r, r.Path)
+		}
+
+		if seen[r.String()] {
+			return fmt.Errorf("duplicate route: %s", r)
+		}
+
+		seen[r.String()] = true
+
+		if rr := r.Redirect; rr != nil {
+			switch rr.Code {
+			case 301, 302, 303, 307, 308:
+			default:
+				return fmt.Errorf("invalid redirect code for route %s: %d", r, rr.Code)
+			}
+
+			if rr.To != "" && r.Service != "" {
+				return fmt.Errorf("route %s can not redirect and have a service", r)
+			}
+		}
+
+		if !r.Forward() {
+			continue
+		}
+
+		if r.Service == "" {
+			return fmt.Errorf("route %s requires a service or a redirect", r)
+		}
+
+		s, err := m.Service(r.Service)
+		if err != nil {
+			return fmt.Errorf("route %s references unknown service: %s", r, r.Service)
+		}
+
+		if s.Port.Port == 0 {
+			return fmt.Errorf("route %s references service without a port: %s", r, r.Service)
+		}
+	}
+
+	return nil
+}
+
+func (m *Manifest) ValidateTimers() error {
+	for _, t := range m.Timers {
+		if _, err := t.ConcurrencyPolicy(); err != nil {
+			return err
+		}
+
+		if _, err := t.Location(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateVolumes returns an error if a volume is invalid or if services disagree on the settings of a shared volume
+func (m *Manifest) ValidateVolumes() error {
+	shared := map[string]ServiceVolume{}
+
+	for _, s := range m.Services {
+		for _, v := range s.Volumes {
+			if v.Path == "" {
+				return fmt.Errorf("volume %s for service %s requires a path", v.Name, s.Name)
+			}
+
+			if v.Size < 1 {
+				return fmt.Errorf("invalid size for volume %s: %d", v.Name, v.Size)
+			}
+
+			switch v.Access {
+			case "", "ReadOnlyMany", "ReadWriteMany", "ReadWriteOnce":
+			default:
+				return fmt.Errorf("invalid access for volume %s: %s", v.Name, v.Access)
+			}
+
+			if !v.Shared {
+				continue
+			}
+
+			if sv, ok := shared[v.Name]; ok && (sv.Size != v.Size || sv.Class != v.Class || sv.Access != v.Access) {
+				return fmt.Errorf("shared volume %s has conflicting settings", v.Name)
+			}
+
+			shared[v.Name] = v
+		}
+	}
+
+	return nil
+}
+
 func (m *Manifest) ApplyDefaults() error {
+	for i, r := range m.Routes {
+		if r.Path == "" {
+			m.Routes[i].Path = "/"
+		}
+
+		if len(r.Path) > 1 {
+			m.Routes[i].Path = strings.TrimSuffix(r.Path, "/")
+		}
+
+		if r.Redirect != nil && r.Redirect.Code == 0 {
+			m.Routes[i].Redirect.Code = DefaultRedirectCode
+		}
+	}
+
 	for i, s := range m.Services {
 		if s.Build.Path == "" && s.Image == "" {
 			m.Services[i].Build.Path = "."
@@ -234,6 +478,14 @@ func (m *Manifest) ApplyDefaults() error {
 			m.Services[i].Drain = 30
 		}
 
+		if s.Limits.Rate.Requests > 0 && s.Limits.Rate.By == "" {
+			m.Services[i].Limits.Rate.By = "ip"
+		}
+
+		if s.Limits.Rate.Requests > 0 && s.Limits.Rate.Period == 0 {
+			m.Services[i].Limits.Rate.Period = DefaultRatePeriod
+		}
+
 		if s.Health.Path == "" {
 			m.Services[i].Health.Path = "/"
 		}
@@ -277,6 +529,16 @@ func (m *Manifest) ApplyDefaults() error {
 		if !m.AttributeSet(fmt.Sprintf("services.%s.sticky", s.Name)) {
 			m.Services[i].Sticky = true
 		}
+
+		for j, v := range s.Volumes {
+			if v.Name == "" {
+				m.Services[i].Volumes[j].Name = v.Path
+			}
+
+			if v.Size == 0 {
+				m.Services[i].Volumes[j].Size = DefaultVolumeSize
+			}
+		}
 	}
 
 	return nil
@@ -412,6 +412,277 @@ func TestManifestLoadInvalid(t *testing.T) {
 	require.Len(t, m.Services, 0)
 }
 
+func TestManifestLoadTimers(t *testing.T) {
+	m, err := testdataManifest("timers", map[string]string{})
+	require.NoError(t, err)
+	require.Len(t, m.Timers, 2)
+
+	require.Equal(t, "cleanup", m.Timers[0].Name)
+	require.Equal(t, "", m.Timers[0].Timezone)
+
+	policy, err := m.Timers[0].ConcurrencyPolicy()
+	require.NoError(t, err)
+	require.Equal(t, "Allow", policy)
+
+	require.Equal(t, "report", m.Timers[1].Name)
+	require.Equal(t, "America/New_York", m.Timers[1].Timezone)
+
+	policy, err = m.Timers[1].ConcurrencyPolicy()
+	require.NoError(t, err)
+	require.Equal(t, "Forbid", policy)
+}
+
+func TestManifestLoadTimersInvalidTimezone(t *testing.T) {
+	m, err := manifest.Load([]byte("timers:\n  report:\n    command: bin/report\n    schedule: \"0 * * * *\"\n    service: web\n    timezone: Mars/Olympus_Mons\n"), map[string]string{})
+	require.Nil(t, m)
+	require.EqualError(t, err, "invalid timezone for timer report: Mars/Olympus_Mons")
+}
+
+func TestManifestLoadTimersInvalidConcurrency(t *testing.T) {
+	m, err := manifest.Load([]byte("timers:\n  report:\n    command: bin/report\n    concurrency: sometimes\n    schedule: \"0 * * * *\"\n    service: web\n"), map[string]string{})
+	require.Nil(t, m)
+	require.EqualError(t, err, "invalid concurrency for timer report: sometimes")
+}
+
+func TestManifestLoadResourceBackups(t *testing.T) {
+	m, err := manifest.Load([]byte("resources:\n  database:\n    type: postgres\n    options:\n      backup: \"0 3 * * *\"\n      backupRetention: \"14\"\n  cache:\n    type: redis\n"), map[string]string{})
+	require.NoError(t, err)
+
+	r, err := m.Resource("database")
+	require.NoError(t, err)
+	require.Equal(t, "0 3 * * *", r.BackupSchedule())
+
+	retention, err := r.BackupRetention()
+	require.NoError(t, err)
+	require.Equal(t, 14, retention)
+
+	r, err = m.Resource("cache")
+	require.NoError(t, err)
+	require.Equal(t, "", r.BackupSchedule())
+
+	retention, err = r.BackupRetention()
+	require.NoError(t, err)
+	require.Equal(t, manifest.DefaultBackupRetention, retention)
+}
+
+func TestManifestLoadResourceBackupsInvalid(t *testing.T) {
+	m, err := manifest.Load([]byte("resources:\n  database:\n    type: postgres\n    options:\n      backup: \"daily\"\n"), map[string]string{})
+	require.Nil(t, m)
+	require.EqualError(t, err, "resource database: invalid schedule expression: daily")
+
+	m, err = manifest.Load([]byte("resources:\n  database:\n    type: postgres\n    options:\n      backup: \"0 3 * * *\"\n      backupRetention: \"0\"\n"), map[string]string{})
+	require.Nil(t, m)
+	require.EqualError(t, err, "invalid backupRetention for resource database: 0")
+}
+
+func TestManifestLoadResourceHA(t *testing.T) {
+	m, err := manifest.Load([]byte("resources:\n  database:\n    type: postgres\n    options:\n      ha: \"true\"\n      replicas: \"2\"\n  cache:\n    type: redis\n"), map[string]string{})
+	require.NoError(t, err)
+
+	r, err := m.Resource("database")
+	require.NoError(t, err)
+
+	ha, err := r.HA()
+	require.NoError(t, err)
+	require.True(t, ha)
+
+	replicas, err := r.Replicas()
+	require.NoError(t, err)
+	require.Equal(t, 2, replicas)
+
+	r, err = m.Resource("cache")
+	require.NoError(t, err)
+
+	ha, err = r.HA()
+	require.NoError(t, err)
+	require.False(t, ha)
+
+	replicas, err = r.Replicas()
+	require.NoError(t, err)
+	require.Equal(t, manifest.DefaultReplicas, replicas)
+}
+
+func TestManifestLoadResourceHAInvalid(t *testing.T) {
+	m, err := manifest.Load([]byte("resources:\n  database:\n    type: postgres\n    options:\n      ha: \"sometimes\"\n"), map[string]string{})
+	require.Nil(t, m)
+	require.EqualError(t, err, "invalid ha for resource database: sometimes")
+
+	m, err = manifest.Load([]byte("resources:\n  cache:\n    type: memcached\n    options:\n      ha: \"true\"\n"), map[string]string{})
+	require.Nil(t, m)
+	require.EqualError(t, err, "ha is not available for resources of type: memcached")
+
+	m, err = manifest.Load([]byte("resources:\n  database:\n    type: postgres\n    options:\n      ha: \"true\"\n      replicas: \"0\"\n"), map[string]string{})
+	require.Nil(t, m)
+	require.EqualError(t, err, "invalid replicas for resource database: 0")
+}
+
+func TestManifestLoadAccess(t *testing.T) {
+	m, err := manifest.Load([]byte("services:\n  admin:\n    port: 3000\n    access:\n      allow: [10.0.0.0/8, 1.2.3.4]\n      auth: basic\n"), map[string]string{})
+	require.NoError(t, err)
+
+	s, err := m.Service("admin")
+	require.NoError(t, err)
+
+	require.Equal(t, manifest.ServiceAccess{Allow: []string{"10.0.0.0/8", "1.2.3.4"}, Auth: "basic"}, s.Access)
+}
+
+func TestManifestLoadAccessInvalid(t *testing.T) {
+	tests := map[string]string{
+		"services:\n  admin:\n    access:\n      auth: basic\n":                          "service admin requires a port for access rules",
+		"services:\n  admin:\n    port: 3000\n    access:\n      allow: [10.0.0.0/33]\n": "invalid allow for service admin: 10.0.0.0/33",
+		"services:\n  admin:\n    port: 3000\n    access:\n      auth: digest\n":         "invalid auth for service admin: digest",
+	}
+
+	for data, message := range tests {
+		m, err := manifest.Load([]byte(data), map[string]string{})
+		require.Nil(t, m)
+		require.EqualError(t, err, message)
+	}
+}
+
+func TestManifestLoadLimits(t *testing.T) {
+	m, err := manifest.Load([]byte("services:\n  api:\n    port: 3000\n    limits:\n      body: 10\n      rate:\n        requests: 100\n  web:\n    port: 3000\n    limits:\n      rate:\n        by: header:X-Api-Key\n        period: 1\n        requests: 5\n"), map[string]string{})
+	require.NoError(t, err)
+
+	s, err := m.Service("api")
+	require.NoError(t, err)
+
+	require.Equal(t, manifest.ServiceLimits{Body: 10, Rate: manifest.ServiceRateLimit{By: "ip", Period: 60, Requests: 100}}, s.Limits)
+
+	s, err = m.Service("web")
+	require.NoError(t, err)
+
+	require.Equal(t, manifest.ServiceLimits{Rate: manifest.ServiceRateLimit{By: "header:X-Api-Key", Period: 1, Requests: 5}}, s.Limits)
+}
+
+func TestManifestLoadLimitsInvalid(t *testing.T) {
+	tests := map[string]string{
+		"services:\n  api:\n    limits:\n      body: 10\n":                                                            "service api requires a port for limits",
+		"services:\n  api:\n    port: 3000\n    limits:\n      body: -1\n":                                            "invalid body limit for service api: -1",
+		"services:\n  api:\n    port: 3000\n    limits:\n      rate:\n        requests: -1\n":                         "invalid rate limit for service api: -1",
+		"services:\n  api:\n    port: 3000\n    limits:\n      rate:\n        requests: 5\n        period: -1\n":      "invalid rate period for service api: -1",
+		"services:\n  api:\n    port: 3000\n    limits:\n      rate:\n        requests: 5\n        by: cookie\n":      "invalid rate key for service api: cookie",
+		"services:\n  api:\n    port: 3000\n    limits:\n      rate:\n        requests: 5\n        by: \"header:\"\n": "invalid rate key for service api: header:",
+	}
+
+	for data, message := range tests {
+		m, err := manifest.Load([]byte(data), map[string]string{})
+		require.Nil(t, m)
+		require.EqualError(t, err, message)
+	}
+}
+
+func TestManifestLoadRoutes(t *testing.T) {
+	m, err := manifest.Load([]byte(`
+routes:
+  - domain: example.org
+    path: /api/
+    service: api
+    headers:
+      request:
+        add:
+          X-Api: "true"
+      response:
+        remove: [Server]
+  - domain: example.org
+    service: web
+    redirect:
+      https: true
+      code: 308
+  - domain: old.example.org
+    redirect:
+      to: https://example.org
+services:
+  api:
+    port: 5000
+  web:
+    port: 3000
+`), map[string]string{})
+	require.NoError(t, err)
+
+	require.Equal(t, manifest.Routes{
+		{
+			Domain: "example.org",
+			Headers: manifest.RouteHeaders{
+				Request:  manifest.RouteHeaderRules{Add: map[string]string{"X-Api": "true"}},
+				Response: manifest.RouteHeaderRules{Remove: []string{"Server"}},
+			},
+			Path:    "/api",
+			Service: "api",
+		},
+		{
+			Domain:   "example.org",
+			Path:     "/",
+			Redirect: &manifest.RouteRedirect{Code: 308, Https: true},
+			Service:  "web",
+		},
+		{
+			Domain:   "old.example.org",
+			Path:     "/",
+			Redirect: &manifest.RouteRedirect{Code: 301, To: "https://example.org"},
+		},
+	}, m.Routes)
+
+	require.True(t, m.Routes[1].Forward())
+	require.False(t, m.Routes[2].Forward())
+
+	require.True(t, m.Routes[0].Rules())
+	require.True(t, m.Routes[1].Rules())
+	require.False(t, manifest.Route{Domain: "example.org", Path: "/", Service: "web"}.Rules())
+}
+
+func TestManifestLoadRoutesInvalid(t *testing.T) {
+	tests := map[string]string{
+		"routes:\n  - service: web\n":                                                                  "route requires a domain",
+		"routes:\n  - domain: example.org\n    path: api\n    service: web\n":                          "invalid path for route example.orgapi: api",
+		"routes:\n  - domain: example.org\n    service: web\n  - domain: example.org\n    path: /\n":   "duplicate route: example.org/",
+		"routes:\n  - domain: example.org\n    redirect:\n      to: https://x.org\n      code: 200\n":  "invalid redirect code for route example.org/: 200",
+		"routes:\n  - domain: example.org\n    service: web\n    redirect:\n      to: https://x.org\n": "route example.org/ can not redirect and have a service",
+		"routes:\n  - domain: example.org\n":                                                           "route example.org/ requires a service or a redirect",
+		"routes:\n  - domain: example.org\n    service: other\n":                                       "route example.org/ references unknown service: other",
+		"routes:\n  - domain: example.org\n    service: worker\n":                                      "route example.org/ references service without a port: worker",
+	}
+
+	for data, message := range tests {
+		m, err := manifest.Load([]byte(data+"services:\n  web:\n    port: 3000\n  worker:\n    command: work\n"), map[string]string{})
+		require.Nil(t, m)
+		require.EqualError(t, err, message)
+	}
+}
+
+func TestManifestLoadVolumes(t *testing.T) {
+	m, err := manifest.Load([]byte("services:\n  web:\n    volumes:\n    - data:/data\n    - /cache\n    - name: db\n      path: /var/db\n      size: 20\n      class: standard\n      access: ReadWriteOnce\n    - name: uploads\n      path: /uploads\n      shared: true\n"), map[string]string{})
+	require.NoError(t, err)
+
+	s, err := m.Service("web")
+	require.NoError(t, err)
+
+	require.Equal(t, manifest.ServiceVolumes{
+		{Name: "data", Path: "/data", Size: 10, Shared: true},
+		{Name: "/cache", Path: "/cache", Size: 10},
+		{Name: "db", Path: "/var/db", Size: 20, Class: "standard", Access: "ReadWriteOnce"},
+		{Name: "uploads", Path: "/uploads", Size: 10, Shared: true},
+	}, s.Volumes)
+}
+
+func TestManifestLoadVolumesInvalid(t *testing.T) {
+	m, err := manifest.Load([]byte("services:\n  web:\n    volumes:\n    - name: db\n"), map[string]string{})
+	require.Nil(t, m)
+	require.EqualError(t, err, "volume db for service web requires a path")
+
+	m, err = manifest.Load([]byte("services:\n  web:\n    volumes:\n    - path: /data\n      size: -1\n"), map[string]string{})
+	require.Nil(t, m)
+	require.EqualError(t, err, "invalid size for volume /data: -1")
+
+	m, err = manifest.Load([]byte("services:\n  web:\n    volumes:\n    - path: /data\n      access: ReadWriteSometimes\n"), map[string]string{})
+	require.Nil(t, m)
+	require.EqualError(t, err, "invalid access for volume /data: ReadWriteSometimes")
+
+	m, err = manifest.Load([]byte("services:\n  web:\n    volumes:\n    - data:/data\n  worker:\n    volumes:\n    - name: data\n      path: /data\n      size: 20\n      shared: true\n"), map[string]string{})
+	require.Nil(t, m)
+	require.EqualError(t, err, "shared volume data has conflicting settings")
+}
+
 func TestManifestEnvManipulation(t *testing.T) {
 	m, err := testdataManifest("env", map[string]string{})
 	require.NotNil(t, m)
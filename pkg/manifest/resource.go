@@ -1,5 +1,15 @@
 package manifest
 
+import (
+	"fmt"
+	"strconv"
+)
+
+const (
+	DefaultBackupRetention = 7
+	DefaultReplicas        = 1
+)
+
 type Resource struct {
 	Name    string            `yaml:"-"`
 	Type    string            `yaml:"type"`
@@ -8,6 +18,65 @@ type Resource struct {
 
 type Resources []Resource
 
+// BackupRetention returns how many scheduled backups of the resource are kept
+func (r Resource) BackupRetention() (int, error) {
+	v, ok := r.Options["backupRetention"]
+	if !ok {
+		return DefaultBackupRetention, nil
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid backupRetention for resource %s: %s", r.Name, v)
+	}
+
+	return n, nil
+}
+
+// BackupSchedule returns the cron expression for scheduled backups (UTC), or an empty string when there are none
+func (r Resource) BackupSchedule() string {
+	return r.Options["backup"]
+}
+
+// HA is true when the resource runs a primary with replicas and fails over automatically
+func (r Resource) HA() (bool, error) {
+	v, ok := r.Options["ha"]
+	if !ok {
+		return false, nil
+	}
+
+	ha, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("invalid ha for resource %s: %s", r.Name, v)
+	}
+
+	if !ha {
+		return false, nil
+	}
+
+	switch r.Type {
+	case "mysql", "postgres", "redis":
+		return true, nil
+	default:
+		return false, fmt.Errorf("ha is not available for resources of type: %s", r.Type)
+	}
+}
+
+// Replicas returns the number of read replicas that run alongside the primary of an ha resource
+func (r Resource) Replicas() (int, error) {
+	v, ok := r.Options["replicas"]
+	if !ok {
+		return DefaultReplicas, nil
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid replicas for resource %s: %s", r.Name, v)
+	}
+
+	return n, nil
+}
+
 func (r Resource) GetName() string {
 	return r.Name
 }
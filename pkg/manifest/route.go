@@ -0,0 +1,53 @@
+package manifest
+
+import "fmt"
+
+const (
+	DefaultRedirectCode = 301
+)
+
+// Route sends requests for a path prefix on a domain to a service, or redirects them
+type Route struct {
+	Domain   string         `yaml:"domain"`
+	Headers  RouteHeaders   `yaml:"headers,omitempty"`
+	Path     string         `yaml:"path,omitempty"`
+	Redirect *RouteRedirect `yaml:"redirect,omitempty"`
+	Service  string         `yaml:"service,omitempty"`
+}
+
+type Routes []Route
+
+type RouteHeaders struct {
+	Request  RouteHeaderRules `yaml:"request,omitempty"`
+	Response RouteHeaderRules `yaml:"response,omitempty"`
+}
+
+type RouteHeaderRules struct {
+	Add    map[string]string `yaml:"add,omitempty"`
+	Remove []string          `yaml:"remove,omitempty"`
+}
+
+// RouteRedirect sends requests to another url, or only from http to https when To is empty
+type RouteRedirect struct {
+	Code  int    `yaml:"code,omitempty"`
+	Https bool   `yaml:"https,omitempty"`
+	To    string `yaml:"to,omitempty"`
+}
+
+func (r Route) String() string {
+	return fmt.Sprintf("%s%s", r.Domain, r.Path)
+}
+
+// Rules is true when the route redirects or changes headers
+func (r Route) Rules() bool {
+	return r.Redirect != nil || r.Headers.Request.any() || r.Headers.Response.any()
+}
+
+func (r RouteHeaderRules) any() bool {
+	return len(r.Add) > 0 || len(r.Remove) > 0
+}
+
+// Forward is true when requests are sent on to a service
+func (r Route) Forward() bool {
+	return r.Redirect == nil || r.Redirect.To == ""
+}
@@ -10,6 +10,7 @@ import (
 type Service struct {
 	Name string `yaml:"-"`
 
+	Access      ServiceAccess  `yaml:"access,omitempty"`
 	Agent       ServiceAgent   `yaml:"agent,omitempty"`
 	Build       ServiceBuild   `yaml:"build,omitempty"`
 	Command     string         `yaml:"command,omitempty"`
@@ -20,6 +21,7 @@ type Service struct {
 	Image       string         `yaml:"image,omitempty"`
 	Init        bool           `yaml:"init,omitempty"`
 	Internal    bool           `yaml:"internal,omitempty"`
+	Limits      ServiceLimits  `yaml:"limits,omitempty"`
 	Links       []string       `yaml:"links,omitempty"`
 	Port        ServicePort    `yaml:"port,omitempty"`
 	Privileged  bool           `yaml:"privileged,omitempty"`
@@ -28,11 +30,18 @@ type Service struct {
 	Singleton   bool           `yaml:"singleton,omitempty"`
 	Sticky      bool           `yaml:"sticky,omitempty"`
 	Test        string         `yaml:"test,omitempty"`
-	Volumes     []string       `yaml:"volumes,omitempty"`
+	Volumes     ServiceVolumes `yaml:"volumes,omitempty"`
 }
 
 type Services []Service
 
+// ServiceAccess restricts external requests to a service to the networks in Allow and, when Auth
+// is basic, to clients with credentials from the BASIC_AUTH environment variable
+type ServiceAccess struct {
+	Allow []string `yaml:"allow,omitempty"`
+	Auth  string   `yaml:"auth,omitempty"`
+}
+
 type ServiceAgent struct {
 	Enabled bool               `yaml:"enabled,omitempty"`
 	Ports   []ServiceAgentPort `yaml:"ports,omitempty"`
@@ -58,6 +67,20 @@ type ServiceHealth struct {
 	Timeout  int
 }
 
+// ServiceLimits caps the size of request bodies in megabytes and the rate of requests from each client
+type ServiceLimits struct {
+	Body int              `yaml:"body,omitempty"`
+	Rate ServiceRateLimit `yaml:"rate,omitempty"`
+}
+
+// ServiceRateLimit allows Requests per Period seconds for each client, clients are identified by ip
+// or by the value of a header when By is header:<name>
+type ServiceRateLimit struct {
+	By       string `yaml:"by,omitempty"`
+	Period   int    `yaml:"period,omitempty"`
+	Requests int    `yaml:"requests,omitempty"`
+}
+
 type ServicePort struct {
 	Port   int    `yaml:"port,omitempty"`
 	Scheme string `yaml:"scheme,omitempty"`
@@ -92,10 +115,28 @@ type ServiceScaleTargets struct {
 	Requests int
 }
 
+// ServiceVolume is a persistent volume mounted at Path, shared volumes are mounted by every
+// service in the app that uses the same name. an empty Access uses the default of the rack
+type ServiceVolume struct {
+	Name   string `yaml:"name,omitempty"`
+	Path   string `yaml:"path,omitempty"`
+	Size   int    `yaml:"size,omitempty"`
+	Class  string `yaml:"class,omitempty"`
+	Access string `yaml:"access,omitempty"`
+	Shared bool   `yaml:"shared,omitempty"`
+}
+
+type ServiceVolumes []ServiceVolume
+
 func (s Service) BuildHash(key string) string {
 	return fmt.Sprintf("%x", sha1.Sum([]byte(fmt.Sprintf("key=%q build[path=%q, manifest=%q, args=%v] image=%q", key, s.Build.Path, s.Build.Manifest, s.Build.Args, s.Image))))
 }
 
+// BodyBytes returns the body limit in bytes
+func (l ServiceLimits) BodyBytes() int64 {
+	return int64(l.Body) * 1024 * 1024
+}
+
 func (s Service) Domain() string {
 	if len(s.Domains) < 1 {
 		return ""
@@ -3,14 +3,17 @@ package manifest
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 type Timer struct {
 	Name string `yaml:"-"`
 
-	Command  string `yaml:"command"`
-	Schedule string `yaml:"schedule"`
-	Service  string `yaml:"service"`
+	Command     string `yaml:"command"`
+	Concurrency string `yaml:"concurrency,omitempty"`
+	Schedule    string `yaml:"schedule"`
+	Service     string `yaml:"service"`
+	Timezone    string `yaml:"timezone,omitempty"`
 }
 
 type Timers []Timer
@@ -26,6 +29,34 @@ func (t Timer) Cron() (string, error) {
 	}
 }
 
+// ConcurrencyPolicy returns the CronJob policy for runs that overlap a previous run that is still active
+func (t Timer) ConcurrencyPolicy() (string, error) {
+	switch t.Concurrency {
+	case "", "allow":
+		return "Allow", nil
+	case "forbid":
+		return "Forbid", nil
+	case "replace":
+		return "Replace", nil
+	default:
+		return "", fmt.Errorf("invalid concurrency for timer %s: %s", t.Name, t.Concurrency)
+	}
+}
+
+// Location returns the time zone that the schedule runs in
+func (t Timer) Location() (*time.Location, error) {
+	if t.Timezone == "Local" {
+		return nil, fmt.Errorf("invalid timezone for timer %s: %s", t.Name, t.Timezone)
+	}
+
+	loc, err := time.LoadLocation(t.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone for timer %s: %s", t.Name, t.Timezone)
+	}
+
+	return loc, nil
+}
+
 func (t Timer) GetName() string {
 	return t.Name
 }
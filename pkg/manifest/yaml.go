@@ -499,6 +499,38 @@ func (v ServiceScaleCount) MarshalYAML() (interface{}, error) {
 	return v, nil
 }
 
+func (v *ServiceVolume) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var w interface{}
+
+	if err := unmarshal(&w); err != nil {
+		return err
+	}
+
+	switch t := w.(type) {
+	case map[interface{}]interface{}:
+		type serviceVolume ServiceVolume
+		var sv serviceVolume
+		if err := remarshal(w, &sv); err != nil {
+			return err
+		}
+		*v = ServiceVolume(sv)
+	case string:
+		// from:to is shared by the app, a bare path belongs to the service
+		if parts := strings.SplitN(t, ":", 2); len(parts) == 2 {
+			v.Name = parts[0]
+			v.Path = parts[1]
+			v.Shared = true
+		} else {
+			v.Name = t
+			v.Path = t
+		}
+	default:
+		return fmt.Errorf("unknown type for service volume: %T", t)
+	}
+
+	return nil
+}
+
 func (v Timers) MarshalYAML() (interface{}, error) {
 	return marshalMapSlice(v)
 }
@@ -208,6 +208,43 @@ func (_m *Interface) AppUpdate(name string, opts structs.AppUpdateOptions) error
 	return r0
 }
 
+// AuditList provides a mock function with given fields: opts
+func (_m *Interface) AuditList(opts structs.AuditListOptions) (structs.AuditEntries, error) {
+	ret := _m.Called(opts)
+
+	var r0 structs.AuditEntries
+	if rf, ok := ret.Get(0).(func(structs.AuditListOptions) structs.AuditEntries); ok {
+		r0 = rf(opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(structs.AuditEntries)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(structs.AuditListOptions) error); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuditRecord provides a mock function with given fields: entry
+func (_m *Interface) AuditRecord(entry structs.AuditEntry) error {
+	ret := _m.Called(entry)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(structs.AuditEntry) error); ok {
+		r0 = rf(entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // BalancerList provides a mock function with given fields: app
 func (_m *Interface) BalancerList(app string) (structs.Balancers, error) {
 	ret := _m.Called(app)
@@ -595,6 +632,29 @@ func (_m *Interface) CertificateList() (structs.Certificates, error) {
 	return r0, r1
 }
 
+// EnvironmentSecretList provides a mock function with given fields: app
+func (_m *Interface) EnvironmentSecretList(app string) (structs.EnvironmentSecrets, error) {
+	ret := _m.Called(app)
+
+	var r0 structs.EnvironmentSecrets
+	if rf, ok := ret.Get(0).(func(string) structs.EnvironmentSecrets); ok {
+		r0 = rf(app)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(structs.EnvironmentSecrets)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(app)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // EnvironmentSet provides a mock function with given fields: _a0, _a1
 func (_m *Interface) EnvironmentSet(_a0 string, _a1 []byte) (*structs.Release, error) {
 	ret := _m.Called(_a0, _a1)
@@ -655,6 +715,29 @@ func (_m *Interface) EventSend(action string, opts structs.EventSendOptions) err
 	return r0
 }
 
+// FilesChecksums provides a mock function with given fields: app, pid, path
+func (_m *Interface) FilesChecksums(app string, pid string, path string) (structs.FileChecksums, error) {
+	ret := _m.Called(app, pid, path)
+
+	var r0 structs.FileChecksums
+	if rf, ok := ret.Get(0).(func(string, string, string) structs.FileChecksums); ok {
+		r0 = rf(app, pid, path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(structs.FileChecksums)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(app, pid, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FilesDelete provides a mock function with given fields: app, pid, files
 func (_m *Interface) FilesDelete(app string, pid string, files []string) error {
 	ret := _m.Called(app, pid, files)
@@ -968,6 +1051,27 @@ func (_m *Interface) ObjectStore(app string, key string, r io.Reader, opts struc
 	return r0, r1
 }
 
+// ProcessDebug provides a mock function with given fields: app, pid, rw, opts
+func (_m *Interface) ProcessDebug(app string, pid string, rw io.ReadWriter, opts structs.ProcessDebugOptions) (int, error) {
+	ret := _m.Called(app, pid, rw, opts)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(string, string, io.ReadWriter, structs.ProcessDebugOptions) int); ok {
+		r0 = rf(app, pid, rw, opts)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, io.ReadWriter, structs.ProcessDebugOptions) error); ok {
+		r1 = rf(app, pid, rw, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ProcessExec provides a mock function with given fields: app, pid, command, rw, opts
 func (_m *Interface) ProcessExec(app string, pid string, command string, rw io.ReadWriter, opts structs.ProcessExecOptions) (int, error) {
 	ret := _m.Called(app, pid, command, rw, opts)
@@ -989,6 +1093,20 @@ func (_m *Interface) ProcessExec(app string, pid string, command string, rw io.R
 	return r0, r1
 }
 
+// ProcessForward provides a mock function with given fields: app, pid, port, rw, opts
+func (_m *Interface) ProcessForward(app string, pid string, port int, rw io.ReadWriter, opts structs.ProcessForwardOptions) error {
+	ret := _m.Called(app, pid, port, rw, opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, int, io.ReadWriter, structs.ProcessForwardOptions) error); ok {
+		r0 = rf(app, pid, port, rw, opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ProcessGet provides a mock function with given fields: app, pid
 func (_m *Interface) ProcessGet(app string, pid string) (*structs.Process, error) {
 	ret := _m.Called(app, pid)
@@ -1248,6 +1366,29 @@ func (_m *Interface) ReleaseCreate(app string, opts structs.ReleaseCreateOptions
 	return r0, r1
 }
 
+// ReleaseDiff provides a mock function with given fields: app, id, opts
+func (_m *Interface) ReleaseDiff(app string, id string, opts structs.ReleaseDiffOptions) (*structs.ReleaseDiff, error) {
+	ret := _m.Called(app, id, opts)
+
+	var r0 *structs.ReleaseDiff
+	if rf, ok := ret.Get(0).(func(string, string, structs.ReleaseDiffOptions) *structs.ReleaseDiff); ok {
+		r0 = rf(app, id, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*structs.ReleaseDiff)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, structs.ReleaseDiffOptions) error); ok {
+		r1 = rf(app, id, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ReleaseGet provides a mock function with given fields: app, id
 func (_m *Interface) ReleaseGet(app string, id string) (*structs.Release, error) {
 	ret := _m.Called(app, id)
@@ -1308,6 +1449,29 @@ func (_m *Interface) ReleasePromote(app string, id string, opts structs.ReleaseP
 	return r0
 }
 
+// ResourceBackupList provides a mock function with given fields: app, name
+func (_m *Interface) ResourceBackupList(app string, name string) (structs.ResourceBackups, error) {
+	ret := _m.Called(app, name)
+
+	var r0 structs.ResourceBackups
+	if rf, ok := ret.Get(0).(func(string, string) structs.ResourceBackups); ok {
+		r0 = rf(app, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(structs.ResourceBackups)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(app, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ResourceConsole provides a mock function with given fields: app, name, rw, opts
 func (_m *Interface) ResourceConsole(app string, name string, rw io.ReadWriter, opts structs.ResourceConsoleOptions) error {
 	ret := _m.Called(app, name, rw, opts)
@@ -1428,6 +1592,43 @@ func (_m *Interface) ResourceList(app string) (structs.Resources, error) {
 	return r0, r1
 }
 
+// ResourceRestore provides a mock function with given fields: app, name, id
+func (_m *Interface) ResourceRestore(app string, name string, id string) error {
+	ret := _m.Called(app, name, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(app, name, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResourceUpdate provides a mock function with given fields: app, name, opts
+func (_m *Interface) ResourceUpdate(app string, name string, opts structs.ResourceUpdateOptions) (structs.ResourceChanges, error) {
+	ret := _m.Called(app, name, opts)
+
+	var r0 structs.ResourceChanges
+	if rf, ok := ret.Get(0).(func(string, string, structs.ResourceUpdateOptions) structs.ResourceChanges); ok {
+		r0 = rf(app, name, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(structs.ResourceChanges)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, structs.ResourceUpdateOptions) error); ok {
+		r1 = rf(app, name, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ResourceUpdateClassic provides a mock function with given fields: _a0, _a1
 func (_m *Interface) ResourceUpdateClassic(_a0 string, _a1 structs.ResourceUpdateOptions) (*structs.Resource, error) {
 	ret := _m.Called(_a0, _a1)
@@ -1451,6 +1652,20 @@ func (_m *Interface) ResourceUpdateClassic(_a0 string, _a1 structs.ResourceUpdat
 	return r0, r1
 }
 
+// ServiceForward provides a mock function with given fields: app, name, port, rw, opts
+func (_m *Interface) ServiceForward(app string, name string, port int, rw io.ReadWriter, opts structs.ServiceForwardOptions) error {
+	ret := _m.Called(app, name, port, rw, opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, int, io.ReadWriter, structs.ServiceForwardOptions) error); ok {
+		r0 = rf(app, name, port, rw, opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ServiceList provides a mock function with given fields: app
 func (_m *Interface) ServiceList(app string) (structs.Services, error) {
 	ret := _m.Called(app)
@@ -1488,6 +1703,34 @@ func (_m *Interface) ServiceRestart(app string, name string) error {
 	return r0
 }
 
+// ServiceScheduleDelete provides a mock function with given fields: app, name, schedule
+func (_m *Interface) ServiceScheduleDelete(app string, name string, schedule string) error {
+	ret := _m.Called(app, name, schedule)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(app, name, schedule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ServiceScheduleSet provides a mock function with given fields: app, name, schedule, opts
+func (_m *Interface) ServiceScheduleSet(app string, name string, schedule string, opts structs.ServiceScheduleSetOptions) error {
+	ret := _m.Called(app, name, schedule, opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, structs.ServiceScheduleSetOptions) error); ok {
+		r0 = rf(app, name, schedule, opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ServiceUpdate provides a mock function with given fields: app, name, opts
 func (_m *Interface) ServiceUpdate(app string, name string, opts structs.ServiceUpdateOptions) error {
 	ret := _m.Called(app, name, opts)
@@ -2030,6 +2273,237 @@ func (_m *Interface) SystemUpdate(opts structs.SystemUpdateOptions) error {
 	return r0
 }
 
+// TimerList provides a mock function with given fields: app
+func (_m *Interface) TimerList(app string) (structs.Timers, error) {
+	ret := _m.Called(app)
+
+	var r0 structs.Timers
+	if rf, ok := ret.Get(0).(func(string) structs.Timers); ok {
+		r0 = rf(app)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(structs.Timers)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(app)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TimerResume provides a mock function with given fields: app, name
+func (_m *Interface) TimerResume(app string, name string) error {
+	ret := _m.Called(app, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(app, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TimerRun provides a mock function with given fields: app, name
+func (_m *Interface) TimerRun(app string, name string) error {
+	ret := _m.Called(app, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(app, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TimerSuspend provides a mock function with given fields: app, name
+func (_m *Interface) TimerSuspend(app string, name string) error {
+	ret := _m.Called(app, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(app, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TokenAuthenticate provides a mock function with given fields: secret
+func (_m *Interface) TokenAuthenticate(secret string) (*structs.Token, error) {
+	ret := _m.Called(secret)
+
+	var r0 *structs.Token
+	if rf, ok := ret.Get(0).(func(string) *structs.Token); ok {
+		r0 = rf(secret)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*structs.Token)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(secret)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TokenCreate provides a mock function with given fields: name, opts
+func (_m *Interface) TokenCreate(name string, opts structs.TokenCreateOptions) (*structs.Token, error) {
+	ret := _m.Called(name, opts)
+
+	var r0 *structs.Token
+	if rf, ok := ret.Get(0).(func(string, structs.TokenCreateOptions) *structs.Token); ok {
+		r0 = rf(name, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*structs.Token)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, structs.TokenCreateOptions) error); ok {
+		r1 = rf(name, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TokenDelete provides a mock function with given fields: id
+func (_m *Interface) TokenDelete(id string) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TokenList provides a mock function with given fields:
+func (_m *Interface) TokenList() (structs.Tokens, error) {
+	ret := _m.Called()
+
+	var r0 structs.Tokens
+	if rf, ok := ret.Get(0).(func() structs.Tokens); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(structs.Tokens)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VolumeList provides a mock function with given fields: app
+func (_m *Interface) VolumeList(app string) (structs.Volumes, error) {
+	ret := _m.Called(app)
+
+	var r0 structs.Volumes
+	if rf, ok := ret.Get(0).(func(string) structs.Volumes); ok {
+		r0 = rf(app)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(structs.Volumes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(app)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VolumeRestore provides a mock function with given fields: app, name, id, opts
+func (_m *Interface) VolumeRestore(app string, name string, id string, opts structs.VolumeRestoreOptions) error {
+	ret := _m.Called(app, name, id, opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, structs.VolumeRestoreOptions) error); ok {
+		r0 = rf(app, name, id, opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VolumeSnapshot provides a mock function with given fields: app, name
+func (_m *Interface) VolumeSnapshot(app string, name string) (*structs.VolumeSnapshot, error) {
+	ret := _m.Called(app, name)
+
+	var r0 *structs.VolumeSnapshot
+	if rf, ok := ret.Get(0).(func(string, string) *structs.VolumeSnapshot); ok {
+		r0 = rf(app, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*structs.VolumeSnapshot)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(app, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VolumeSnapshotList provides a mock function with given fields: app, name
+func (_m *Interface) VolumeSnapshotList(app string, name string) (structs.VolumeSnapshots, error) {
+	ret := _m.Called(app, name)
+
+	var r0 structs.VolumeSnapshots
+	if rf, ok := ret.Get(0).(func(string, string) structs.VolumeSnapshots); ok {
+		r0 = rf(app, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(structs.VolumeSnapshots)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(app, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // WithContext provides a mock function with given fields: ctx
 func (_m *Interface) WithContext(ctx context.Context) structs.Provider {
 	ret := _m.Called(ctx)
@@ -14,6 +14,8 @@ type Backend interface {
 }
 
 type BackendRouter interface {
+	RuleRemove(host string) error
+	RuleSet(host string, rule *Rule) error
 	TargetAdd(host, target string, idles bool) error
 	TargetRemove(host, target string) error
 }
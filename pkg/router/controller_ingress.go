@@ -3,6 +3,7 @@ package router
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/convox/convox/pkg/kctl"
 	ac "k8s.io/api/core/v1"
@@ -69,11 +70,42 @@ func (c *IngressController) Add(obj interface{}) error {
 
 	fmt.Printf("ns=controller.ingress at=add ingress=%s\n", i.ObjectMeta.Name)
 
+	rule, err := parseRule(i.ObjectMeta.Annotations)
+	if err != nil {
+		return err
+	}
+
+	if name := i.ObjectMeta.Annotations["convox.pages"]; name != "" {
+		// the default pages are served until the custom ones can be read
+		cm, err := c.kc.CoreV1().ConfigMaps(i.ObjectMeta.Namespace).Get(name, am.GetOptions{})
+		if err != nil {
+			fmt.Printf("ns=controller.ingress at=add ingress=%s pages=%s error=%q\n", i.ObjectMeta.Name, name, err)
+		} else {
+			rule.Pages = cm.Data
+		}
+	}
+
 	for _, r := range i.Spec.Rules {
 		for _, port := range r.IngressRuleValue.HTTP.Paths {
+			host := rulePathHost(r.Host, port)
+
+			pr, err := pathRule(rule, i.ObjectMeta, port)
+			if err != nil {
+				return err
+			}
+
+			if pr != nil {
+				c.router.RuleSet(host, pr)
+			}
+
+			// redirects have no target
+			if pr != nil && pr.Redirect != nil && pr.Redirect.To != "" {
+				continue
+			}
+
 			target := rulePathTarget(port, i.ObjectMeta)
-			c.controller.Event(i, ac.EventTypeNormal, "TargetAdd", fmt.Sprintf("%s => %s", r.Host, target))
-			c.router.TargetAdd(r.Host, target, i.ObjectMeta.Annotations["convox.idles"] == "true")
+			c.controller.Event(i, ac.EventTypeNormal, "TargetAdd", fmt.Sprintf("%s => %s", host, target))
+			c.router.TargetAdd(host, target, i.ObjectMeta.Annotations["convox.idles"] == "true")
 		}
 	}
 
@@ -92,11 +124,27 @@ func (c *IngressController) Delete(obj interface{}) error {
 
 	fmt.Printf("ns=controller.ingress at=delete ingress=%s\n", i.ObjectMeta.Name)
 
+	rule, err := parseRule(i.ObjectMeta.Annotations)
+	if err != nil {
+		return err
+	}
+
 	for _, r := range i.Spec.Rules {
 		for _, port := range r.IngressRuleValue.HTTP.Paths {
+			host := rulePathHost(r.Host, port)
+
+			pr, err := pathRule(rule, i.ObjectMeta, port)
+			if err != nil {
+				return err
+			}
+
+			if pr != nil {
+				c.router.RuleRemove(host)
+			}
+
 			target := rulePathTarget(port, i.ObjectMeta)
-			c.controller.Event(i, ac.EventTypeNormal, "TargetDelete", fmt.Sprintf("%s => %s", r.Host, target))
-			c.router.TargetRemove(r.Host, rulePathTarget(port, i.ObjectMeta))
+			c.controller.Event(i, ac.EventTypeNormal, "TargetDelete", fmt.Sprintf("%s => %s", host, target))
+			c.router.TargetRemove(host, target)
 		}
 	}
 
@@ -154,6 +202,12 @@ func assertIngress(v interface{}) (*ae.Ingress, error) {
 	return i, nil
 }
 
+// rulePathHost is the key for the targets of an ingress path, paths below the root are
+// appended to the host
+func rulePathHost(host string, port ae.HTTPIngressPath) string {
+	return host + strings.TrimSuffix(port.Path, "/")
+}
+
 func rulePathTarget(port ae.HTTPIngressPath, meta am.ObjectMeta) string {
 	proto := "http"
 
@@ -4,10 +4,14 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"math"
 	"net"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/convox/convox/pkg/common"
 )
@@ -19,9 +23,12 @@ type HTTP struct {
 }
 
 type HTTPRouter interface {
+	RateIncrement(key string, window time.Duration) (int64, error)
 	RequestBegin(target string) error
+	RequestDenied(host, reason string) error
 	RequestEnd(target string) error
-	Route(host string) (string, error)
+	Route(host, path string) (string, *Rule, error)
+	Waking(target string) bool
 }
 
 func NewHTTP(ln net.Listener, router HTTPRouter) (*HTTP, error) {
@@ -81,9 +88,39 @@ func (h *HTTP) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		//   return
 	}
 
-	target, err := h.router.Route(r.Host)
+	target, rule, err := h.router.Route(r.Host, r.URL.Path)
+
+	if rule != nil && rule.Maintenance {
+		servePage(w, rule, "maintenance", http.StatusServiceUnavailable, defaultMaintenancePage)
+		return
+	}
+
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		serveError(w, rule, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	if rule != nil && rule.Access != nil {
+		if !rule.Access.Allowed(r) {
+			h.router.RequestDenied(r.Host, "allow")
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		if !rule.Access.Authorized(r) {
+			h.router.RequestDenied(r.Host, "auth")
+			w.Header().Set("WWW-Authenticate", `Basic realm="convox"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	if rule != nil && rule.Limits != nil && !h.limit(w, r, rule.Limits) {
+		return
+	}
+
+	if rule != nil && rule.Redirect != nil && rule.Redirect.To != "" {
+		http.Redirect(w, r, rule.Redirect.To, rule.Redirect.Code)
 		return
 	}
 
@@ -102,7 +139,15 @@ func (h *HTTP) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	p.Director = h.proxyDirector(p.Director)
 
-	p.ErrorHandler = h.proxyErrorHandler
+	if rule != nil {
+		p.Director = ruleDirector(p.Director, rule)
+		p.ModifyResponse = func(res *http.Response) error {
+			rule.ResponseHeaders.Apply(res.Header)
+			return nil
+		}
+	}
+
+	p.ErrorHandler = h.proxyErrorHandler(target, rule)
 
 	t := common.NewDefaultTransport()
 
@@ -138,6 +183,69 @@ func (h *HTTP) proxyDirector(existing func(r *http.Request)) func(r *http.Reques
 	}
 }
 
-func (h *HTTP) proxyErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
-	http.Error(w, err.Error(), http.StatusBadGateway)
+func ruleDirector(existing func(r *http.Request), rule *Rule) func(r *http.Request) {
+	return func(r *http.Request) {
+		existing(r)
+
+		rule.RequestHeaders.Apply(r.Header)
+	}
+}
+
+// limit enforces the limits of a rule and returns false when the request has been rejected
+func (h *HTTP) limit(w http.ResponseWriter, r *http.Request, l *RuleLimits) bool {
+	if l.Body > 0 {
+		if r.ContentLength > l.Body {
+			h.router.RequestDenied(r.Host, "body")
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return false
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, l.Body)
+	}
+
+	if l.Rate > 0 {
+		count, err := h.router.RateIncrement(fmt.Sprintf("%s.%s", l.Scope, l.Client(r)), l.Period)
+		if err != nil {
+			// let requests through rather than fail them all when the storage backend is unavailable
+			fmt.Printf("ns=http at=limit error=%q\n", err)
+			return true
+		}
+
+		if count > l.Rate {
+			now := time.Now()
+			retry := rateWindow(now, l.Period).Add(l.Period).Sub(now)
+
+			h.router.RequestDenied(r.Host, "rate")
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retry.Seconds()))))
+			http.Error(w, "too many requests", http.StatusTooManyRequests)
+			return false
+		}
+	}
+
+	return true
+}
+
+func (h *HTTP) proxyErrorHandler(target string, rule *Rule) func(w http.ResponseWriter, r *http.Request, err error) {
+	return func(w http.ResponseWriter, r *http.Request, err error) {
+		// the body was cut off by the limit in a request without a content length
+		if strings.Contains(err.Error(), "request body too large") {
+			h.router.RequestDenied(r.Host, "body")
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		if h.router.Waking(target) {
+			w.Header().Set("Refresh", strconv.Itoa(wakeRefresh))
+			w.Header().Set("Retry-After", strconv.Itoa(wakeRefresh))
+			servePage(w, rule, "waking", http.StatusServiceUnavailable, defaultWakingPage)
+			return
+		}
+
+		if ne, ok := err.(net.Error); (ok && ne.Timeout()) || err == context.DeadlineExceeded {
+			serveError(w, rule, http.StatusGatewayTimeout, err.Error())
+			return
+		}
+
+		serveError(w, rule, http.StatusBadGateway, err.Error())
+	}
 }
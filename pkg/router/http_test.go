@@ -3,18 +3,22 @@ package router_test
 import (
 	"context"
 	"crypto/tls"
+	"encoding/base64"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/convox/convox/pkg/common"
 	"github.com/convox/convox/pkg/router"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestHTTPNoHost(t *testing.T) {
@@ -260,11 +264,266 @@ func TestHTTPRequestWebsocket(t *testing.T) {
 	})
 }
 
+func TestHTTPRuleAccessAllow(t *testing.T) {
+	_, n, err := net.ParseCIDR("10.0.0.0/8")
+	require.NoError(t, err)
+
+	r := &testRuleRouter{rule: &router.Rule{Access: &router.RuleAccess{Allow: []*net.IPNet{n}}}}
+
+	testHTTP(t, r, func(h *router.HTTP) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "should not be reached", 500)
+		}))
+
+		r.target = s.URL
+
+		res, err := testRequest(h, "GET", "test.convox", nil, nil)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		require.Equal(t, 403, res.StatusCode)
+		require.Equal(t, []string{"allow"}, r.denied)
+	})
+}
+
+func TestHTTPRuleAccessAuth(t *testing.T) {
+	_, n, err := net.ParseCIDR("127.0.0.0/8")
+	require.NoError(t, err)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	r := &testRuleRouter{rule: &router.Rule{Access: &router.RuleAccess{Allow: []*net.IPNet{n}, Auth: map[string]string{"admin": string(hash)}}}}
+
+	testHTTP(t, r, func(h *router.HTTP) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, "valid")
+		}))
+
+		r.target = s.URL
+
+		res, err := testRequest(h, "GET", "test.convox", nil, nil)
+		require.NoError(t, err)
+		res.Body.Close()
+
+		require.Equal(t, 401, res.StatusCode)
+		require.Equal(t, `Basic realm="convox"`, res.Header.Get("WWW-Authenticate"))
+
+		res, err = testRequest(h, "GET", "test.convox", nil, http.Header{"Authorization": []string{"Basic " + base64.StdEncoding.EncodeToString([]byte("admin:wrong"))}})
+		require.NoError(t, err)
+		res.Body.Close()
+
+		require.Equal(t, 401, res.StatusCode)
+
+		res, err = testRequest(h, "GET", "test.convox", nil, http.Header{"Authorization": []string{"Basic " + base64.StdEncoding.EncodeToString([]byte("admin:secret"))}})
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		require.Equal(t, 200, res.StatusCode)
+		require.Equal(t, []string{"auth", "auth"}, r.denied)
+
+		data, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, []byte("valid"), data)
+	})
+}
+
+func TestHTTPRuleLimitsBody(t *testing.T) {
+	r := &testRuleRouter{rule: &router.Rule{Limits: &router.RuleLimits{Body: 5, Scope: "ns.web.3000"}}}
+
+	testHTTP(t, r, func(h *router.HTTP) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			fmt.Fprintf(w, "%s", data)
+		}))
+
+		r.target = s.URL
+
+		res, err := testRequest(h, "POST", "test.convox", strings.NewReader("small"), nil)
+		require.NoError(t, err)
+		res.Body.Close()
+
+		require.Equal(t, 200, res.StatusCode)
+
+		res, err = testRequest(h, "POST", "test.convox", strings.NewReader("too large"), nil)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		require.Equal(t, 413, res.StatusCode)
+		require.Equal(t, []string{"body"}, r.denied)
+	})
+}
+
+func TestHTTPRuleLimitsRate(t *testing.T) {
+	r := &testRuleRouter{rule: &router.Rule{Limits: &router.RuleLimits{Header: "X-Api-Key", Period: time.Minute, Rate: 2, Scope: "ns.web.3000"}}}
+
+	testHTTP(t, r, func(h *router.HTTP) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, "valid")
+		}))
+
+		r.target = s.URL
+
+		for i := 0; i < 2; i++ {
+			res, err := testRequest(h, "GET", "test.convox", nil, http.Header{"X-Api-Key": []string{"one"}})
+			require.NoError(t, err)
+			res.Body.Close()
+
+			require.Equal(t, 200, res.StatusCode)
+		}
+
+		res, err := testRequest(h, "GET", "test.convox", nil, http.Header{"X-Api-Key": []string{"one"}})
+		require.NoError(t, err)
+		res.Body.Close()
+
+		require.Equal(t, 429, res.StatusCode)
+		require.NotEmpty(t, res.Header.Get("Retry-After"))
+		require.Equal(t, []string{"rate"}, r.denied)
+
+		res, err = testRequest(h, "GET", "test.convox", nil, http.Header{"X-Api-Key": []string{"two"}})
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		require.Equal(t, 200, res.StatusCode)
+		require.Equal(t, map[string]int64{"ns.web.3000.header.one": 3, "ns.web.3000.header.two": 1}, r.rates)
+	})
+}
+
+func TestHTTPRuleMaintenance(t *testing.T) {
+	r := &testRuleRouter{rule: &router.Rule{Maintenance: true}}
+
+	testHTTP(t, r, func(h *router.HTTP) {
+		res, err := testRequest(h, "GET", "test.convox", nil, nil)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		require.Equal(t, 503, res.StatusCode)
+		require.Equal(t, "text/html; charset=utf-8", res.Header.Get("Content-Type"))
+
+		data, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Contains(t, string(data), "Down for maintenance")
+	})
+}
+
+func TestHTTPRuleMaintenanceCustom(t *testing.T) {
+	r := &testRuleRouter{rule: &router.Rule{Maintenance: true, Pages: map[string]string{"maintenance.html": "<p>back soon</p>"}}}
+
+	testHTTP(t, r, func(h *router.HTTP) {
+		res, err := testRequest(h, "GET", "test.convox", nil, nil)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		require.Equal(t, 503, res.StatusCode)
+
+		data, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, []byte("<p>back soon</p>"), data)
+	})
+}
+
+func TestHTTPRulePageError(t *testing.T) {
+	r := &testRuleRouter{rule: &router.Rule{Pages: map[string]string{"502.html": "<p>oops</p>"}}}
+
+	testHTTP(t, r, func(h *router.HTTP) {
+		res, err := testRequest(h, "GET", "test.convox", nil, nil)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		require.Equal(t, 502, res.StatusCode)
+		require.Equal(t, "text/html; charset=utf-8", res.Header.Get("Content-Type"))
+
+		data, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, []byte("<p>oops</p>"), data)
+	})
+}
+
+func TestHTTPRulePageWaking(t *testing.T) {
+	r := &testRuleRouter{rule: &router.Rule{}, waking: true}
+
+	testHTTP(t, r, func(h *router.HTTP) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		s.Close()
+
+		r.target = s.URL
+
+		res, err := testRequest(h, "GET", "test.convox", nil, nil)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		require.Equal(t, 503, res.StatusCode)
+		require.Equal(t, "5", res.Header.Get("Refresh"))
+
+		data, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Contains(t, string(data), "waking up")
+	})
+}
+
+func TestHTTPRuleRedirect(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/other", r.URL.Path)
+		fmt.Fprintf(w, "redirected")
+	}))
+	defer s.Close()
+
+	r := &testRuleRouter{rule: &router.Rule{Redirect: &router.RuleRedirect{Code: 302, To: s.URL + "/other"}}}
+
+	testHTTP(t, r, func(h *router.HTTP) {
+		res, err := testRequest(h, "GET", "test.convox", nil, nil)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		require.Equal(t, 200, res.StatusCode)
+		require.NotNil(t, res.Request.Response)
+		require.Equal(t, 302, res.Request.Response.StatusCode)
+		require.Equal(t, s.URL+"/other", res.Request.Response.Header.Get("Location"))
+
+		data, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, []byte("redirected"), data)
+	})
+}
+
+func TestHTTPRuleHeaders(t *testing.T) {
+	r := &testRuleRouter{
+		rule: &router.Rule{
+			RequestHeaders:  router.RuleHeaders{Add: map[string]string{"X-Request-Added": "yes"}, Remove: []string{"X-Request-Removed"}},
+			ResponseHeaders: router.RuleHeaders{Add: map[string]string{"X-Response-Added": "yes"}, Remove: []string{"X-Response-Removed"}},
+		},
+	}
+
+	testHTTP(t, r, func(h *router.HTTP) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "yes", r.Header.Get("X-Request-Added"))
+			require.Empty(t, r.Header.Get("X-Request-Removed"))
+			w.Header().Set("X-Response-Removed", "yes")
+			fmt.Fprintf(w, "valid")
+		}))
+
+		r.target = s.URL
+
+		res, err := testRequest(h, "GET", "test.convox", nil, http.Header{"X-Request-Removed": []string{"yes"}})
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		require.Equal(t, 200, res.StatusCode)
+		require.Equal(t, "yes", res.Header.Get("X-Response-Added"))
+		require.Empty(t, res.Header.Get("X-Response-Removed"))
+
+		data, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, []byte("valid"), data)
+	})
+}
+
 func generateSelfSignedCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
 	return common.CertificateSelfSigned(hello.ServerName)
 }
 
-func testHTTP(t *testing.T, r testHTTPRouter, fn func(h *router.HTTP)) {
+func testHTTP(t *testing.T, r router.HTTPRouter, fn func(h *router.HTTP)) {
 	ln, err := tls.Listen("tcp", "", &tls.Config{
 		GetCertificate: generateSelfSignedCertificate,
 	})
@@ -341,19 +600,78 @@ func testWebsocket(h *router.HTTP, host, path string) (*websocket.Conn, error) {
 
 type testHTTPRouter map[string]string
 
+func (r testHTTPRouter) RateIncrement(key string, window time.Duration) (int64, error) {
+	return 0, nil
+}
+
 func (r testHTTPRouter) RequestBegin(target string) error {
 	return nil
 }
 
+func (r testHTTPRouter) RequestDenied(host, reason string) error {
+	return nil
+}
+
 func (r testHTTPRouter) RequestEnd(target string) error {
 	return nil
 }
 
-func (r testHTTPRouter) Route(host string) (string, error) {
+func (r testHTTPRouter) Waking(target string) bool {
+	return false
+}
+
+func (r testHTTPRouter) Route(host, path string) (string, *router.Rule, error) {
 	target, ok := r[host]
 	if !ok {
-		return "", fmt.Errorf("no route")
+		return "", nil, fmt.Errorf("no route")
+	}
+
+	return target, nil, nil
+}
+
+type testRuleRouter struct {
+	denied []string
+	rates  map[string]int64
+	rule   *router.Rule
+	target string
+	waking bool
+}
+
+func (r *testRuleRouter) Waking(target string) bool {
+	return r.waking
+}
+
+func (r *testRuleRouter) RateIncrement(key string, window time.Duration) (int64, error) {
+	if r.rates == nil {
+		r.rates = map[string]int64{}
+	}
+
+	r.rates[key]++
+
+	return r.rates[key], nil
+}
+
+func (r *testRuleRouter) RequestBegin(target string) error {
+	return nil
+}
+
+func (r *testRuleRouter) RequestDenied(host, reason string) error {
+	r.denied = append(r.denied, reason)
+	return nil
+}
+
+func (r *testRuleRouter) RequestEnd(target string) error {
+	return nil
+}
+
+func (r *testRuleRouter) Route(host, path string) (string, *router.Rule, error) {
+	if r.rule != nil && (r.rule.Maintenance || (r.rule.Redirect != nil && r.rule.Redirect.To != "")) {
+		return "", r.rule, nil
+	}
+
+	if r.target == "" {
+		return "", r.rule, fmt.Errorf("no backends available")
 	}
 
-	return target, nil
+	return r.target, r.rule, nil
 }
@@ -0,0 +1,58 @@
+package router
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Metrics counts router events between flushes
+type Metrics struct {
+	counts map[string]int64
+	lock   sync.Mutex
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{counts: map[string]int64{}}
+}
+
+func (m *Metrics) Count(name string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.counts[name]++
+}
+
+// Flush returns the counts since the last flush and resets them
+func (m *Metrics) Flush() map[string]int64 {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	counts := m.counts
+
+	m.counts = map[string]int64{}
+
+	return counts
+}
+
+// String formats counts as log fields sorted by name
+func (m *Metrics) String() string {
+	counts := m.Flush()
+
+	names := []string{}
+
+	for name := range counts {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	fields := []string{}
+
+	for _, name := range names {
+		fields = append(fields, fmt.Sprintf("count#%s=%d", name, counts[name]))
+	}
+
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,56 @@
+package router
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+const (
+	wakeRefresh = 5
+)
+
+var defaultMaintenancePage = `<!DOCTYPE html>
+<html>
+<head><title>Down for maintenance</title></head>
+<body>
+<h1>Down for maintenance</h1>
+<p>This application is undergoing maintenance and will be back shortly.</p>
+</body>
+</html>
+`
+
+var defaultWakingPage = fmt.Sprintf(`<!DOCTYPE html>
+<html>
+<head><title>Starting up</title><meta http-equiv="refresh" content="%d"></head>
+<body>
+<h1>Starting up</h1>
+<p>This application is waking up, this page will refresh automatically.</p>
+</body>
+</html>
+`, wakeRefresh)
+
+// serveError writes the custom page for an error status if the app has one, or a plain error
+func serveError(w http.ResponseWriter, rule *Rule, code int, message string) {
+	if _, ok := rule.Page(strconv.Itoa(code)); ok {
+		servePage(w, rule, strconv.Itoa(code), code, "")
+		return
+	}
+
+	http.Error(w, message, code)
+}
+
+// servePage writes the custom html for a page if the app has one, or the fallback html
+func servePage(w http.ResponseWriter, rule *Rule, name string, code int, fallback string) {
+	html, ok := rule.Page(name)
+	if !ok {
+		html = fallback
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+
+	io.WriteString(w, html)
+}
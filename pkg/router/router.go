@@ -22,6 +22,8 @@ import (
 const (
 	idleTick    = 1 * time.Minute
 	idleTimeout = 60 * time.Minute
+	metricsTick = 1 * time.Minute
+	wakeTimeout = 5 * time.Minute
 )
 
 var (
@@ -37,7 +39,10 @@ type Router struct {
 	backend Backend
 	cache   autocert.Cache
 	certs   sync.Map
+	metrics *Metrics
+	rules   sync.Map
 	storage Storage
+	waking  sync.Map
 }
 
 type Server interface {
@@ -53,7 +58,8 @@ func New() (*Router, error) {
 	fmt.Printf("ns=router fn=new\n")
 
 	r := &Router{
-		certs: sync.Map{},
+		certs:   sync.Map{},
+		metrics: NewMetrics(),
 	}
 
 	switch os.Getenv("BACKEND") {
@@ -87,15 +93,19 @@ func New() (*Router, error) {
 
 	switch os.Getenv("STORAGE") {
 	case "dynamodb":
-		s, err := NewStorageDynamo(os.Getenv("ROUTER_HOSTS"), os.Getenv("ROUTER_TARGETS"))
+		s, err := NewStorageDynamo(os.Getenv("ROUTER_HOSTS"), os.Getenv("ROUTER_RATES"), os.Getenv("ROUTER_TARGETS"))
 		if err != nil {
 			return nil, err
 		}
 
 		r.storage = s
 	case "redis":
-		addr := os.Getenv("REDIS_ADDR")
-		fmt.Printf("addr: %+v\n", addr)
+		s, err := NewStorageRedis(os.Getenv("REDIS_ADDR"), os.Getenv("REDIS_AUTH"), os.Getenv("REDIS_SECURE") == "true")
+		if err != nil {
+			return nil, err
+		}
+
+		r.storage = s
 	default:
 		r.storage = NewStorageMemory()
 	}
@@ -140,6 +150,7 @@ func (r *Router) Serve() error {
 	go serve(ch, r.HTTPS)
 
 	go r.idleTicker()
+	go r.metricsTicker()
 
 	return <-ch
 }
@@ -152,6 +163,10 @@ func (r *Router) Shutdown(ctx context.Context) error {
 	return nil
 }
 
+func (r *Router) RateIncrement(key string, window time.Duration) (int64, error) {
+	return r.storage.RateIncrement(key, window)
+}
+
 func (r *Router) RequestBegin(target string) error {
 	fmt.Printf("ns=router at=request.begin target=%q\n", target)
 
@@ -168,32 +183,97 @@ func (r *Router) RequestBegin(target string) error {
 		if err := r.backend.IdleSet(target, false); err != nil {
 			return fmt.Errorf("could not unidle: %s", err)
 		}
+
+		r.waking.Store(target, time.Now().UTC())
 	}
 
 	return nil
 }
 
+func (r *Router) RequestDenied(host, reason string) error {
+	fmt.Printf("ns=router at=request.denied host=%q reason=%q\n", host, reason)
+
+	r.metrics.Count(fmt.Sprintf("router.request.denied.%s", reason))
+
+	return nil
+}
+
 func (r *Router) RequestEnd(target string) error {
 	fmt.Printf("ns=router at=request.end target=%q\n", target)
 
 	return r.storage.RequestEnd(target)
 }
 
-func (r *Router) Route(host string) (string, error) {
-	fmt.Printf("ns=router at=route host=%q\n", host)
+// Route returns the target and rule for a request, the most specific path prefix on a host wins.
+// The target is empty when the rule redirects the request or the app is in maintenance.
+func (r *Router) Route(host, path string) (string, *Rule, error) {
+	fmt.Printf("ns=router at=route host=%q path=%q\n", host, path)
+
+	// the most specific rule is returned with errors so that its pages can be served
+	var found *Rule
 
 	for _, vr := range validRoutes(host) {
-		ts, err := r.TargetList(vr)
-		if err != nil {
-			return "", fmt.Errorf("error reaching backend")
-		}
+		for _, rp := range routePaths(path) {
+			key := vr + rp
+
+			rule := r.rule(key)
+
+			if rule != nil && found == nil {
+				found = rule
+			}
+
+			if rule != nil && rule.Maintenance {
+				return "", rule, nil
+			}
+
+			if rule != nil && rule.Redirect != nil && rule.Redirect.To != "" {
+				return "", rule, nil
+			}
 
-		if len(ts) > 0 {
-			return ts[rand.Intn(len(ts))], nil
+			ts, err := r.TargetList(key)
+			if err != nil {
+				return "", found, fmt.Errorf("error reaching backend")
+			}
+
+			if len(ts) > 0 {
+				return ts[rand.Intn(len(ts))], rule, nil
+			}
 		}
 	}
 
-	return "", fmt.Errorf("no backends available")
+	return "", found, fmt.Errorf("no backends available")
+}
+
+// Waking returns true when a target has been unidled recently and may still be starting
+func (r *Router) Waking(target string) bool {
+	v, ok := r.waking.Load(target)
+	if !ok {
+		return false
+	}
+
+	if t, ok := v.(time.Time); ok && time.Since(t) < wakeTimeout {
+		return true
+	}
+
+	r.waking.Delete(target)
+
+	return false
+}
+
+func (r *Router) RuleRemove(host string) error {
+	fmt.Printf("ns=router at=rule.remove host=%q\n", host)
+
+	r.rules.Delete(host)
+
+	return nil
+}
+
+func (r *Router) RuleSet(host string, rule *Rule) error {
+	fmt.Printf("ns=router at=rule.set host=%q\n", host)
+
+	r.rules.Store(host, rule)
+
+	return nil
 }
 
 func (r *Router) TargetAdd(host, target string, idles bool) error {
@@ -231,6 +311,28 @@ func (r *Router) Upstream() (string, error) {
 	return fmt.Sprintf("%s:53", cc.Servers[0]), nil
 }
 
+func (r *Router) metricsTicker() {
+	for range time.Tick(metricsTick) {
+		if m := r.metrics.String(); m != "" {
+			fmt.Printf("ns=router at=metrics %s\n", m)
+		}
+	}
+}
+
+func (r *Router) rule(host string) *Rule {
+	v, ok := r.rules.Load(host)
+	if !ok {
+		return nil
+	}
+
+	rule, ok := v.(*Rule)
+	if !ok {
+		return nil
+	}
+
+	return rule
+}
+
 func (r *Router) generateCertificateAutocert(m *autocert.Manager) func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
 	return func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
 		if hello.ServerName == "" {
@@ -343,7 +445,7 @@ func (r *Router) setupHTTP() error {
 
 	r.HTTPS = https
 
-	r.HTTP = &http.Server{Addr: ":80", Handler: redirectHTTPS(https.ServeHTTP)}
+	r.HTTP = &http.Server{Addr: ":80", Handler: r.redirectHTTPS(https.ServeHTTP)}
 
 	return nil
 }
@@ -368,7 +470,7 @@ func (r *Router) setupHTTPAutocert() error {
 
 	r.HTTPS = https
 
-	r.HTTP = &http.Server{Addr: ":80", Handler: m.HTTPHandler(redirectHTTPS(https.ServeHTTP))}
+	r.HTTP = &http.Server{Addr: ":80", Handler: m.HTTPHandler(r.redirectHTTPS(https.ServeHTTP))}
 
 	return nil
 }
@@ -386,16 +488,23 @@ func parseTarget(target string) (string, string, bool) {
 	return "", "", false
 }
 
-func redirectHTTPS(fn http.HandlerFunc) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Header.Get("X-Forwarded-Proto") == "https" {
-			fn(w, r)
+// redirectHTTPS sends plain http requests to https using the redirect code of the route, if any
+func (r *Router) redirectHTTPS(fn http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("X-Forwarded-Proto") == "https" {
+			fn(w, req)
 			return
 		}
 
-		target := url.URL{Scheme: "https", Host: r.Host, Path: r.URL.Path, RawQuery: r.URL.RawQuery}
+		code := http.StatusMovedPermanently
+
+		if _, rule, err := r.Route(req.Host, req.URL.Path); err == nil && rule != nil && rule.Redirect != nil && rule.Redirect.Https {
+			code = rule.Redirect.Code
+		}
+
+		target := url.URL{Scheme: "https", Host: req.Host, Path: req.URL.Path, RawQuery: req.URL.RawQuery}
 
-		http.Redirect(w, r, target.String(), http.StatusMovedPermanently)
+		http.Redirect(w, req, target.String(), code)
 	}
 }
 
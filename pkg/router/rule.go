@@ -0,0 +1,345 @@
+package router
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	ae "k8s.io/api/extensions/v1beta1"
+	am "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// the credentials that have matched a bcrypt hash, keyed by the sha256 of hash:password
+var ruleAuthorized sync.Map
+
+// Rule changes how the requests for a host and path prefix are handled, it is read from the
+// annotations of the ingress that routes them
+type Rule struct {
+	Access          *RuleAccess
+	Limits          *RuleLimits
+	Maintenance     bool
+	Pages           map[string]string
+	Redirect        *RuleRedirect
+	RequestHeaders  RuleHeaders
+	ResponseHeaders RuleHeaders
+}
+
+// RuleAccess restricts requests to clients from the networks in Allow and, when Auth is not empty, to
+// clients with basic auth credentials that match the bcrypt hash stored for the user
+type RuleAccess struct {
+	Allow []*net.IPNet
+	Auth  map[string]string
+}
+
+// RuleLimits caps the size of request bodies in bytes and allows Rate requests per Period from each
+// client of a service, clients are identified by the value of Header when it is set or by ip
+type RuleLimits struct {
+	Body   int64
+	Header string
+	Period time.Duration
+	Rate   int64
+	Scope  string
+}
+
+type RuleHeaders struct {
+	Add    map[string]string
+	Remove []string
+}
+
+// RuleRedirect sends requests to another url, or only from http to https when To is empty
+type RuleRedirect struct {
+	Code  int
+	Https bool
+	To    string
+}
+
+// Allowed returns true when the request comes from an allowed network
+func (a RuleAccess) Allowed(r *http.Request) bool {
+	if len(a.Allow) == 0 {
+		return true
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	for _, n := range a.Allow {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Authorized returns true when the request has valid basic auth credentials
+func (a RuleAccess) Authorized(r *http.Request) bool {
+	if len(a.Auth) == 0 {
+		return true
+	}
+
+	user, password, ok := r.BasicAuth()
+	if !ok {
+		return false
+	}
+
+	hash, ok := a.Auth[user]
+	if !ok {
+		return false
+	}
+
+	// bcrypt is slow on purpose so credentials that have matched are remembered
+	key := sha256.Sum256([]byte(fmt.Sprintf("%s:%s", hash, password)))
+
+	if _, ok := ruleAuthorized.Load(key); ok {
+		return true
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		return false
+	}
+
+	ruleAuthorized.Store(key, true)
+
+	return true
+}
+
+// Client returns the key that identifies the client of a request for rate limits
+func (l RuleLimits) Client(r *http.Request) string {
+	if v := r.Header.Get(l.Header); l.Header != "" && v != "" {
+		return fmt.Sprintf("header.%s", v)
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return fmt.Sprintf("ip.%s", r.RemoteAddr)
+	}
+
+	return fmt.Sprintf("ip.%s", host)
+}
+
+// Page returns the custom html for a page, or false when the app has not set one
+func (r *Rule) Page(name string) (string, bool) {
+	if r == nil {
+		return "", false
+	}
+
+	html, ok := r.Pages[fmt.Sprintf("%s.html", name)]
+
+	return html, ok
+}
+
+func (h RuleHeaders) Apply(hs http.Header) {
+	for _, k := range h.Remove {
+		hs.Del(k)
+	}
+
+	for k, v := range h.Add {
+		hs.Set(k, v)
+	}
+}
+
+func parseRule(annotations map[string]string) (*Rule, error) {
+	r := &Rule{}
+	found := false
+
+	if annotations["convox.maintenance"] == "true" {
+		r.Maintenance = true
+		found = true
+	}
+
+	// the pages themselves are loaded from their config map by the controller
+	if annotations["convox.pages"] != "" {
+		found = true
+	}
+
+	if to, https := annotations["convox.redirect.to"], annotations["convox.redirect.https"]; to != "" || https == "true" {
+		rr := &RuleRedirect{Code: http.StatusMovedPermanently, Https: https == "true", To: to}
+
+		if v := annotations["convox.redirect.code"]; v != "" {
+			code, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid redirect code: %s", v)
+			}
+
+			rr.Code = code
+		}
+
+		r.Redirect = rr
+		found = true
+	}
+
+	for prefix, h := range map[string]*RuleHeaders{"convox.headers.request": &r.RequestHeaders, "convox.headers.response": &r.ResponseHeaders} {
+		if v := annotations[prefix+".add"]; v != "" {
+			if err := json.Unmarshal([]byte(v), &h.Add); err != nil {
+				return nil, fmt.Errorf("invalid headers: %s", v)
+			}
+
+			found = true
+		}
+
+		if v := annotations[prefix+".remove"]; v != "" {
+			h.Remove = strings.Split(v, ",")
+			found = true
+		}
+	}
+
+	if !found {
+		return nil, nil
+	}
+
+	return r, nil
+}
+
+// parseRuleAccess reads the access annotations for a service port of an ingress
+func parseRuleAccess(annotations map[string]string, service string, port int32) (*RuleAccess, error) {
+	prefix := fmt.Sprintf("convox.ingress.service.%s.%d", service, port)
+
+	allow, auth := annotations[prefix+".allow"], annotations[prefix+".auth"]
+
+	if allow == "" && auth == "" {
+		return nil, nil
+	}
+
+	a := &RuleAccess{Allow: []*net.IPNet{}, Auth: map[string]string{}}
+
+	for _, v := range strings.Split(allow, ",") {
+		if v == "" {
+			continue
+		}
+
+		if !strings.Contains(v, "/") {
+			if ip := net.ParseIP(v); ip != nil && ip.To4() == nil {
+				v += "/128"
+			} else {
+				v += "/32"
+			}
+		}
+
+		_, n, err := net.ParseCIDR(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allow: %s", v)
+		}
+
+		a.Allow = append(a.Allow, n)
+	}
+
+	for _, v := range strings.Split(auth, ",") {
+		if v == "" {
+			continue
+		}
+
+		parts := strings.SplitN(v, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid auth for user: %s", parts[0])
+		}
+
+		a.Auth[parts[0]] = parts[1]
+	}
+
+	return a, nil
+}
+
+// parseRuleLimits reads the limit annotations for a service port of an ingress
+func parseRuleLimits(meta am.ObjectMeta, service string, port int32) (*RuleLimits, error) {
+	prefix := fmt.Sprintf("convox.ingress.service.%s.%d.limit", service, port)
+
+	body, requests := meta.Annotations[prefix+".body"], meta.Annotations[prefix+".rate.requests"]
+
+	if body == "" && requests == "" {
+		return nil, nil
+	}
+
+	l := &RuleLimits{Scope: fmt.Sprintf("%s.%s.%d", meta.Namespace, service, port)}
+
+	if body != "" {
+		v, err := strconv.ParseInt(body, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid body limit: %s", body)
+		}
+
+		l.Body = v
+	}
+
+	if requests != "" {
+		v, err := strconv.ParseInt(requests, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit: %s", requests)
+		}
+
+		l.Rate = v
+
+		period := meta.Annotations[prefix+".rate.period"]
+
+		seconds, err := strconv.Atoi(period)
+		if err != nil || seconds < 1 {
+			return nil, fmt.Errorf("invalid rate period: %s", period)
+		}
+
+		l.Period = time.Duration(seconds) * time.Second
+
+		if by := meta.Annotations[prefix+".rate.by"]; strings.HasPrefix(by, "header:") {
+			l.Header = strings.TrimPrefix(by, "header:")
+		}
+	}
+
+	return l, nil
+}
+
+// pathRule returns the rule for a path of an ingress, the ingress rule with the access rules and
+// limits for the service behind the path
+func pathRule(rule *Rule, meta am.ObjectMeta, port ae.HTTPIngressPath) (*Rule, error) {
+	a, err := parseRuleAccess(meta.Annotations, port.Backend.ServiceName, port.Backend.ServicePort.IntVal)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := parseRuleLimits(meta, port.Backend.ServiceName, port.Backend.ServicePort.IntVal)
+	if err != nil {
+		return nil, err
+	}
+
+	if a == nil && l == nil {
+		return rule, nil
+	}
+
+	pr := Rule{}
+
+	if rule != nil {
+		pr = *rule
+	}
+
+	pr.Access = a
+	pr.Limits = l
+
+	return &pr, nil
+}
+
+// routePaths returns the path prefixes that can route a request path from the most specific to
+// the least, the root of a host is stored without a path
+func routePaths(path string) []string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	ps := []string{}
+
+	for i := len(parts); i > 0; i-- {
+		if p := strings.Join(parts[0:i], "/"); p != "" {
+			ps = append(ps, "/"+p)
+		}
+	}
+
+	return append(ps, "")
+}
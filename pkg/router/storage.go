@@ -3,6 +3,7 @@ package router
 import "time"
 
 type Storage interface {
+	RateIncrement(key string, window time.Duration) (int64, error)
 	RequestBegin(target string) error
 	RequestEnd(target string) error
 	Stale(cutoff time.Time) ([]string, error)
@@ -10,3 +11,8 @@ type Storage interface {
 	TargetList(host string) ([]string, error)
 	TargetRemove(host, target string) error
 }
+
+// rateWindow returns the start of the fixed window that contains t
+func rateWindow(t time.Time, window time.Duration) time.Time {
+	return t.UTC().Truncate(window)
+}
@@ -2,6 +2,7 @@ package router
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -13,11 +14,12 @@ import (
 type StorageDynamo struct {
 	ddb     *dynamodb.DynamoDB
 	hosts   string
+	rates   string
 	targets string
 }
 
-func NewStorageDynamo(hosts, targets string) (*StorageDynamo, error) {
-	fmt.Printf("ns=storage.dynamo at=new hosts=%s targets=%s\n", hosts, targets)
+func NewStorageDynamo(hosts, rates, targets string) (*StorageDynamo, error) {
+	fmt.Printf("ns=storage.dynamo at=new hosts=%s rates=%s targets=%s\n", hosts, rates, targets)
 
 	s, err := session.NewSession()
 	if err != nil {
@@ -27,6 +29,7 @@ func NewStorageDynamo(hosts, targets string) (*StorageDynamo, error) {
 	d := &StorageDynamo{
 		ddb:     dynamodb.New(s),
 		hosts:   hosts,
+		rates:   rates,
 		targets: targets,
 	}
 
@@ -66,6 +69,30 @@ func (s *StorageDynamo) IdleSet(target string, idle bool) error {
 	return nil
 }
 
+func (s *StorageDynamo) RateIncrement(key string, window time.Duration) (int64, error) {
+	w := rateWindow(time.Now(), window)
+
+	// expired counts are removed by the ttl on the table
+	expires := w.Add(2 * window).Unix()
+
+	res, err := s.ddb.UpdateItem(&dynamodb.UpdateItemInput{
+		ExpressionAttributeNames:  map[string]*string{"#count": aws.String("count"), "#expires": aws.String("expires")},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{":expires": {N: aws.String(fmt.Sprintf("%d", expires))}, ":n": {N: aws.String("1")}},
+		Key:                       map[string]*dynamodb.AttributeValue{"key": {S: aws.String(fmt.Sprintf("%s.%d", key, w.Unix()))}},
+		ReturnValues:              aws.String("UPDATED_NEW"),
+		TableName:                 aws.String(s.rates),
+		UpdateExpression:          aws.String("SET #expires = :expires ADD #count :n"),
+	})
+	if err != nil {
+		return 0, err
+	}
+	if res.Attributes == nil || res.Attributes["count"] == nil || res.Attributes["count"].N == nil {
+		return 0, fmt.Errorf("invalid rate count")
+	}
+
+	return strconv.ParseInt(*res.Attributes["count"].N, 10, 64)
+}
+
 func (s *StorageDynamo) RequestBegin(target string) error {
 	fmt.Printf("ns=storage.dynamo at=request.begin target=%q\n", target)
 
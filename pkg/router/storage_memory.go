@@ -6,20 +6,34 @@ import (
 	"time"
 )
 
-type StorageMemory struct {
-	activity activityTracker
-	idle     sync.Map
-	idles    sync.Map
-	routes   sync.Map
+const (
+	rateSweepInterval = 1 * time.Minute
+)
 
+type StorageMemory struct {
+	activity  activityTracker
+	idle      sync.Map
+	idles     sync.Map
+	rates     map[string]rateCount
+	rateSwept time.Time
+	routes    sync.Map
+
+	rateLock   sync.Mutex
 	targetLock sync.Mutex
 }
 
+type rateCount struct {
+	count  int64
+	window time.Time
+	until  time.Time
+}
+
 func NewStorageMemory() *StorageMemory {
 	fmt.Printf("ns=storage.memory at=new\n")
 
 	return &StorageMemory{
 		idle:   sync.Map{},
+		rates:  map[string]rateCount{},
 		routes: sync.Map{},
 	}
 }
@@ -48,6 +62,37 @@ func (s *StorageMemory) IdleSet(target string, idle bool) error {
 	return nil
 }
 
+func (s *StorageMemory) RateIncrement(key string, window time.Duration) (int64, error) {
+	s.rateLock.Lock()
+	defer s.rateLock.Unlock()
+
+	now := time.Now().UTC()
+	w := rateWindow(now, window)
+
+	// drop the counts of windows that have ended
+	if now.Sub(s.rateSwept) >= rateSweepInterval {
+		for k, v := range s.rates {
+			if v.until.Before(now) {
+				delete(s.rates, k)
+			}
+		}
+
+		s.rateSwept = now
+	}
+
+	rc, ok := s.rates[key]
+
+	if !ok || !rc.window.Equal(w) {
+		rc = rateCount{window: w, until: w.Add(window)}
+	}
+
+	rc.count++
+
+	s.rates[key] = rc
+
+	return rc.count, nil
+}
+
 func (s *StorageMemory) RequestBegin(target string) error {
 	fmt.Printf("ns=storage.memory at=request.begin target=%q\n", target)
 
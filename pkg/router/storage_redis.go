@@ -0,0 +1,61 @@
+package router
+
+import (
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// StorageRedis shares rate limit counts between router replicas, targets and their activity are
+// kept in memory as every replica watches the same ingresses
+type StorageRedis struct {
+	*StorageMemory
+	redis *redis.Client
+}
+
+func NewStorageRedis(addr, password string, secure bool) (*StorageRedis, error) {
+	fmt.Printf("ns=storage.redis at=new addr=%s\n", addr)
+
+	opts := &redis.Options{
+		Addr:     addr,
+		Password: password,
+	}
+
+	if secure {
+		opts.TLSConfig = &tls.Config{}
+	}
+
+	rc := redis.NewClient(opts)
+
+	if _, err := rc.Ping().Result(); err != nil {
+		return nil, err
+	}
+
+	s := &StorageRedis{
+		StorageMemory: NewStorageMemory(),
+		redis:         rc,
+	}
+
+	return s, nil
+}
+
+func (s *StorageRedis) RateIncrement(key string, window time.Duration) (int64, error) {
+	w := rateWindow(time.Now(), window)
+
+	rk := fmt.Sprintf("router.rate.%s.%d", key, w.Unix())
+
+	var incr *redis.IntCmd
+
+	_, err := s.redis.TxPipelined(func(p redis.Pipeliner) error {
+		incr = p.Incr(rk)
+		p.ExpireAt(rk, w.Add(2*window))
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
@@ -0,0 +1,91 @@
+package router_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/convox/convox/pkg/router"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageMemoryRateIncrement(t *testing.T) {
+	testStorageRateIncrement(t, router.NewStorageMemory())
+}
+
+func TestStorageMemoryStale(t *testing.T) {
+	testStorageStale(t, router.NewStorageMemory())
+}
+
+func TestStorageRedisRateIncrement(t *testing.T) {
+	testStorageRateIncrement(t, testStorageRedis(t))
+}
+
+func TestStorageRedisStale(t *testing.T) {
+	testStorageStale(t, testStorageRedis(t))
+}
+
+func testStorageRateIncrement(t *testing.T, s router.Storage) {
+	window := 1 * time.Second
+
+	// start at the beginning of a window so that the counts below land in the same one
+	time.Sleep(time.Until(time.Now().Truncate(window).Add(window)))
+
+	key := time.Now().Format("rate.20060102150405.000000000")
+
+	for i := int64(1); i <= 3; i++ {
+		n, err := s.RateIncrement(key, window)
+		require.NoError(t, err)
+		require.Equal(t, i, n)
+	}
+
+	n, err := s.RateIncrement(key+".other", window)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	time.Sleep(window)
+
+	n, err = s.RateIncrement(key, window)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+}
+
+func testStorageStale(t *testing.T, s router.Storage) {
+	require.NoError(t, s.TargetAdd("app1.example.org", "https://web.app1.svc:443", true))
+	require.NoError(t, s.TargetAdd("app1.example.org", "https://api.app1.svc:443", true))
+	require.NoError(t, s.TargetAdd("app2.example.org", "https://web.app2.svc:443", false))
+
+	ts, err := s.Stale(time.Now().Add(1 * time.Minute))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"https://web.app1.svc:443", "https://api.app1.svc:443"}, ts)
+
+	require.NoError(t, s.RequestBegin("https://web.app1.svc:443"))
+
+	ts, err = s.Stale(time.Now().Add(1 * time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, []string{"https://api.app1.svc:443"}, ts)
+
+	require.NoError(t, s.RequestEnd("https://web.app1.svc:443"))
+
+	ts, err = s.Stale(time.Now().Add(-1 * time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, []string{}, ts)
+
+	require.NoError(t, s.TargetRemove("app1.example.org", "https://api.app1.svc:443"))
+
+	ts, err = s.Stale(time.Now().Add(1 * time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, []string{"https://web.app1.svc:443"}, ts)
+}
+
+func testStorageRedis(t *testing.T) *router.StorageRedis {
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		t.Skip("REDIS_ADDR is not set")
+	}
+
+	s, err := router.NewStorageRedis(addr, os.Getenv("REDIS_AUTH"), os.Getenv("REDIS_SECURE") == "true")
+	require.NoError(t, err)
+
+	return s
+}
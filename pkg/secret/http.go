@@ -0,0 +1,91 @@
+package secret
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HTTPResolver reads secrets from an http endpoint such as the vault kv api.
+// The response must be a json object containing the key at the top level or
+// under "data" or "data.data"
+type HTTPResolver struct {
+	Client   *http.Client
+	Endpoint string
+	Token    string
+}
+
+func NewHTTPResolver(endpoint, token string) *HTTPResolver {
+	return &HTTPResolver{
+		Client:   &http.Client{Timeout: 10 * time.Second},
+		Endpoint: strings.TrimSuffix(endpoint, "/"),
+		Token:    token,
+	}
+}
+
+func (h *HTTPResolver) Resolve(path, key string) (string, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/%s", h.Endpoint, strings.TrimPrefix(path, "/")), nil)
+	if err != nil {
+		return "", err
+	}
+
+	if h.Token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", h.Token))
+		req.Header.Set("X-Vault-Token", h.Token)
+	}
+
+	res, err := h.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if res.StatusCode != 200 {
+		return "", fmt.Errorf("response status %d", res.StatusCode)
+	}
+
+	var body map[string]interface{}
+
+	if err := json.Unmarshal(data, &body); err != nil {
+		return "", fmt.Errorf("invalid response: %s", err)
+	}
+
+	for _, m := range httpResolverMaps(body) {
+		if v, ok := m[key]; ok {
+			switch t := v.(type) {
+			case string:
+				return t, nil
+			default:
+				data, err := json.Marshal(t)
+				if err != nil {
+					return "", err
+				}
+				return string(data), nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("key not found: %s", key)
+}
+
+// httpResolverMaps returns the places a key may live in order of preference
+func httpResolverMaps(body map[string]interface{}) []map[string]interface{} {
+	ms := []map[string]interface{}{}
+
+	if d, ok := body["data"].(map[string]interface{}); ok {
+		if dd, ok := d["data"].(map[string]interface{}); ok {
+			ms = append(ms, dd)
+		}
+		ms = append(ms, d)
+	}
+
+	return append(ms, body)
+}
@@ -0,0 +1,122 @@
+package secret
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/convox/convox/pkg/structs"
+)
+
+const Prefix = "secret://"
+
+// Reference points at a value held outside of convox, e.g. secret://vault/db/prod#password
+type Reference struct {
+	Resolver string
+	Path     string
+	Key      string
+}
+
+// Resolver fetches the value of a single key at a path
+type Resolver interface {
+	Resolve(path, key string) (string, error)
+}
+
+// Resolvers maps the resolver name in a reference to its implementation
+type Resolvers map[string]Resolver
+
+// IsReference returns true if the value is a secret reference
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, Prefix)
+}
+
+func Parse(value string) (*Reference, error) {
+	if !IsReference(value) {
+		return nil, fmt.Errorf("not a secret reference: %s", value)
+	}
+
+	rest := strings.TrimPrefix(value, Prefix)
+
+	parts := strings.SplitN(rest, "/", 2)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid secret reference: %s", value)
+	}
+
+	pk := strings.SplitN(parts[1], "#", 2)
+	if len(pk) < 2 || pk[0] == "" || pk[1] == "" {
+		return nil, fmt.Errorf("secret reference requires a #key: %s", value)
+	}
+
+	r := &Reference{
+		Resolver: parts[0],
+		Path:     pk[0],
+		Key:      pk[1],
+	}
+
+	return r, nil
+}
+
+func (r Reference) String() string {
+	return fmt.Sprintf("%s%s/%s#%s", Prefix, r.Resolver, r.Path, r.Key)
+}
+
+// Digest returns a hash of a resolved value that can be stored without exposing the value
+func Digest(value string) string {
+	hash := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(hash[:])
+}
+
+// References returns the names of the variables in env that are secret references
+func References(env structs.Environment) []string {
+	names := []string{}
+
+	for k, v := range env {
+		if IsReference(v) {
+			names = append(names, k)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func (rs Resolvers) Resolve(value string) (string, error) {
+	ref, err := Parse(value)
+	if err != nil {
+		return "", err
+	}
+
+	r, ok := rs[ref.Resolver]
+	if !ok {
+		return "", fmt.Errorf("unknown secret resolver: %s", ref.Resolver)
+	}
+
+	v, err := r.Resolve(ref.Path, ref.Key)
+	if err != nil {
+		return "", fmt.Errorf("could not resolve %s: %s", ref, err)
+	}
+
+	return v, nil
+}
+
+// Environment returns a copy of env with every secret reference replaced by its value
+func (rs Resolvers) Environment(env structs.Environment) (structs.Environment, error) {
+	re := structs.Environment{}
+
+	for k, v := range env {
+		if IsReference(v) {
+			rv, err := rs.Resolve(v)
+			if err != nil {
+				return nil, err
+			}
+			v = rv
+		}
+
+		re[k] = v
+	}
+
+	return re, nil
+}
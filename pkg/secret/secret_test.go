@@ -0,0 +1,126 @@
+package secret_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/convox/convox/pkg/secret"
+	"github.com/convox/convox/pkg/structs"
+	"github.com/stretchr/testify/require"
+)
+
+type testResolver map[string]string
+
+func (r testResolver) Resolve(path, key string) (string, error) {
+	v, ok := r[fmt.Sprintf("%s#%s", path, key)]
+	if !ok {
+		return "", fmt.Errorf("not found")
+	}
+	return v, nil
+}
+
+func TestParse(t *testing.T) {
+	r, err := secret.Parse("secret://vault/db/prod#password")
+	require.NoError(t, err)
+	require.Equal(t, &secret.Reference{Resolver: "vault", Path: "db/prod", Key: "password"}, r)
+	require.Equal(t, "secret://vault/db/prod#password", r.String())
+
+	r, err = secret.Parse("secret://k8s/shared/database#url")
+	require.NoError(t, err)
+	require.Equal(t, &secret.Reference{Resolver: "k8s", Path: "shared/database", Key: "url"}, r)
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := map[string]string{
+		"postgres://db":          "not a secret reference: postgres://db",
+		"secret://vault":         "invalid secret reference: secret://vault",
+		"secret:///db#password":  "invalid secret reference: secret:///db#password",
+		"secret://vault/db":      "secret reference requires a #key: secret://vault/db",
+		"secret://vault/db#":     "secret reference requires a #key: secret://vault/db#",
+		"secret://vault/#secret": "secret reference requires a #key: secret://vault/#secret",
+	}
+
+	for value, message := range tests {
+		_, err := secret.Parse(value)
+		require.EqualError(t, err, message, value)
+	}
+}
+
+func TestResolversEnvironment(t *testing.T) {
+	rs := secret.Resolvers{
+		"test": testResolver{"db/prod#password": "hunter2"},
+	}
+
+	env := structs.Environment{
+		"DB_PASSWORD": "secret://test/db/prod#password",
+		"FOO":         "bar",
+	}
+
+	require.Equal(t, []string{"DB_PASSWORD"}, secret.References(env))
+
+	re, err := rs.Environment(env)
+	require.NoError(t, err)
+	require.Equal(t, structs.Environment{"DB_PASSWORD": "hunter2", "FOO": "bar"}, re)
+	require.Equal(t, "secret://test/db/prod#password", env["DB_PASSWORD"])
+}
+
+func TestResolversErrors(t *testing.T) {
+	rs := secret.Resolvers{
+		"test": testResolver{},
+	}
+
+	_, err := rs.Resolve("secret://other/db#password")
+	require.EqualError(t, err, "unknown secret resolver: other")
+
+	_, err = rs.Environment(structs.Environment{"FOO": "secret://test/db#password"})
+	require.EqualError(t, err, "could not resolve secret://test/db#password: not found")
+}
+
+func TestHTTPResolver(t *testing.T) {
+	ht := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token1" {
+			http.Error(w, "forbidden", 403)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/secret/data/db":
+			fmt.Fprintf(w, `{"data":{"data":{"password":"hunter2","port":5432},"metadata":{"version":3}}}`)
+		case "/v1/secret/plain":
+			fmt.Fprintf(w, `{"api_key":"abc"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ht.Close()
+
+	h := secret.NewHTTPResolver(ht.URL+"/v1/", "token1")
+
+	v, err := h.Resolve("secret/data/db", "password")
+	require.NoError(t, err)
+	require.Equal(t, "hunter2", v)
+
+	v, err = h.Resolve("secret/data/db", "port")
+	require.NoError(t, err)
+	require.Equal(t, "5432", v)
+
+	v, err = h.Resolve("secret/plain", "api_key")
+	require.NoError(t, err)
+	require.Equal(t, "abc", v)
+
+	_, err = h.Resolve("secret/plain", "missing")
+	require.EqualError(t, err, "key not found: missing")
+
+	_, err = h.Resolve("secret/other", "key")
+	require.EqualError(t, err, "response status 404")
+
+	_, err = secret.NewHTTPResolver(ht.URL+"/v1", "bad").Resolve("secret/plain", "api_key")
+	require.EqualError(t, err, "response status 403")
+}
+
+func TestDigest(t *testing.T) {
+	require.Equal(t, secret.Digest("a"), secret.Digest("a"))
+	require.NotEqual(t, secret.Digest("a"), secret.Digest("b"))
+}
@@ -1,7 +1,6 @@
 package start
 
 import (
-	"archive/tar"
 	"bufio"
 	"bytes"
 	"context"
@@ -219,87 +218,6 @@ func (s *Start) Start2(ctx context.Context, w io.Writer, opts Options2) error {
 	return nil
 }
 
-func (opts Options2) handleAdds(pid, remote string, adds []changes.Change) error {
-	if len(adds) == 0 {
-		return nil
-	}
-
-	if !filepath.IsAbs(remote) {
-		var buf bytes.Buffer
-
-		if _, err := opts.Provider.ProcessExec(opts.App, pid, "pwd", &buf, structs.ProcessExecOptions{}); err != nil {
-			return errors.WithStack(fmt.Errorf("%s pwd: %s", pid, err))
-		}
-
-		wd := strings.TrimSpace(buf.String())
-
-		remote = filepath.Join(wd, remote)
-	}
-
-	rp, wp := io.Pipe()
-
-	ch := make(chan error)
-
-	go func() {
-		ch <- opts.Provider.FilesUpload(opts.App, pid, rp)
-		close(ch)
-	}()
-
-	tw := tar.NewWriter(wp)
-
-	for _, add := range adds {
-		local := filepath.Join(add.Base, add.Path)
-
-		stat, err := os.Stat(local)
-		if err != nil {
-			// skip transient files like '.git/.COMMIT_EDITMSG.swp'
-			if os.IsNotExist(err) {
-				continue
-			}
-
-			return errors.WithStack(err)
-		}
-
-		tw.WriteHeader(&tar.Header{
-			Name:    filepath.Join(remote, add.Path),
-			Mode:    int64(stat.Mode()),
-			Size:    stat.Size(),
-			ModTime: stat.ModTime(),
-		})
-
-		fd, err := os.Open(local)
-		if err != nil {
-			return errors.WithStack(err)
-		}
-
-		defer fd.Close()
-
-		if _, err := io.Copy(tw, fd); err != nil {
-			return errors.WithStack(err)
-		}
-
-		fd.Close()
-	}
-
-	if err := tw.Close(); err != nil {
-		return errors.WithStack(err)
-	}
-
-	if err := wp.Close(); err != nil {
-		return errors.WithStack(err)
-	}
-
-	return <-ch
-}
-
-func (opts Options2) handleRemoves(pid string, removes []changes.Change) error {
-	if len(removes) == 0 {
-		return nil
-	}
-
-	return opts.Provider.FilesDelete(opts.App, pid, changes.Files(removes))
-}
-
 func (opts Options2) healthCheck(ctx context.Context, pw prefix.Writer, s manifest.Service, errch chan error, wg *sync.WaitGroup) {
 	rss, err := opts.Provider.ServiceList(opts.App)
 	if err != nil {
@@ -503,7 +421,7 @@ func (opts Options2) watchPath(ctx context.Context, pw prefix.Writer, service, r
 					}
 				}
 
-				if err := opts.handleAdds(ps.Id, bs.Remote, adds); err != nil {
+				if err := SyncAdds(opts.Provider, opts.App, ps.Id, bs.Remote, adds); err != nil {
 					pw.Writef("convox", "sync add error: %s\n", err)
 				}
 
@@ -516,7 +434,7 @@ func (opts Options2) watchPath(ctx context.Context, pw prefix.Writer, service, r
 					}
 				}
 
-				if err := opts.handleRemoves(ps.Id, removes); err != nil {
+				if err := SyncRemoves(opts.Provider, opts.App, ps.Id, removes); err != nil {
 					pw.Writef("convox", "sync remove error: %s\n", err)
 				}
 			}
@@ -0,0 +1,99 @@
+package start
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/convox/changes"
+	"github.com/convox/convox/pkg/structs"
+	"github.com/pkg/errors"
+)
+
+// SyncAdds uploads added files to remote on a process, a relative remote is
+// resolved against the working directory of the process
+func SyncAdds(p structs.Provider, app, pid, remote string, adds []changes.Change) error {
+	if len(adds) == 0 {
+		return nil
+	}
+
+	if !filepath.IsAbs(remote) {
+		var buf bytes.Buffer
+
+		if _, err := p.ProcessExec(app, pid, "pwd", &buf, structs.ProcessExecOptions{}); err != nil {
+			return errors.WithStack(fmt.Errorf("%s pwd: %s", pid, err))
+		}
+
+		wd := strings.TrimSpace(buf.String())
+
+		remote = filepath.Join(wd, remote)
+	}
+
+	rp, wp := io.Pipe()
+
+	ch := make(chan error)
+
+	go func() {
+		ch <- p.FilesUpload(app, pid, rp)
+		close(ch)
+	}()
+
+	tw := tar.NewWriter(wp)
+
+	for _, add := range adds {
+		local := filepath.Join(add.Base, add.Path)
+
+		stat, err := os.Stat(local)
+		if err != nil {
+			// skip transient files like '.git/.COMMIT_EDITMSG.swp'
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			return errors.WithStack(err)
+		}
+
+		tw.WriteHeader(&tar.Header{
+			Name:    filepath.Join(remote, add.Path),
+			Mode:    int64(stat.Mode()),
+			Size:    stat.Size(),
+			ModTime: stat.ModTime(),
+		})
+
+		fd, err := os.Open(local)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		defer fd.Close()
+
+		if _, err := io.Copy(tw, fd); err != nil {
+			return errors.WithStack(err)
+		}
+
+		fd.Close()
+	}
+
+	if err := tw.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := wp.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return <-ch
+}
+
+// SyncRemoves deletes removed files from a process
+func SyncRemoves(p structs.Provider, app, pid string, removes []changes.Change) error {
+	if len(removes) == 0 {
+		return nil
+	}
+
+	return p.FilesDelete(app, pid, changes.Files(removes))
+}
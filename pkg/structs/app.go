@@ -1,29 +1,61 @@
 package structs
 
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// AppTagExpires holds the RFC3339 time after which a preview app is deleted
+	AppTagExpires = "Expires"
+)
+
 type App struct {
-	Generation string `json:"generation,omitempty"`
-	Locked     bool   `json:"locked"`
-	Name       string `json:"name"`
-	Release    string `json:"release"`
-	Router     string `json:"router"`
-	Status     string `json:"status"`
+	Generation  string `json:"generation,omitempty"`
+	Locked      bool   `json:"locked"`
+	Maintenance bool   `json:"maintenance,omitempty"`
+	Name        string `json:"name"`
+	Release     string `json:"release"`
+	Router      string `json:"router"`
+	Status      string `json:"status"`
 
 	Outputs    map[string]string `json:"-"`
 	Parameters map[string]string `json:"parameters"`
-	Tags       map[string]string `json:"-"`
+	Tags       map[string]string `json:"tags,omitempty"`
 }
 
 type Apps []App
 
 type AppCreateOptions struct {
-	Generation *string `default:"2" flag:"generation,g" param:"generation"`
+	CloneFrom  *string        `flag:"clone-from" param:"clone-from"`
+	Generation *string        `default:"2" flag:"generation,g" param:"generation"`
+	Ttl        *time.Duration `flag:"ttl" param:"ttl"`
 }
 
 type AppUpdateOptions struct {
-	Lock       *bool             `param:"lock"`
-	Parameters map[string]string `param:"parameters"`
+	Lock        *bool             `param:"lock"`
+	Maintenance *bool             `param:"maintenance"`
+	Parameters  map[string]string `param:"parameters"`
+}
+
+// AppPages are the custom html pages the router can serve for an app
+var AppPages = []string{"502", "504", "maintenance", "waking"}
+
+// AppPageKey returns the object key for a custom page of an app
+func AppPageKey(name string) string {
+	return fmt.Sprintf("convox/pages/%s.html", name)
 }
 
 func (a Apps) Less(i, j int) bool {
 	return a[i].Name < a[j].Name
 }
+
+// Expires returns the time a preview app will be deleted, or a zero time if it does not expire
+func (a App) Expires() time.Time {
+	t, err := time.Parse(time.RFC3339, a.Tags[AppTagExpires])
+	if err != nil {
+		return time.Time{}
+	}
+
+	return t
+}
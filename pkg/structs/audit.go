@@ -0,0 +1,57 @@
+package structs
+
+import "time"
+
+type AuditEntry struct {
+	Id       string            `json:"id"`
+	App      string            `json:"app,omitempty"`
+	Code     int               `json:"code"`
+	Duration time.Duration     `json:"duration"`
+	Identity string            `json:"identity"`
+	Method   string            `json:"method"`
+	Params   map[string]string `json:"params,omitempty"`
+	Path     string            `json:"path"`
+	Route    string            `json:"route"`
+	Time     time.Time         `json:"time"`
+	Token    string            `json:"token,omitempty"`
+}
+
+type AuditEntries []AuditEntry
+
+type AuditListOptions struct {
+	App      *string        `flag:"app" query:"app"`
+	Identity *string        `flag:"identity" query:"identity"`
+	Limit    *int           `flag:"limit,l" query:"limit"`
+	Route    *string        `flag:"route" query:"route"`
+	Since    *time.Duration `flag:"since" query:"since"`
+}
+
+func NewAuditEntry() *AuditEntry {
+	return &AuditEntry{
+		Id:   id("A", 10),
+		Time: time.Now().UTC(),
+	}
+}
+
+// Matches returns true if the entry passes the filters in opts
+func (e *AuditEntry) Matches(opts AuditListOptions) bool {
+	if opts.App != nil && *opts.App != e.App {
+		return false
+	}
+
+	if opts.Identity != nil && *opts.Identity != e.Identity {
+		return false
+	}
+
+	if opts.Route != nil && *opts.Route != e.Route {
+		return false
+	}
+
+	if opts.Since != nil && e.Time.Before(time.Now().UTC().Add(-1**opts.Since)) {
+		return false
+	}
+
+	return true
+}
+
+func (es AuditEntries) Less(i, j int) bool { return es[i].Time.After(es[j].Time) }
@@ -3,6 +3,7 @@ package structs
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
@@ -49,6 +50,72 @@ func (e Environment) String() string {
 	return strings.Join(lines, "\n")
 }
 
+const (
+	EnvironmentSecretCurrent = "current"
+	EnvironmentSecretError   = "error"
+	EnvironmentSecretPending = "pending"
+	EnvironmentSecretStale   = "stale"
+)
+
+// EnvironmentSecret is an env var whose value is a secret:// reference resolved at promote time.
+// It is stale when the referenced value has changed since the active release was promoted.
+type EnvironmentSecret struct {
+	Name      string `json:"name"`
+	Reference string `json:"reference"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+}
+
+type EnvironmentSecrets []EnvironmentSecret
+
+// EnvironmentMeta holds optional metadata for environment variables keyed by name.
+type EnvironmentMeta map[string]EnvironmentKeyMeta
+
+type EnvironmentKeyMeta struct {
+	Description string `json:"description,omitempty"`
+	Sensitive   bool   `json:"sensitive,omitempty"`
+}
+
+func NewEnvironmentMeta(data []byte) (EnvironmentMeta, error) {
+	m := EnvironmentMeta{}
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		return m, nil
+	}
+
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Sensitive returns true if the variable is marked as sensitive
+func (m EnvironmentMeta) Sensitive(key string) bool {
+	return m[key].Sensitive
+}
+
+func (m EnvironmentMeta) String() string {
+	mm := EnvironmentMeta{}
+
+	for k, v := range m {
+		if v != (EnvironmentKeyMeta{}) {
+			mm[k] = v
+		}
+	}
+
+	if len(mm) == 0 {
+		return ""
+	}
+
+	data, err := json.Marshal(mm)
+	if err != nil {
+		return ""
+	}
+
+	return string(data)
+}
+
 // SortedNames returns a slice of environment variables sorted by name.
 // func (e Environment) SortedNames() []string {
 //   names := []string{}
@@ -0,0 +1,10 @@
+package structs
+
+type FileChecksum struct {
+	Checksum string `json:"checksum"`
+	Path     string `json:"path"`
+}
+
+type FileChecksums []FileChecksum
+
+func (fs FileChecksums) Less(i, j int) bool { return fs[i].Path < fs[j].Path }
@@ -168,6 +168,43 @@ func (_m *MockProvider) AppUpdate(name string, opts AppUpdateOptions) error {
 	return r0
 }
 
+// AuditList provides a mock function with given fields: opts
+func (_m *MockProvider) AuditList(opts AuditListOptions) (AuditEntries, error) {
+	ret := _m.Called(opts)
+
+	var r0 AuditEntries
+	if rf, ok := ret.Get(0).(func(AuditListOptions) AuditEntries); ok {
+		r0 = rf(opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(AuditEntries)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(AuditListOptions) error); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuditRecord provides a mock function with given fields: entry
+func (_m *MockProvider) AuditRecord(entry AuditEntry) error {
+	ret := _m.Called(entry)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(AuditEntry) error); ok {
+		r0 = rf(entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // BalancerList provides a mock function with given fields: app
 func (_m *MockProvider) BalancerList(app string) (Balancers, error) {
 	ret := _m.Called(app)
@@ -463,6 +500,29 @@ func (_m *MockProvider) CertificateList() (Certificates, error) {
 	return r0, r1
 }
 
+// EnvironmentSecretList provides a mock function with given fields: app
+func (_m *MockProvider) EnvironmentSecretList(app string) (EnvironmentSecrets, error) {
+	ret := _m.Called(app)
+
+	var r0 EnvironmentSecrets
+	if rf, ok := ret.Get(0).(func(string) EnvironmentSecrets); ok {
+		r0 = rf(app)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(EnvironmentSecrets)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(app)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // EventSend provides a mock function with given fields: action, opts
 func (_m *MockProvider) EventSend(action string, opts EventSendOptions) error {
 	ret := _m.Called(action, opts)
@@ -477,6 +537,29 @@ func (_m *MockProvider) EventSend(action string, opts EventSendOptions) error {
 	return r0
 }
 
+// FilesChecksums provides a mock function with given fields: app, pid, path
+func (_m *MockProvider) FilesChecksums(app string, pid string, path string) (FileChecksums, error) {
+	ret := _m.Called(app, pid, path)
+
+	var r0 FileChecksums
+	if rf, ok := ret.Get(0).(func(string, string, string) FileChecksums); ok {
+		r0 = rf(app, pid, path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(FileChecksums)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(app, pid, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FilesDelete provides a mock function with given fields: app, pid, files
 func (_m *MockProvider) FilesDelete(app string, pid string, files []string) error {
 	ret := _m.Called(app, pid, files)
@@ -718,6 +801,27 @@ func (_m *MockProvider) ObjectStore(app string, key string, r io.Reader, opts Ob
 	return r0, r1
 }
 
+// ProcessDebug provides a mock function with given fields: app, pid, rw, opts
+func (_m *MockProvider) ProcessDebug(app string, pid string, rw io.ReadWriter, opts ProcessDebugOptions) (int, error) {
+	ret := _m.Called(app, pid, rw, opts)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(string, string, io.ReadWriter, ProcessDebugOptions) int); ok {
+		r0 = rf(app, pid, rw, opts)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, io.ReadWriter, ProcessDebugOptions) error); ok {
+		r1 = rf(app, pid, rw, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ProcessExec provides a mock function with given fields: app, pid, command, rw, opts
 func (_m *MockProvider) ProcessExec(app string, pid string, command string, rw io.ReadWriter, opts ProcessExecOptions) (int, error) {
 	ret := _m.Called(app, pid, command, rw, opts)
@@ -739,6 +843,20 @@ func (_m *MockProvider) ProcessExec(app string, pid string, command string, rw i
 	return r0, r1
 }
 
+// ProcessForward provides a mock function with given fields: app, pid, port, rw, opts
+func (_m *MockProvider) ProcessForward(app string, pid string, port int, rw io.ReadWriter, opts ProcessForwardOptions) error {
+	ret := _m.Called(app, pid, port, rw, opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, int, io.ReadWriter, ProcessForwardOptions) error); ok {
+		r0 = rf(app, pid, port, rw, opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ProcessGet provides a mock function with given fields: app, pid
 func (_m *MockProvider) ProcessGet(app string, pid string) (*Process, error) {
 	ret := _m.Called(app, pid)
@@ -942,6 +1060,29 @@ func (_m *MockProvider) ReleaseCreate(app string, opts ReleaseCreateOptions) (*R
 	return r0, r1
 }
 
+// ReleaseDiff provides a mock function with given fields: app, id, opts
+func (_m *MockProvider) ReleaseDiff(app string, id string, opts ReleaseDiffOptions) (*ReleaseDiff, error) {
+	ret := _m.Called(app, id, opts)
+
+	var r0 *ReleaseDiff
+	if rf, ok := ret.Get(0).(func(string, string, ReleaseDiffOptions) *ReleaseDiff); ok {
+		r0 = rf(app, id, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ReleaseDiff)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, ReleaseDiffOptions) error); ok {
+		r1 = rf(app, id, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ReleaseGet provides a mock function with given fields: app, id
 func (_m *MockProvider) ReleaseGet(app string, id string) (*Release, error) {
 	ret := _m.Called(app, id)
@@ -1002,6 +1143,29 @@ func (_m *MockProvider) ReleasePromote(app string, id string, opts ReleasePromot
 	return r0
 }
 
+// ResourceBackupList provides a mock function with given fields: app, name
+func (_m *MockProvider) ResourceBackupList(app string, name string) (ResourceBackups, error) {
+	ret := _m.Called(app, name)
+
+	var r0 ResourceBackups
+	if rf, ok := ret.Get(0).(func(string, string) ResourceBackups); ok {
+		r0 = rf(app, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(ResourceBackups)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(app, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ResourceConsole provides a mock function with given fields: app, name, rw, opts
 func (_m *MockProvider) ResourceConsole(app string, name string, rw io.ReadWriter, opts ResourceConsoleOptions) error {
 	ret := _m.Called(app, name, rw, opts)
@@ -1099,6 +1263,57 @@ func (_m *MockProvider) ResourceList(app string) (Resources, error) {
 	return r0, r1
 }
 
+// ResourceRestore provides a mock function with given fields: app, name, id
+func (_m *MockProvider) ResourceRestore(app string, name string, id string) error {
+	ret := _m.Called(app, name, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(app, name, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResourceUpdate provides a mock function with given fields: app, name, opts
+func (_m *MockProvider) ResourceUpdate(app string, name string, opts ResourceUpdateOptions) (ResourceChanges, error) {
+	ret := _m.Called(app, name, opts)
+
+	var r0 ResourceChanges
+	if rf, ok := ret.Get(0).(func(string, string, ResourceUpdateOptions) ResourceChanges); ok {
+		r0 = rf(app, name, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(ResourceChanges)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, ResourceUpdateOptions) error); ok {
+		r1 = rf(app, name, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceForward provides a mock function with given fields: app, name, port, rw, opts
+func (_m *MockProvider) ServiceForward(app string, name string, port int, rw io.ReadWriter, opts ServiceForwardOptions) error {
+	ret := _m.Called(app, name, port, rw, opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, int, io.ReadWriter, ServiceForwardOptions) error); ok {
+		r0 = rf(app, name, port, rw, opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ServiceList provides a mock function with given fields: app
 func (_m *MockProvider) ServiceList(app string) (Services, error) {
 	ret := _m.Called(app)
@@ -1136,6 +1351,34 @@ func (_m *MockProvider) ServiceRestart(app string, name string) error {
 	return r0
 }
 
+// ServiceScheduleDelete provides a mock function with given fields: app, name, schedule
+func (_m *MockProvider) ServiceScheduleDelete(app string, name string, schedule string) error {
+	ret := _m.Called(app, name, schedule)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(app, name, schedule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ServiceScheduleSet provides a mock function with given fields: app, name, schedule, opts
+func (_m *MockProvider) ServiceScheduleSet(app string, name string, schedule string, opts ServiceScheduleSetOptions) error {
+	ret := _m.Called(app, name, schedule, opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, ServiceScheduleSetOptions) error); ok {
+		r0 = rf(app, name, schedule, opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ServiceUpdate provides a mock function with given fields: app, name, opts
 func (_m *MockProvider) ServiceUpdate(app string, name string, opts ServiceUpdateOptions) error {
 	ret := _m.Called(app, name, opts)
@@ -1503,6 +1746,237 @@ func (_m *MockProvider) SystemUpdate(opts SystemUpdateOptions) error {
 	return r0
 }
 
+// TimerList provides a mock function with given fields: app
+func (_m *MockProvider) TimerList(app string) (Timers, error) {
+	ret := _m.Called(app)
+
+	var r0 Timers
+	if rf, ok := ret.Get(0).(func(string) Timers); ok {
+		r0 = rf(app)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(Timers)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(app)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TimerResume provides a mock function with given fields: app, name
+func (_m *MockProvider) TimerResume(app string, name string) error {
+	ret := _m.Called(app, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(app, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TimerRun provides a mock function with given fields: app, name
+func (_m *MockProvider) TimerRun(app string, name string) error {
+	ret := _m.Called(app, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(app, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TimerSuspend provides a mock function with given fields: app, name
+func (_m *MockProvider) TimerSuspend(app string, name string) error {
+	ret := _m.Called(app, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(app, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TokenAuthenticate provides a mock function with given fields: secret
+func (_m *MockProvider) TokenAuthenticate(secret string) (*Token, error) {
+	ret := _m.Called(secret)
+
+	var r0 *Token
+	if rf, ok := ret.Get(0).(func(string) *Token); ok {
+		r0 = rf(secret)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Token)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(secret)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TokenCreate provides a mock function with given fields: name, opts
+func (_m *MockProvider) TokenCreate(name string, opts TokenCreateOptions) (*Token, error) {
+	ret := _m.Called(name, opts)
+
+	var r0 *Token
+	if rf, ok := ret.Get(0).(func(string, TokenCreateOptions) *Token); ok {
+		r0 = rf(name, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Token)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, TokenCreateOptions) error); ok {
+		r1 = rf(name, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TokenDelete provides a mock function with given fields: id
+func (_m *MockProvider) TokenDelete(id string) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TokenList provides a mock function with given fields:
+func (_m *MockProvider) TokenList() (Tokens, error) {
+	ret := _m.Called()
+
+	var r0 Tokens
+	if rf, ok := ret.Get(0).(func() Tokens); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(Tokens)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VolumeList provides a mock function with given fields: app
+func (_m *MockProvider) VolumeList(app string) (Volumes, error) {
+	ret := _m.Called(app)
+
+	var r0 Volumes
+	if rf, ok := ret.Get(0).(func(string) Volumes); ok {
+		r0 = rf(app)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(Volumes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(app)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VolumeRestore provides a mock function with given fields: app, name, id, opts
+func (_m *MockProvider) VolumeRestore(app string, name string, id string, opts VolumeRestoreOptions) error {
+	ret := _m.Called(app, name, id, opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, VolumeRestoreOptions) error); ok {
+		r0 = rf(app, name, id, opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VolumeSnapshot provides a mock function with given fields: app, name
+func (_m *MockProvider) VolumeSnapshot(app string, name string) (*VolumeSnapshot, error) {
+	ret := _m.Called(app, name)
+
+	var r0 *VolumeSnapshot
+	if rf, ok := ret.Get(0).(func(string, string) *VolumeSnapshot); ok {
+		r0 = rf(app, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*VolumeSnapshot)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(app, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VolumeSnapshotList provides a mock function with given fields: app, name
+func (_m *MockProvider) VolumeSnapshotList(app string, name string) (VolumeSnapshots, error) {
+	ret := _m.Called(app, name)
+
+	var r0 VolumeSnapshots
+	if rf, ok := ret.Get(0).(func(string, string) VolumeSnapshots); ok {
+		r0 = rf(app, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(VolumeSnapshots)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(app, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // WithContext provides a mock function with given fields: ctx
 func (_m *MockProvider) WithContext(ctx context.Context) Provider {
 	ret := _m.Called(ctx)
@@ -8,22 +8,33 @@ import (
 type Process struct {
 	Id string `json:"id"`
 
-	App      string    `json:"app"`
-	Command  string    `json:"command"`
-	Cpu      float64   `json:"cpu"`
-	Host     string    `json:"host"`
-	Image    string    `json:"image"`
-	Instance string    `json:"instance"`
-	Memory   float64   `json:"memory"`
-	Name     string    `json:"name"`
-	Ports    []string  `json:"ports"`
-	Release  string    `json:"release"`
-	Started  time.Time `json:"started"`
-	Status   string    `json:"status"`
+	App      string     `json:"app"`
+	Command  string     `json:"command"`
+	Cpu      float64    `json:"cpu"`
+	Ended    *time.Time `json:"ended,omitempty"`
+	ExitCode *int       `json:"exit-code,omitempty"`
+	Host     string     `json:"host"`
+	Identity string     `json:"identity,omitempty"`
+	Image    string     `json:"image"`
+	Instance string     `json:"instance"`
+	Memory   float64    `json:"memory"`
+	Name     string     `json:"name"`
+	Ports    []string   `json:"ports"`
+	Release  string     `json:"release"`
+	Started  time.Time  `json:"started"`
+	Status   string     `json:"status"`
 }
 
 type Processes []Process
 
+type ProcessDebugOptions struct {
+	Command *string `header:"Command"`
+	Height  *int    `header:"Height"`
+	Image   *string `header:"Image"`
+	Tty     *bool   `header:"Tty" default:"true"`
+	Width   *int    `header:"Width"`
+}
+
 type ProcessExecOptions struct {
 	Entrypoint *bool `header:"Entrypoint"`
 	Height     *int  `header:"Height"`
@@ -31,7 +42,12 @@ type ProcessExecOptions struct {
 	Width      *int  `header:"Width"`
 }
 
+type ProcessForwardOptions struct {
+	Protocol *string `header:"Protocol" default:"tcp"`
+}
+
 type ProcessListOptions struct {
+	History *bool   `flag:"history" query:"history"`
 	Release *string `flag:"release" query:"release"`
 	Service *string `flag:"service,s" query:"service"`
 }
@@ -40,6 +56,7 @@ type ProcessRunOptions struct {
 	Command     *string           `header:"Command"`
 	Environment map[string]string `header:"Environment"`
 	Height      *int              `header:"Height"`
+	Identity    *string           `header:"Identity"`
 	Image       *string           `header:"Image"`
 	Memory      *int              `header:"Memory"`
 	Release     *string           `flag:"release" header:"Release"`
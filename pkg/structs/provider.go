@@ -18,6 +18,9 @@ type Provider interface {
 	AppMetrics(name string, opts MetricsOptions) (Metrics, error)
 	AppUpdate(name string, opts AppUpdateOptions) error
 
+	AuditList(opts AuditListOptions) (AuditEntries, error)
+	AuditRecord(entry AuditEntry) error
+
 	BalancerList(app string) (Balancers, error)
 
 	BuildCreate(app, url string, opts BuildCreateOptions) (*Build, error)
@@ -36,8 +39,11 @@ type Provider interface {
 	CertificateGenerate(domains []string) (*Certificate, error)
 	CertificateList() (Certificates, error)
 
+	EnvironmentSecretList(app string) (EnvironmentSecrets, error)
+
 	EventSend(action string, opts EventSendOptions) error
 
+	FilesChecksums(app, pid, path string) (FileChecksums, error)
 	FilesDelete(app, pid string, files []string) error
 	FilesDownload(app, pid string, file string) (io.Reader, error)
 	FilesUpload(app, pid string, r io.Reader) error
@@ -53,7 +59,9 @@ type Provider interface {
 	ObjectList(app, prefix string) ([]string, error)
 	ObjectStore(app, key string, r io.Reader, opts ObjectStoreOptions) (*Object, error)
 
+	ProcessDebug(app, pid string, rw io.ReadWriter, opts ProcessDebugOptions) (int, error)
 	ProcessExec(app, pid, command string, rw io.ReadWriter, opts ProcessExecOptions) (int, error)
+	ProcessForward(app, pid string, port int, rw io.ReadWriter, opts ProcessForwardOptions) error
 	ProcessGet(app, pid string) (*Process, error)
 	ProcessList(app string, opts ProcessListOptions) (Processes, error)
 	ProcessLogs(app, pid string, opts LogsOptions) (io.ReadCloser, error)
@@ -67,18 +75,25 @@ type Provider interface {
 	RegistryRemove(server string) error
 
 	ReleaseCreate(app string, opts ReleaseCreateOptions) (*Release, error)
+	ReleaseDiff(app, id string, opts ReleaseDiffOptions) (*ReleaseDiff, error)
 	ReleaseGet(app, id string) (*Release, error)
 	ReleaseList(app string, opts ReleaseListOptions) (Releases, error)
 	ReleasePromote(app, id string, opts ReleasePromoteOptions) error
 
+	ResourceBackupList(app, name string) (ResourceBackups, error)
 	ResourceConsole(app, name string, rw io.ReadWriter, opts ResourceConsoleOptions) error
 	ResourceExport(app, name string) (io.ReadCloser, error)
 	ResourceGet(app, name string) (*Resource, error)
 	ResourceImport(app, name string, r io.Reader) error
 	ResourceList(app string) (Resources, error)
+	ResourceRestore(app, name, id string) error
+	ResourceUpdate(app, name string, opts ResourceUpdateOptions) (ResourceChanges, error)
 
+	ServiceForward(app, name string, port int, rw io.ReadWriter, opts ServiceForwardOptions) error
 	ServiceList(app string) (Services, error)
 	ServiceRestart(app, name string) error
+	ServiceScheduleDelete(app, name, schedule string) error
+	ServiceScheduleSet(app, name, schedule string, opts ServiceScheduleSetOptions) error
 	ServiceUpdate(app, name string, opts ServiceUpdateOptions) error
 
 	SystemGet() (*System, error)
@@ -99,6 +114,21 @@ type Provider interface {
 	SystemResourceUnlink(name, app string) (*Resource, error)
 	SystemResourceUpdate(name string, opts ResourceUpdateOptions) (*Resource, error)
 
+	TimerList(app string) (Timers, error)
+	TimerResume(app, name string) error
+	TimerRun(app, name string) error
+	TimerSuspend(app, name string) error
+
+	TokenAuthenticate(secret string) (*Token, error)
+	TokenCreate(name string, opts TokenCreateOptions) (*Token, error)
+	TokenDelete(id string) error
+	TokenList() (Tokens, error)
+
+	VolumeList(app string) (Volumes, error)
+	VolumeRestore(app, name, id string, opts VolumeRestoreOptions) error
+	VolumeSnapshot(app, name string) (*VolumeSnapshot, error)
+	VolumeSnapshotList(app, name string) (VolumeSnapshots, error)
+
 	WithContext(ctx context.Context) Provider
 
 	Workers() error
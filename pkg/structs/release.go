@@ -8,6 +8,7 @@ type Release struct {
 	App         string `json:"app"`
 	Build       string `json:"build"`
 	Env         string `json:"env"`
+	EnvMeta     string `json:"env-meta,omitempty"`
 	Manifest    string `json:"manifest"`
 	Description string `json:"description"`
 
@@ -17,8 +18,43 @@ type Release struct {
 type Releases []Release
 
 type ReleaseCreateOptions struct {
-	Build *string `param:"build"`
-	Env   *string `param:"env"`
+	Build   *string `param:"build"`
+	Env     *string `param:"env"`
+	EnvMeta *string `param:"env-meta"`
+}
+
+type ReleaseDiff struct {
+	App      string               `json:"app"`
+	From     string               `json:"from"`
+	To       string               `json:"to"`
+	Build    *ReleaseDiffBuild    `json:"build,omitempty"`
+	Env      []ReleaseDiffChange  `json:"env,omitempty"`
+	Services []ReleaseDiffService `json:"services,omitempty"`
+}
+
+type ReleaseDiffBuild struct {
+	From        string `json:"from"`
+	To          string `json:"to"`
+	Description string `json:"description"`
+}
+
+// ReleaseDiffChange is a single value that was added, removed, or changed between releases
+type ReleaseDiffChange struct {
+	Change string `json:"change"`
+	Name   string `json:"name"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+}
+
+type ReleaseDiffService struct {
+	Change  string              `json:"change"`
+	Name    string              `json:"name"`
+	Changes []ReleaseDiffChange `json:"changes,omitempty"`
+}
+
+type ReleaseDiffOptions struct {
+	From   *string `query:"from"`
+	Reveal *bool   `flag:"reveal" query:"reveal"`
 }
 
 type ReleaseListOptions struct {
@@ -42,6 +78,11 @@ func NewRelease(app string) *Release {
 	}
 }
 
+// Empty returns true if the releases have no differences
+func (d *ReleaseDiff) Empty() bool {
+	return d.Build == nil && len(d.Env) == 0 && len(d.Services) == 0
+}
+
 func (rs Releases) Less(i, j int) bool {
 	return rs[i].Created.After(rs[j].Created)
 }
@@ -1,5 +1,7 @@
 package structs
 
+import "time"
+
 type Resource struct {
 	Name       string            `json:"name"`
 	Parameters map[string]string `json:"parameters,omitempty"`
@@ -16,6 +18,51 @@ func (rs Resources) Less(i, j int) bool {
 	return rs[i].Name < rs[j].Name
 }
 
+type ResourceBackup struct {
+	Created time.Time `json:"created"`
+	Id      string    `json:"id"`
+	Size    int64     `json:"size"`
+}
+
+type ResourceBackups []ResourceBackup
+
+func (rbs ResourceBackups) Less(i, j int) bool {
+	return rbs[i].Created.After(rbs[j].Created)
+}
+
+// ResourceChange is a difference between a deployed resource and its parameters in a release manifest
+// along with the Action taken to apply it, Destructive changes can lose data
+type ResourceChange struct {
+	Action      string `json:"action"`
+	Current     string `json:"current"`
+	Desired     string `json:"desired"`
+	Destructive bool   `json:"destructive"`
+	Parameter   string `json:"parameter"`
+}
+
+type ResourceChanges []ResourceChange
+
+func (cs ResourceChanges) Destructive() bool {
+	for _, c := range cs {
+		if c.Destructive {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Migrate is true when the resource data has to be moved to a new volume
+func (cs ResourceChanges) Migrate() bool {
+	for _, c := range cs {
+		if c.Action == "migrate" {
+			return true
+		}
+	}
+
+	return false
+}
+
 type ResourceType struct {
 	Name       string             `json:"name"`
 	Parameters ResourceParameters `json:"parameters"`
@@ -50,5 +97,8 @@ type ResourceCreateOptions struct {
 }
 
 type ResourceUpdateOptions struct {
+	DryRun     *bool             `param:"dry-run"`
+	Force      *bool             `param:"force"`
 	Parameters map[string]string `param:"parameters"`
+	Release    *string           `param:"release"`
 }
@@ -13,6 +13,8 @@ func init() {
 	routes["AppLogs"] = "SOCKET /apps/{name}/logs"
 	routes["AppMetrics"] = "GET /apps/{name}/metrics"
 	routes["AppUpdate"] = "PUT /apps/{name}"
+	routes["AuditList"] = "GET /system/audit"
+	routes["AuditRecord"] = ""
 	routes["BalancerList"] = "GET /apps/{app}/balancers"
 	routes["BuildCreate"] = "POST /apps/{app}/builds"
 	routes["BuildExport"] = "GET /apps/{app}/builds/{id}.tgz"
@@ -27,7 +29,9 @@ func init() {
 	routes["CertificateDelete"] = "DELETE /certificates/{id}"
 	routes["CertificateGenerate"] = "POST /certificates/generate"
 	routes["CertificateList"] = "GET /certificates"
+	routes["EnvironmentSecretList"] = "GET /apps/{app}/environment/secrets"
 	routes["EventSend"] = "POST /events"
+	routes["FilesChecksums"] = "GET /apps/{app}/processes/{pid}/files/checksums"
 	routes["FilesDelete"] = "DELETE /apps/{app}/processes/{pid}/files"
 	routes["FilesDownload"] = "GET /apps/{app}/processes/{pid}/files"
 	routes["FilesUpload"] = "POST /apps/{app}/processes/{pid}/files"
@@ -40,7 +44,9 @@ func init() {
 	routes["ObjectFetch"] = "GET /apps/{app}/objects/{key:.*}"
 	routes["ObjectList"] = "GET /apps/{app}/objects"
 	routes["ObjectStore"] = "POST /apps/{app}/objects/{key:.*}"
+	routes["ProcessDebug"] = "SOCKET /apps/{app}/processes/{pid}/debug"
 	routes["ProcessExec"] = "SOCKET /apps/{app}/processes/{pid}/exec"
+	routes["ProcessForward"] = "SOCKET /apps/{app}/processes/{pid}/forward/{port}"
 	routes["ProcessGet"] = "GET /apps/{app}/processes/{pid}"
 	routes["ProcessList"] = "GET /apps/{app}/processes"
 	routes["ProcessLogs"] = "SOCKET /apps/{app}/processes/{pid}/logs"
@@ -48,19 +54,26 @@ func init() {
 	routes["ProcessStop"] = "DELETE /apps/{app}/processes/{pid}"
 	routes["Proxy"] = "SOCKET /proxy/{host}/{port}"
 	routes["ReleaseCreate"] = "POST /apps/{app}/releases"
+	routes["ReleaseDiff"] = "GET /apps/{app}/releases/{id}/diff"
 	routes["ReleaseGet"] = "GET /apps/{app}/releases/{id}"
 	routes["ReleaseList"] = "GET /apps/{app}/releases"
 	routes["ReleasePromote"] = "POST /apps/{app}/releases/{id}/promote"
 	routes["RegistryAdd"] = "POST /registries"
 	routes["RegistryList"] = "GET /registries"
 	routes["RegistryRemove"] = "DELETE /registries/{server:.*}"
+	routes["ResourceBackupList"] = "GET /apps/{app}/resources/{name}/backups"
 	routes["ResourceConsole"] = "SOCKET /apps/{app}/resources/{name}/console"
 	routes["ResourceExport"] = "GET /apps/{app}/resources/{name}/data"
 	routes["ResourceGet"] = "GET /apps/{app}/resources/{name}"
 	routes["ResourceImport"] = "PUT /apps/{app}/resources/{name}/data"
 	routes["ResourceList"] = "GET /apps/{app}/resources"
+	routes["ResourceRestore"] = "POST /apps/{app}/resources/{name}/backups/{id}/restore"
+	routes["ResourceUpdate"] = "PUT /apps/{app}/resources/{name}"
+	routes["ServiceForward"] = "SOCKET /apps/{app}/services/{name}/forward/{port}"
 	routes["ServiceList"] = "GET /apps/{app}/services"
 	routes["ServiceRestart"] = "POST /apps/{app}/services/{name}/restart"
+	routes["ServiceScheduleDelete"] = "DELETE /apps/{app}/services/{name}/schedules"
+	routes["ServiceScheduleSet"] = "POST /apps/{app}/services/{name}/schedules"
 	routes["ServiceUpdate"] = "PUT /apps/{app}/services/{name}"
 	routes["SystemGet"] = "GET /system"
 	routes["SystemLogs"] = "SOCKET /system/logs"
@@ -78,6 +91,18 @@ func init() {
 	routes["SystemResourceUpdate"] = "PUT /resources/{name}"
 	routes["SystemUninstall"] = ""
 	routes["SystemUpdate"] = "PUT /system"
+	routes["TimerList"] = "GET /apps/{app}/timers"
+	routes["TimerResume"] = "POST /apps/{app}/timers/{name}/resume"
+	routes["TimerRun"] = "POST /apps/{app}/timers/{name}/run"
+	routes["TimerSuspend"] = "POST /apps/{app}/timers/{name}/suspend"
+	routes["TokenAuthenticate"] = ""
+	routes["TokenCreate"] = "POST /system/tokens"
+	routes["TokenDelete"] = "DELETE /system/tokens/{id}"
+	routes["TokenList"] = "GET /system/tokens"
+	routes["VolumeList"] = "GET /apps/{app}/volumes"
+	routes["VolumeRestore"] = "POST /apps/{app}/volumes/{name}/snapshots/{id}/restore"
+	routes["VolumeSnapshot"] = "POST /apps/{app}/volumes/{name}/snapshots"
+	routes["VolumeSnapshotList"] = "GET /apps/{app}/volumes/{name}/snapshots"
 	routes["Workers"] = ""
 }
 
@@ -1,12 +1,15 @@
 package structs
 
+import "time"
+
 type Service struct {
-	Count  int           `json:"count"`
-	Cpu    int           `json:"cpu"`
-	Domain string        `json:"domain"`
-	Memory int           `json:"memory"`
-	Name   string        `json:"name"`
-	Ports  []ServicePort `json:"ports"`
+	Count     int               `json:"count"`
+	Cpu       int               `json:"cpu"`
+	Domain    string            `json:"domain"`
+	Memory    int               `json:"memory"`
+	Name      string            `json:"name"`
+	Ports     []ServicePort     `json:"ports"`
+	Schedules []ServiceSchedule `json:"schedules,omitempty"`
 }
 
 type Services []Service
@@ -22,3 +25,19 @@ type ServiceUpdateOptions struct {
 	Cpu    *int `flag:"cpu" param:"cpu"`
 	Memory *int `flag:"memory" param:"memory"`
 }
+
+// ServiceSchedule scales a service to Count each time the cron expression in Schedule fires (UTC).
+// For autoscaled services Count sets the minimum that the autoscaler will scale down to.
+type ServiceSchedule struct {
+	Count    int       `json:"count"`
+	Schedule string    `json:"schedule"`
+	Next     time.Time `json:"next"`
+}
+
+type ServiceForwardOptions struct {
+	Protocol *string `header:"Protocol" default:"tcp"`
+}
+
+type ServiceScheduleSetOptions struct {
+	Count *int `flag:"count" param:"count"`
+}
@@ -0,0 +1,19 @@
+package structs
+
+import "time"
+
+type Timer struct {
+	Command     string     `json:"command"`
+	Concurrency string     `json:"concurrency"`
+	LastRun     *time.Time `json:"last-run,omitempty"`
+	LastStatus  string     `json:"last-status,omitempty"`
+	Name        string     `json:"name"`
+	Schedule    string     `json:"schedule"`
+	Service     string     `json:"service"`
+	Suspended   bool       `json:"suspended"`
+	Timezone    string     `json:"timezone,omitempty"`
+}
+
+type Timers []Timer
+
+func (ts Timers) Less(i, j int) bool { return ts[i].Name < ts[j].Name }
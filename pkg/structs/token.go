@@ -0,0 +1,62 @@
+package structs
+
+import "time"
+
+const (
+	TokenRoleReadOnly = "read-only"
+	TokenRoleDeployer = "deployer"
+	TokenRoleAdmin    = "admin"
+)
+
+var tokenRoleLevels = map[string]int{
+	TokenRoleReadOnly: 1,
+	TokenRoleDeployer: 2,
+	TokenRoleAdmin:    3,
+}
+
+type Token struct {
+	Id      string    `json:"id"`
+	Name    string    `json:"name"`
+	Role    string    `json:"role"`
+	Apps    []string  `json:"apps,omitempty"`
+	Secret  string    `json:"secret,omitempty"`
+	Created time.Time `json:"created"`
+}
+
+type Tokens []Token
+
+type TokenCreateOptions struct {
+	Apps []string `param:"apps"`
+	Role *string  `default:"read-only" flag:"role" param:"role"`
+}
+
+func TokenRoleValid(role string) bool {
+	_, ok := tokenRoleLevels[role]
+	return ok
+}
+
+// Allows returns true if the token role grants at least the given role
+func (t *Token) Allows(role string) bool {
+	return tokenRoleLevels[t.Role] >= tokenRoleLevels[role]
+}
+
+// AllowsApp returns true if the token is not scoped or is scoped to the given app
+func (t *Token) AllowsApp(app string) bool {
+	if len(t.Apps) == 0 {
+		return true
+	}
+
+	for _, a := range t.Apps {
+		if a == app {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *Token) Scoped() bool {
+	return len(t.Apps) > 0
+}
+
+func (ts Tokens) Less(i, j int) bool { return ts[i].Name < ts[j].Name }
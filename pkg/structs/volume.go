@@ -0,0 +1,39 @@
+package structs
+
+import "time"
+
+// Volume is a persistent volume claimed by the services of an app, Used is zero when no
+// running process mounts it
+type Volume struct {
+	Access   string   `json:"access"`
+	Class    string   `json:"class"`
+	Name     string   `json:"name"`
+	Services []string `json:"services"`
+	Size     int64    `json:"size"`
+	Status   string   `json:"status"`
+	Used     int64    `json:"used"`
+}
+
+type Volumes []Volume
+
+func (vs Volumes) Less(i, j int) bool {
+	return vs[i].Name < vs[j].Name
+}
+
+type VolumeSnapshot struct {
+	Created time.Time `json:"created"`
+	Id      string    `json:"id"`
+	Size    int64     `json:"size"`
+	Status  string    `json:"status"`
+	Volume  string    `json:"volume"`
+}
+
+type VolumeSnapshots []VolumeSnapshot
+
+func (vss VolumeSnapshots) Less(i, j int) bool {
+	return vss[i].Created.After(vss[j].Created)
+}
+
+type VolumeRestoreOptions struct {
+	SourceApp *string `param:"source-app"`
+}
@@ -12,7 +12,7 @@ func (p *Provider) ManifestValidate(m *manifest.Manifest) error {
 
 	for _, s := range m.Services {
 		if len(s.Volumes) > 0 {
-			errs = append(errs, fmt.Sprintf("shared volumes are not supported on gcp"))
+			errs = append(errs, fmt.Sprintf("shared volumes are not supported on azure"))
 			break
 		}
 	}
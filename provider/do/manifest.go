@@ -1,9 +1,37 @@
 package do
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/convox/convox/pkg/manifest"
+	"github.com/convox/convox/provider/k8s"
 )
 
 func (p *Provider) ManifestValidate(m *manifest.Manifest) error {
+	errs := []string{}
+
+	for _, s := range m.Services {
+		for _, v := range s.Volumes {
+			if k8s.SystemVolume(v.Name) {
+				continue
+			}
+
+			// block storage volumes can only be attached to one node
+			if v.Access != "" && v.Access != "ReadWriteOnce" {
+				errs = append(errs, fmt.Sprintf("volume %s for service %s: access %s is not supported on do, use ReadWriteOnce", v.Name, s.Name, v.Access))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("manifest valiation errors:\n%s", strings.Join(errs, "\n"))
+	}
+
 	return nil
 }
+
+// VolumeAccessDefault attaches volumes to a single node as block storage can not be shared
+func (p *Provider) VolumeAccessDefault() string {
+	return "ReadWriteOnce"
+}
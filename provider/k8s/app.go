@@ -32,6 +32,21 @@ func (p *Provider) AppCreate(name string, opts structs.AppCreateOptions) (*struc
 		return nil, err
 	}
 
+	var src *structs.App
+
+	if opts.CloneFrom != nil {
+		a, err := p.AppGet(*opts.CloneFrom)
+		if err != nil {
+			return nil, err
+		}
+
+		src = a
+	}
+
+	if opts.Ttl != nil && *opts.Ttl <= 0 {
+		return nil, fmt.Errorf("invalid ttl: %s", *opts.Ttl)
+	}
+
 	ns := &ac.Namespace{
 		ObjectMeta: am.ObjectMeta{
 			Name: p.AppNamespace(name),
@@ -49,6 +64,17 @@ func (p *Provider) AppCreate(name string, opts structs.AppCreateOptions) (*struc
 	a := &structs.App{
 		Name:       name,
 		Parameters: p.Engine.AppParameters(),
+		Tags:       map[string]string{},
+	}
+
+	if src != nil {
+		for k, v := range src.Parameters {
+			a.Parameters[k] = v
+		}
+	}
+
+	if opts.Ttl != nil {
+		a.Tags[structs.AppTagExpires] = time.Now().Add(*opts.Ttl).UTC().Format(time.RFC3339)
 	}
 
 	if err := p.appUpdate(a); err != nil {
@@ -59,6 +85,17 @@ func (p *Provider) AppCreate(name string, opts structs.AppCreateOptions) (*struc
 		return nil, err
 	}
 
+	if src != nil {
+		if err := p.appClone(src, a); err != nil {
+			// remove the partial copy so that the clone can be tried again with the same name
+			if err := p.appDelete(name); err != nil {
+				fmt.Printf("ns=k8s at=app.create app=%s error=%q\n", name, err)
+			}
+
+			return nil, err
+		}
+	}
+
 	a, err := p.AppGet(name)
 	if err != nil {
 		return nil, err
@@ -77,11 +114,7 @@ func (p *Provider) AppDelete(name string) error {
 		return fmt.Errorf("app is locked: %s", name)
 	}
 
-	if err := p.Cluster.CoreV1().Namespaces().Delete(p.AppNamespace(name), nil); err != nil {
-		return err
-	}
-
-	return nil
+	return p.appDelete(name)
 }
 
 func (p *Provider) AppGet(name string) (*structs.App, error) {
@@ -150,6 +183,10 @@ func (p *Provider) AppUpdate(name string, opts structs.AppUpdateOptions) error {
 		a.Locked = *opts.Lock
 	}
 
+	if opts.Maintenance != nil {
+		a.Maintenance = *opts.Maintenance
+	}
+
 	if opts.Parameters != nil {
 		if err := p.appParametersUpdate(a, opts.Parameters); err != nil {
 			return err
@@ -167,6 +204,55 @@ func (p *Provider) AppUpdate(name string, opts structs.AppUpdateOptions) error {
 	return nil
 }
 
+// appDelete removes the namespace of an app along with everything in it
+func (p *Provider) appDelete(name string) error {
+	if err := p.Cluster.CoreV1().Namespaces().Delete(p.AppNamespace(name), nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// appClone copies the environment of the latest release of src into a new release of a and
+// creates the resources of the promoted release of src in a with a copy of their current data
+func (p *Provider) appClone(src, a *structs.App) error {
+	sr, err := common.ReleaseLatest(p, src.Name)
+	if err != nil {
+		return err
+	}
+
+	if sr == nil {
+		return nil
+	}
+
+	r := structs.NewRelease(a.Name)
+
+	r.Env = sr.Env
+	r.EnvMeta = sr.EnvMeta
+
+	r, err = p.releaseCreate(r)
+	if err != nil {
+		return err
+	}
+
+	if src.Release == "" {
+		return nil
+	}
+
+	m, _, err := common.ReleaseManifest(p, src.Name, src.Release)
+	if err != nil {
+		return err
+	}
+
+	for _, mr := range m.Resources {
+		if err := p.resourceClone(src.Name, a, mr, r.Id); err != nil {
+			return fmt.Errorf("could not clone resource %s: %s", mr.Name, err)
+		}
+	}
+
+	return nil
+}
+
 func (p *Provider) appFromNamespace(ns ac.Namespace) (*structs.App, error) {
 	name := common.CoalesceString(ns.Labels["app"], ns.Labels["name"])
 
@@ -182,12 +268,13 @@ func (p *Provider) appFromNamespace(ns ac.Namespace) (*structs.App, error) {
 	}
 
 	a := &structs.App{
-		Generation: "3",
-		Locked:     ns.Annotations["convox.com/lock"] == "true",
-		Name:       name,
-		Release:    release,
-		Router:     p.Router,
-		Status:     status,
+		Generation:  "3",
+		Locked:      ns.Annotations["convox.com/lock"] == "true",
+		Maintenance: ns.Annotations["convox.com/maintenance"] == "true",
+		Name:        name,
+		Release:     release,
+		Router:      p.Router,
+		Status:      status,
 	}
 
 	var params map[string]string
@@ -212,6 +299,18 @@ func (p *Provider) appFromNamespace(ns ac.Namespace) (*structs.App, error) {
 
 	a.Parameters = params
 
+	tags := map[string]string{}
+
+	if data, ok := ns.Annotations["convox.com/tags"]; ok && data > "" {
+		if err := json.Unmarshal([]byte(data), &tags); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(tags) > 0 {
+		a.Tags = tags
+	}
+
 	switch ns.Status.Phase {
 	case "Terminating":
 		a.Status = "deleting"
@@ -259,6 +358,12 @@ func (p *Provider) appUpdate(a *structs.App) error {
 
 	ns.Annotations["convox.com/lock"] = fmt.Sprintf("%t", a.Locked)
 
+	if a.Maintenance {
+		ns.Annotations["convox.com/maintenance"] = "true"
+	} else {
+		delete(ns.Annotations, "convox.com/maintenance")
+	}
+
 	data, err := json.Marshal(a.Parameters)
 	if err != nil {
 		return err
@@ -266,6 +371,15 @@ func (p *Provider) appUpdate(a *structs.App) error {
 
 	ns.Annotations["convox.com/params"] = string(data)
 
+	if len(a.Tags) > 0 {
+		data, err := json.Marshal(a.Tags)
+		if err != nil {
+			return err
+		}
+
+		ns.Annotations["convox.com/tags"] = string(data)
+	}
+
 	if _, err := p.Cluster.CoreV1().Namespaces().Update(ns); err != nil {
 		return err
 	}
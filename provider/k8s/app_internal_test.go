@@ -0,0 +1,58 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/convox/convox/pkg/atom"
+	"github.com/convox/convox/pkg/options"
+	"github.com/convox/convox/pkg/structs"
+	"github.com/convox/convox/pkg/templater"
+	"github.com/gobuffalo/packr"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	ac "k8s.io/api/core/v1"
+	am "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+type testAppEngine struct {
+	Engine
+}
+
+func (e *testAppEngine) AppParameters() map[string]string {
+	return map[string]string{"Test": "foo"}
+}
+
+func TestAppCreateCloneFailure(t *testing.T) {
+	aa := &atom.MockInterface{}
+	c := fake.NewSimpleClientset(
+		&ac.Namespace{ObjectMeta: am.ObjectMeta{Name: "rack1-app1", Labels: map[string]string{"name": "app1", "type": "app"}}},
+	)
+
+	p := &Provider{
+		Atom:    aa,
+		Cluster: c,
+		// releases can not be read so the clone fails after the app is created
+		Config: &rest.Config{Host: "http://127.0.0.1:1"},
+		Engine: &testAppEngine{},
+		Name:   "rack1",
+	}
+
+	p.templater = templater.New(packr.NewBox("../k8s/template"), p.templateHelpers())
+
+	aa.On("Status", "rack1-app1", "app").Return("Running", "R1234567", nil)
+	aa.On("Status", "rack1-app2", "app").Return("Running", "", nil)
+	aa.On("Apply", "rack1-app2", "app", "", mock.Anything, int32(30)).Return(nil).Once()
+
+	_, err := p.AppCreate("app2", structs.AppCreateOptions{CloneFrom: options.String("app1")})
+	require.Error(t, err)
+
+	_, err = c.CoreV1().Namespaces().Get("rack1-app2", am.GetOptions{})
+	require.Error(t, err)
+
+	_, err = c.CoreV1().Namespaces().Get("rack1-app1", am.GetOptions{})
+	require.NoError(t, err)
+
+	aa.AssertExpectations(t)
+}
@@ -0,0 +1,153 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/convox/convox/pkg/common"
+	"github.com/convox/convox/pkg/structs"
+	ac "k8s.io/api/core/v1"
+	ae "k8s.io/apimachinery/pkg/api/errors"
+	am "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	AuditChunkSize = 512 * 1024
+	AuditLimit     = 100
+	AuditRetention = 90 * 24 * time.Hour
+)
+
+func (p *Provider) AuditList(opts structs.AuditListOptions) (structs.AuditEntries, error) {
+	cms, err := p.Cluster.CoreV1().ConfigMaps(p.Namespace).List(am.ListOptions{
+		LabelSelector: fmt.Sprintf("system=convox,rack=%s,type=audit", p.Name),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	es := structs.AuditEntries{}
+
+	for _, cm := range cms.Items {
+		for _, data := range cm.Data {
+			var e structs.AuditEntry
+
+			if err := json.Unmarshal([]byte(data), &e); err != nil {
+				return nil, err
+			}
+
+			if e.Matches(opts) {
+				es = append(es, e)
+			}
+		}
+	}
+
+	sort.Slice(es, es.Less)
+
+	if limit := common.DefaultInt(opts.Limit, AuditLimit); len(es) > limit {
+		es = es[0:limit]
+	}
+
+	return es, nil
+}
+
+// AuditRecord appends an entry to the configmap chunks for the day of the entry
+func (p *Provider) AuditRecord(entry structs.AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	day := entry.Time.Format("20060102")
+	key := fmt.Sprintf("%d-%s", entry.Time.UnixNano(), entry.Id)
+
+	for chunk, attempts := 0, 0; attempts < 10; attempts++ {
+		name := fmt.Sprintf("audit-%s-%d", day, chunk)
+
+		cm, err := p.Cluster.CoreV1().ConfigMaps(p.Namespace).Get(name, am.GetOptions{})
+		if ae.IsNotFound(err) {
+			_, err := p.Cluster.CoreV1().ConfigMaps(p.Namespace).Create(&ac.ConfigMap{
+				ObjectMeta: am.ObjectMeta{
+					Name: name,
+					Labels: map[string]string{
+						"system": "convox",
+						"rack":   p.Name,
+						"type":   "audit",
+						"day":    day,
+					},
+				},
+				Data: map[string]string{key: string(data)},
+			})
+			if ae.IsAlreadyExists(err) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			if chunk == 0 {
+				return p.auditPrune(entry.Time.Add(-1 * AuditRetention))
+			}
+
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if auditChunkSize(cm)+len(data) > AuditChunkSize {
+			chunk++
+			continue
+		}
+
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+
+		cm.Data[key] = string(data)
+
+		_, err = p.Cluster.CoreV1().ConfigMaps(p.Namespace).Update(cm)
+		if ae.IsConflict(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("could not record audit entry: %s", entry.Id)
+}
+
+func (p *Provider) auditPrune(before time.Time) error {
+	cms, err := p.Cluster.CoreV1().ConfigMaps(p.Namespace).List(am.ListOptions{
+		LabelSelector: fmt.Sprintf("system=convox,rack=%s,type=audit", p.Name),
+	})
+	if err != nil {
+		return err
+	}
+
+	cutoff := before.Format("20060102")
+
+	for _, cm := range cms.Items {
+		if cm.Labels["day"] < cutoff {
+			if err := p.Cluster.CoreV1().ConfigMaps(p.Namespace).Delete(cm.Name, nil); err != nil && !ae.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func auditChunkSize(cm *ac.ConfigMap) int {
+	size := 0
+
+	for k, v := range cm.Data {
+		size += len(k) + len(v)
+	}
+
+	return size
+}
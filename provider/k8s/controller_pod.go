@@ -121,6 +121,10 @@ func (c *PodController) Update(prev, cur interface{}) error {
 func (c *PodController) cleanupPod(p *ac.Pod) error {
 	time.Sleep(5 * time.Second)
 
+	if err := c.Provider.processHistoryRecord(*p); err != nil {
+		fmt.Printf("ns=k8s at=pod.cleanup pod=%s error=%q\n", p.ObjectMeta.Name, err)
+	}
+
 	if err := c.Client().CoreV1().Pods(p.ObjectMeta.Namespace).Delete(p.ObjectMeta.Name, nil); err != nil {
 		return err
 	}
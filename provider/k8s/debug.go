@@ -0,0 +1,199 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/convox/convox/pkg/common"
+	"github.com/convox/convox/pkg/structs"
+	shellquote "github.com/kballard/go-shellquote"
+	ac "k8s.io/api/core/v1"
+	am "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
+)
+
+const (
+	debugImageDefault = "busybox"
+	debugTimeout      = 2 * time.Minute
+)
+
+// the vendored api types predate ephemeral containers so these are
+// marshaled by hand for the pods/ephemeralcontainers subresource
+type debugContainer struct {
+	Command             []string         `json:"command,omitempty"`
+	Image               string           `json:"image"`
+	Name                string           `json:"name"`
+	Stdin               bool             `json:"stdin"`
+	TargetContainerName string           `json:"targetContainerName"`
+	TTY                 bool             `json:"tty"`
+	VolumeMounts        []ac.VolumeMount `json:"volumeMounts,omitempty"`
+}
+
+type debugPodStatus struct {
+	Status struct {
+		EphemeralContainerStatuses []ac.ContainerStatus `json:"ephemeralContainerStatuses"`
+	} `json:"status"`
+}
+
+// ProcessDebug attaches an ephemeral container to a running process that shares
+// the process namespace and volumes of its main container, ephemeral containers
+// can not be removed so it remains on the pod in a terminated state afterwards
+func (p *Provider) ProcessDebug(app, pid string, rw io.ReadWriter, opts structs.ProcessDebugOptions) (int, error) {
+	pd, err := p.Cluster.CoreV1().Pods(p.AppNamespace(app)).Get(pid, am.GetOptions{})
+	if err != nil {
+		return 0, err
+	}
+
+	if pd.Status.Phase != ac.PodRunning {
+		return 0, fmt.Errorf("process is not running: %s", pid)
+	}
+
+	tty := common.DefaultBool(opts.Tty, true)
+
+	dc := debugContainer{
+		Image:               common.DefaultString(opts.Image, debugImageDefault),
+		Name:                strings.ToLower(common.Id("debug-", 11)),
+		Stdin:               true,
+		TargetContainerName: "main",
+		TTY:                 tty,
+	}
+
+	if opts.Command != nil && strings.TrimSpace(*opts.Command) != "" {
+		cp, err := shellquote.Split(*opts.Command)
+		if err != nil {
+			return 0, err
+		}
+
+		dc.Command = cp
+	}
+
+	for _, c := range pd.Spec.Containers {
+		if c.Name == "main" {
+			dc.VolumeMounts = c.VolumeMounts
+		}
+	}
+
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"ephemeralContainers": []debugContainer{dc},
+		},
+	}
+
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := p.Cluster.CoreV1().RESTClient().Patch(types.StrategicMergePatchType).Resource("pods").Name(pid).Namespace(pd.Namespace).SubResource("ephemeralcontainers").Body(data).DoRaw(); err != nil {
+		return 0, fmt.Errorf("could not add debug container: %s", err)
+	}
+
+	if err := p.debugContainerWait(pd.Namespace, pid, dc.Name); err != nil {
+		return 0, err
+	}
+
+	req := p.Cluster.CoreV1().RESTClient().Post().Resource("pods").Name(pid).Namespace(pd.Namespace).SubResource("attach")
+
+	req.VersionedParams(&ac.PodAttachOptions{
+		Container: dc.Name,
+		Stdin:     true,
+		Stdout:    true,
+		Stderr:    !tty,
+		TTY:       tty,
+	}, scheme.ParameterCodec)
+
+	e, err := remotecommand.NewSPDYExecutor(p.Config, "POST", req.URL())
+	if err != nil {
+		return 0, err
+	}
+
+	inr, inw := io.Pipe()
+	go io.Copy(inw, rw)
+
+	sopts := remotecommand.StreamOptions{
+		Stdin:  inr,
+		Stdout: rw,
+		Tty:    tty,
+	}
+
+	if !tty {
+		sopts.Stderr = rw
+	}
+
+	if opts.Height != nil && opts.Width != nil {
+		sopts.TerminalSizeQueue = &terminalSize{Height: *opts.Height, Width: *opts.Width}
+	}
+
+	err = e.Stream(sopts)
+	if ee, ok := err.(exec.ExitError); ok {
+		return ee.ExitStatus(), nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return p.debugContainerExitCode(pd.Namespace, pid, dc.Name), nil
+}
+
+func (p *Provider) debugContainerStatus(ns, pid, name string) (*ac.ContainerStatus, error) {
+	data, err := p.Cluster.CoreV1().RESTClient().Get().Resource("pods").Name(pid).Namespace(ns).DoRaw()
+	if err != nil {
+		return nil, err
+	}
+
+	var ds debugPodStatus
+
+	if err := json.Unmarshal(data, &ds); err != nil {
+		return nil, err
+	}
+
+	for _, cs := range ds.Status.EphemeralContainerStatuses {
+		if cs.Name == name {
+			return &cs, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (p *Provider) debugContainerWait(ns, pid, name string) error {
+	return common.Wait(1*time.Second, debugTimeout, 1, func() (bool, error) {
+		cs, err := p.debugContainerStatus(ns, pid, name)
+		if err != nil {
+			return false, err
+		}
+
+		switch {
+		case cs == nil:
+			return false, nil
+		case cs.State.Running != nil:
+			return true, nil
+		case cs.State.Terminated != nil:
+			return false, fmt.Errorf("debug container exited: %s", cs.State.Terminated.Reason)
+		case cs.State.Waiting != nil && (cs.State.Waiting.Reason == "ErrImagePull" || cs.State.Waiting.Reason == "ImagePullBackOff"):
+			return false, fmt.Errorf("could not pull debug image: %s", cs.Image)
+		default:
+			return false, nil
+		}
+	})
+}
+
+// debugContainerExitCode returns the exit code once the debug container terminates, a
+// container that is still running after the stream closes was detached from and reports 0
+func (p *Provider) debugContainerExitCode(ns, pid, name string) int {
+	for i := 0; i < 5; i++ {
+		if cs, err := p.debugContainerStatus(ns, pid, name); err == nil && cs != nil && cs.State.Terminated != nil {
+			return int(cs.State.Terminated.ExitCode)
+		}
+
+		time.Sleep(1 * time.Second)
+	}
+
+	return 0
+}
@@ -1,6 +1,7 @@
 package k8s
 
 import (
+	"io"
 	"time"
 
 	"github.com/convox/convox/pkg/manifest"
@@ -15,6 +16,8 @@ type Engine interface {
 	IngressSecrets(app string) ([]string, error)
 	Log(app, stream string, ts time.Time, message string) error
 	ManifestValidate(m *manifest.Manifest) error
+	ObjectExists(app, key string) (bool, error)
+	ObjectFetch(app, key string) (io.ReadCloser, error)
 	RepositoryAuth(app string) (string, string, error)
 	RepositoryHost(app string) (string, bool, error)
 	Resolver() (string, error)
@@ -22,3 +25,9 @@ type Engine interface {
 	SystemHost() string
 	SystemStatus() (string, error)
 }
+
+// VolumeAccessEngine is implemented by engines whose storage does not support the default
+// access mode for volumes
+type VolumeAccessEngine interface {
+	VolumeAccessDefault() string
+}
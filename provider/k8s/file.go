@@ -1,14 +1,70 @@
 package k8s
 
 import (
+	"bufio"
+	"bytes"
+	"fmt"
 	"io"
 	"io/ioutil"
+	"strings"
 
+	"github.com/convox/convox/pkg/structs"
 	ac "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
 )
 
+// FilesChecksums returns the sha256 of every file below path as computed inside the process
+func (p *Provider) FilesChecksums(app, pid, path string) (structs.FileChecksums, error) {
+	// find reads arguments that start with - as expressions, an absolute path can not be one
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("path must be absolute: %s", path)
+	}
+
+	req := p.Cluster.CoreV1().RESTClient().Post().Resource("pods").Name(pid).Namespace(p.AppNamespace(app)).SubResource("exec").Param("container", "main")
+
+	eo := &ac.PodExecOptions{
+		Container: "main",
+		Command:   []string{"find", path, "-type", "f", "-exec", "sha256sum", "{}", "+"},
+		Stdout:    true,
+	}
+
+	req.VersionedParams(eo, scheme.ParameterCodec)
+
+	e, err := remotecommand.NewSPDYExecutor(p.Config, "POST", req.URL())
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	// find exits nonzero when path does not exist which leaves no checksums
+	if err := e.Stream(remotecommand.StreamOptions{Stdout: &buf}); err != nil {
+		if _, ok := err.(exec.ExitError); !ok {
+			return nil, err
+		}
+	}
+
+	fs := structs.FileChecksums{}
+
+	s := bufio.NewScanner(&buf)
+
+	for s.Scan() {
+		parts := strings.SplitN(s.Text(), "  ", 2)
+
+		if len(parts) == 2 {
+			fs = append(fs, structs.FileChecksum{Checksum: parts[0], Path: parts[1]})
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return fs, nil
+}
+
 func (p *Provider) FilesDelete(app, pid string, files []string) error {
 	req := p.Cluster.CoreV1().RESTClient().Post().Resource("pods").Name(pid).Namespace(p.AppNamespace(app)).SubResource("exec").Param("container", "main")
 
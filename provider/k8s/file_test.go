@@ -0,0 +1,16 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilesChecksumsRelative(t *testing.T) {
+	p := &Provider{Name: "rack1"}
+
+	for _, path := range []string{"-delete", "-fprint /x", "app", ""} {
+		_, err := p.FilesChecksums("app1", "pid1", path)
+		require.EqualError(t, err, "path must be absolute: "+path)
+	}
+}
@@ -0,0 +1,195 @@
+package k8s
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/convox/convox/pkg/common"
+	"github.com/convox/convox/pkg/structs"
+	ac "k8s.io/api/core/v1"
+	am "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/transport/spdy"
+)
+
+func (p *Provider) ProcessForward(app, pid string, port int, rw io.ReadWriter, opts structs.ProcessForwardOptions) error {
+	pd, err := p.Cluster.CoreV1().Pods(p.AppNamespace(app)).Get(pid, am.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if pd.Status.Phase != ac.PodRunning {
+		return fmt.Errorf("process is not running: %s", pid)
+	}
+
+	return p.podForward(*pd, port, rw, common.DefaultString(opts.Protocol, "tcp"))
+}
+
+func (p *Provider) ServiceForward(app, name string, port int, rw io.ReadWriter, opts structs.ServiceForwardOptions) error {
+	pd, err := p.serviceForwardPod(app, name)
+	if err != nil {
+		return err
+	}
+
+	return p.podForward(*pd, port, rw, common.DefaultString(opts.Protocol, "tcp"))
+}
+
+func (p *Provider) podForward(pd ac.Pod, port int, rw io.ReadWriter, protocol string) error {
+	switch protocol {
+	case "tcp":
+		return p.podForwardTCP(pd, port, rw)
+	case "udp":
+		return p.podForwardUDP(pd, port, rw)
+	default:
+		return fmt.Errorf("unknown protocol: %s", protocol)
+	}
+}
+
+// podForwardTCP streams rw to a port on the pod using the kubernetes port-forward api
+func (p *Provider) podForwardTCP(pd ac.Pod, port int, rw io.ReadWriter) error {
+	req := p.Cluster.CoreV1().RESTClient().Post().Resource("pods").Name(pd.Name).Namespace(pd.Namespace).SubResource("portforward")
+
+	transport, upgrader, err := spdy.RoundTripperFor(p.Config)
+	if err != nil {
+		return err
+	}
+
+	cn, _, err := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", req.URL()).Dial("portforward.k8s.io")
+	if err != nil {
+		return err
+	}
+	defer cn.Close()
+
+	h := http.Header{}
+	h.Set(ac.PortHeader, strconv.Itoa(port))
+	h.Set(ac.PortForwardRequestIDHeader, "0")
+	h.Set(ac.StreamType, ac.StreamTypeError)
+
+	es, err := cn.CreateStream(h)
+	if err != nil {
+		return err
+	}
+
+	// the error stream is read only
+	es.Close()
+
+	errch := make(chan error, 1)
+
+	go func() {
+		data, err := ioutil.ReadAll(es)
+
+		switch {
+		case err != nil:
+			errch <- err
+		case len(data) > 0:
+			errch <- fmt.Errorf("%s", data)
+		default:
+			errch <- nil
+		}
+	}()
+
+	h.Set(ac.StreamType, ac.StreamTypeData)
+
+	ds, err := cn.CreateStream(h)
+	if err != nil {
+		return err
+	}
+	defer ds.Reset()
+
+	if err := common.Pipe(ds, rw); err != nil {
+		return err
+	}
+
+	select {
+	case err := <-errch:
+		return err
+	default:
+		return nil
+	}
+}
+
+// podForwardUDP relays datagrams framed with common.WriteDatagram to a port
+// on the pod, port-forward only carries tcp so these go directly to the pod ip
+func (p *Provider) podForwardUDP(pd ac.Pod, port int, rw io.ReadWriter) error {
+	if pd.Status.PodIP == "" {
+		return fmt.Errorf("no ip for process: %s", pd.Name)
+	}
+
+	cn, err := net.Dial("udp", net.JoinHostPort(pd.Status.PodIP, strconv.Itoa(port)))
+	if err != nil {
+		return err
+	}
+	defer cn.Close()
+
+	go func() {
+		buf := make([]byte, common.MaxDatagram)
+
+		for {
+			n, err := cn.Read(buf)
+			if err != nil {
+				return
+			}
+
+			if err := common.WriteDatagram(rw, buf[0:n]); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		data, err := common.ReadDatagram(rw)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if _, err := cn.Write(data); err != nil {
+			return err
+		}
+	}
+}
+
+// serviceForwardPod picks the newest running and ready pod for a service so
+// that new connections follow the service as its pods are replaced
+func (p *Provider) serviceForwardPod(app, service string) (*ac.Pod, error) {
+	pds, err := p.Cluster.CoreV1().Pods(p.AppNamespace(app)).List(am.ListOptions{
+		LabelSelector: fmt.Sprintf("service=%s,type=service", service),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ready := []ac.Pod{}
+
+	for _, pd := range pds.Items {
+		if pd.DeletionTimestamp == nil && pd.Status.Phase == ac.PodRunning && podReady(pd) {
+			ready = append(ready, pd)
+		}
+	}
+
+	if len(ready) == 0 {
+		return nil, fmt.Errorf("no healthy processes for service: %s", service)
+	}
+
+	sort.Slice(ready, func(i, j int) bool {
+		return ready[i].CreationTimestamp.After(ready[j].CreationTimestamp.Time)
+	})
+
+	return &ready[0], nil
+}
+
+func podReady(pd ac.Pod) bool {
+	for _, cd := range pd.Status.Conditions {
+		if cd.Type == ac.PodReady {
+			return cd.Status == ac.ConditionTrue
+		}
+	}
+
+	return false
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/convox/convox/pkg/manifest"
 	"github.com/convox/convox/pkg/structs"
 	cv "github.com/convox/convox/provider/k8s/pkg/client/clientset/versioned"
+	"golang.org/x/crypto/bcrypt"
 	ae "k8s.io/apimachinery/pkg/api/errors"
 	am "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -28,13 +29,57 @@ func (p *Provider) convoxClient() (cv.Interface, error) {
 	return cv.NewForConfig(p.Config)
 }
 
-func (p *Provider) ingressSecrets(a *structs.App, ss manifest.Services) (map[string]string, error) {
-	domains := map[string]bool{}
+// ingressAuth returns the basic auth credentials for each service that requires them as user:hash
+// pairs, the hash is the bcrypt of the password so that passwords are not stored on the ingress
+func ingressAuth(e structs.Environment, ss manifest.Services) (map[string]string, error) {
+	auth := map[string]string{}
 
 	for _, s := range ss {
-		for _, d := range s.Domains {
-			domains[d] = false
+		if s.Access.Auth != "basic" {
+			continue
+		}
+
+		if e["BASIC_AUTH"] == "" {
+			return nil, fmt.Errorf("service %s requires BASIC_AUTH for basic auth", s.Name)
 		}
+
+		creds := []string{}
+
+		for _, c := range strings.Split(e["BASIC_AUTH"], ",") {
+			parts := strings.SplitN(strings.TrimSpace(c), ":", 2)
+			if len(parts) != 2 || parts[0] == "" {
+				return nil, fmt.Errorf("invalid BASIC_AUTH, expected user:password")
+			}
+
+			hash, err := bcrypt.GenerateFromPassword([]byte(parts[1]), bcrypt.DefaultCost)
+			if err != nil {
+				return nil, err
+			}
+
+			creds = append(creds, fmt.Sprintf("%s:%s", parts[0], hash))
+		}
+
+		auth[s.Name] = strings.Join(creds, ",")
+	}
+
+	return auth, nil
+}
+
+func (p *Provider) ingressSecrets(a *structs.App, ss manifest.Services) (map[string]string, error) {
+	ds := []string{}
+
+	for _, s := range ss {
+		ds = append(ds, s.Domains...)
+	}
+
+	return p.domainSecrets(a, ds)
+}
+
+func (p *Provider) domainSecrets(a *structs.App, ds []string) (map[string]string, error) {
+	domains := map[string]bool{}
+
+	for _, d := range ds {
+		domains[d] = false
 	}
 
 	cs, err := p.CertificateList()
@@ -182,7 +227,8 @@ func extractImageManifest(r io.Reader) (imageManifest, error) {
 	return nil, fmt.Errorf("unable to locate manifest")
 }
 
-func systemVolume(v string) bool {
+// SystemVolume is true for host paths that are mounted into containers directly
+func SystemVolume(v string) bool {
 	switch v {
 	case "/var/run/docker.sock":
 		return true
@@ -192,13 +238,13 @@ func systemVolume(v string) bool {
 	return false
 }
 
-func (p *Provider) volumeFrom(app, service, v string) string {
-	if from := strings.Split(v, ":")[0]; systemVolume(from) {
-		return from
-	} else if strings.Contains(v, ":") {
-		return path.Join("/mnt/volumes", app, "app", from)
+func (p *Provider) volumeFrom(app, service string, v manifest.ServiceVolume) string {
+	if SystemVolume(v.Name) {
+		return v.Name
+	} else if v.Shared {
+		return path.Join("/mnt/volumes", app, "app", v.Name)
 	} else {
-		return path.Join("/mnt/volumes", app, "service", service, from)
+		return path.Join("/mnt/volumes", app, "service", service, v.Name)
 	}
 }
 
@@ -211,7 +257,7 @@ func (p *Provider) volumeName(app, v string) string {
 	return name
 }
 
-func (p *Provider) volumeSources(app, service string, vs []string) []string {
+func (p *Provider) volumeSources(app, service string, vs manifest.ServiceVolumes) []string {
 	vsh := map[string]bool{}
 
 	for _, v := range vs {
@@ -228,14 +274,3 @@ func (p *Provider) volumeSources(app, service string, vs []string) []string {
 
 	return vsu
 }
-
-func volumeTo(v string) (string, error) {
-	switch parts := strings.SplitN(v, ":", 2); len(parts) {
-	case 1:
-		return parts[0], nil
-	case 2:
-		return parts[1], nil
-	default:
-		return "", fmt.Errorf("invalid volume %q", v)
-	}
-}
@@ -145,6 +145,10 @@ func (p *Provider) Start() error {
 
 	go common.Tick(1*time.Hour, p.heartbeat)
 
+	if err := p.Workers(); err != nil {
+		return log.Error(err)
+	}
+
 	return log.Success()
 }
 
@@ -3,6 +3,7 @@ package k8s_test
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -128,6 +129,14 @@ func (te *TestEngine) ManifestValidate(m *manifest.Manifest) error {
 	return nil
 }
 
+func (te *TestEngine) ObjectExists(app, key string) (bool, error) {
+	return false, nil
+}
+
+func (te *TestEngine) ObjectFetch(app, key string) (io.ReadCloser, error) {
+	return nil, fmt.Errorf("object not found: %s", key)
+}
+
 func (te *TestEngine) RepositoryAuth(app string) (string, string, error) {
 	return "un1", "pw1", nil
 }
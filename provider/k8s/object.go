@@ -98,3 +98,13 @@ func generateTempKey() (string, error) {
 
 	return fmt.Sprintf("tmp/%s", hex.EncodeToString(hash[:])[0:30]), nil
 }
+
+// objectStorage returns the provider that owns object storage so that objects written
+// by the rack itself land in the same place as other app objects on wrapped providers
+func (p *Provider) objectStorage() structs.Provider {
+	if sp, ok := p.Engine.(structs.Provider); ok {
+		return sp
+	}
+
+	return p
+}
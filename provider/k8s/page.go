@@ -0,0 +1,74 @@
+package k8s
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/convox/convox/pkg/structs"
+)
+
+const (
+	pageSizeMax = 256 * 1024
+)
+
+// appPages returns the custom pages that have been stored for an app by name
+func (p *Provider) appPages(app string) (map[string]string, error) {
+	pages := map[string]string{}
+
+	for _, name := range structs.AppPages {
+		key := structs.AppPageKey(name)
+
+		exists, err := p.Engine.ObjectExists(app, key)
+		if err != nil {
+			return nil, err
+		}
+
+		if !exists {
+			continue
+		}
+
+		r, err := p.Engine.ObjectFetch(app, key)
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+
+		data, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(data) > pageSizeMax {
+			return nil, fmt.Errorf("page %s is larger than %dKB", name, pageSizeMax/1024)
+		}
+
+		pages[name] = string(data)
+	}
+
+	return pages, nil
+}
+
+// pagesHash changes when any page changes so that the router reloads them
+func pagesHash(pages map[string]string) string {
+	if len(pages) == 0 {
+		return ""
+	}
+
+	names := []string{}
+
+	for name := range pages {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	h := sha256.New()
+
+	for _, name := range names {
+		fmt.Fprintf(h, "%s=%s\n", name, pages[name])
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil))[0:12]
+}
@@ -60,5 +60,6 @@ type ReleaseSpec struct {
 	Build    string `json:"build"`
 	Created  string `json:"created"`
 	Env      string `json:"env"`
+	EnvMeta  string `json:"envMeta,omitempty"`
 	Manifest string `json:"manifest"`
 }
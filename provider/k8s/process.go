@@ -8,10 +8,12 @@ import (
 	"time"
 
 	"github.com/convox/convox/pkg/common"
+	"github.com/convox/convox/pkg/manifest"
 	"github.com/convox/convox/pkg/options"
 	"github.com/convox/convox/pkg/structs"
 	shellquote "github.com/kballard/go-shellquote"
 	ac "k8s.io/api/core/v1"
+	ae "k8s.io/apimachinery/pkg/api/errors"
 	am "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/remotecommand"
@@ -95,6 +97,11 @@ func (p *Provider) ProcessExec(app, pid, command string, rw io.ReadWriter, opts
 
 func (p *Provider) ProcessGet(app, pid string) (*structs.Process, error) {
 	pd, err := p.Cluster.CoreV1().Pods(p.AppNamespace(app)).Get(pid, am.GetOptions{})
+	if ae.IsNotFound(err) {
+		if ps, herr := p.processHistoryGet(app, pid); herr == nil && ps != nil {
+			return ps, nil
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -108,6 +115,10 @@ func (p *Provider) ProcessGet(app, pid string) (*structs.Process, error) {
 }
 
 func (p *Provider) ProcessList(app string, opts structs.ProcessListOptions) (structs.Processes, error) {
+	if common.DefaultBool(opts.History, false) {
+		return p.processHistoryList(app, opts)
+	}
+
 	filters := []string{
 		"type!=resource",
 	}
@@ -164,6 +175,13 @@ func (p *Provider) streamProcessLogs(w io.WriteCloser, app, pid string, opts str
 
 	for {
 		pp, err := p.Cluster.CoreV1().Pods(p.AppNamespace(app)).Get(pid, am.GetOptions{})
+		if ae.IsNotFound(err) {
+			if ok, herr := p.processHistoryLogs(w, app, pid); herr != nil {
+				fmt.Printf("err: %+v\n", herr)
+			} else if ok {
+				return
+			}
+		}
 		if err != nil {
 			fmt.Printf("err: %+v\n", err)
 			break
@@ -247,11 +265,17 @@ func (p *Provider) ProcessRun(app, service string, opts structs.ProcessRunOption
 		release = a.Release
 	}
 
+	annotations := map[string]string{
+		// "iam.amazonaws.com/role": ns.ObjectMeta.Annotations["convox.aws.role"],
+	}
+
+	if opts.Identity != nil {
+		annotations["convox.com/identity"] = *opts.Identity
+	}
+
 	pd, err := p.Cluster.CoreV1().Pods(p.AppNamespace(app)).Create(&ac.Pod{
 		ObjectMeta: am.ObjectMeta{
-			Annotations: map[string]string{
-				// "iam.amazonaws.com/role": ns.ObjectMeta.Annotations["convox.aws.role"],
-			},
+			Annotations:  annotations,
 			GenerateName: fmt.Sprintf("%s-", service),
 			Labels: map[string]string{
 				"app":     app,
@@ -299,6 +323,10 @@ func (p *Provider) ProcessWait(app, pid string) (int, error) {
 		}
 
 		if t := cs[0].State.Terminated; t != nil {
+			if err := p.processHistoryRecord(*pd); err != nil {
+				fmt.Printf("err: %+v\n", err)
+			}
+
 			if err := p.ProcessStop(app, pid); err != nil {
 				return 0, err
 			}
@@ -361,9 +389,13 @@ func (p *Provider) podSpecFromService(app, service, release string) (*ac.PodSpec
 			env[k] = v
 		}
 
-		e := structs.Environment{}
+		re, err := structs.NewEnvironment([]byte(r.Env))
+		if err != nil {
+			return nil, err
+		}
 
-		if err := e.Load([]byte(r.Env)); err != nil {
+		e, _, err := p.secretEnvironment(app, re)
+		if err != nil {
 			return nil, err
 		}
 
@@ -395,6 +427,10 @@ func (p *Provider) podSpecFromService(app, service, release string) (*ac.PodSpec
 				}
 
 				env[fmt.Sprintf("%s_URL", envName(r))] = cm.Data["URL"]
+
+				if url, ok := cm.Data["READ_URL"]; ok {
+					env[fmt.Sprintf("%s_READ_URL", envName(r))] = url
+				}
 			}
 
 			repo, _, err := p.Engine.RepositoryHost(app)
@@ -409,14 +445,9 @@ func (p *Provider) podSpecFromService(app, service, release string) (*ac.PodSpec
 			}
 
 			for _, v := range s.Volumes {
-				to, err := volumeTo(v)
-				if err != nil {
-					return nil, err
-				}
-
 				c.VolumeMounts = append(c.VolumeMounts, ac.VolumeMount{
 					Name:      p.volumeName(app, p.volumeFrom(app, s.Name, v)),
-					MountPath: to,
+					MountPath: v.Path,
 				})
 			}
 		}
@@ -470,10 +501,10 @@ func (p *Provider) podSpecFromRunOptions(app, service string, opts structs.Proce
 	}
 
 	if opts.Volumes != nil {
-		vs := []string{}
+		vs := manifest.ServiceVolumes{}
 
 		for from, to := range opts.Volumes {
-			vs = append(vs, fmt.Sprintf("%s:%s", from, to))
+			vs = append(vs, manifest.ServiceVolume{Name: from, Path: to, Shared: true})
 		}
 
 		for _, v := range p.volumeSources(app, service, vs) {
@@ -481,14 +512,9 @@ func (p *Provider) podSpecFromRunOptions(app, service string, opts structs.Proce
 		}
 
 		for _, v := range vs {
-			to, err := volumeTo(v)
-			if err != nil {
-				return nil, err
-			}
-
 			s.Containers[0].VolumeMounts = append(s.Containers[0].VolumeMounts, ac.VolumeMount{
 				Name:      p.volumeName(app, p.volumeFrom(app, service, v)),
-				MountPath: to,
+				MountPath: v.Path,
 			})
 		}
 	}
@@ -508,7 +534,7 @@ func (p *Provider) podVolume(app, from string) ac.Volume {
 		},
 	}
 
-	if systemVolume(from) {
+	if SystemVolume(from) {
 		v.VolumeSource = ac.VolumeSource{
 			HostPath: &ac.HostPathVolumeSource{
 				Path: from,
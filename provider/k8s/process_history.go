@@ -0,0 +1,234 @@
+package k8s
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+
+	"github.com/convox/convox/pkg/structs"
+	ac "k8s.io/api/core/v1"
+	ae "k8s.io/apimachinery/pkg/api/errors"
+	am "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	processHistoryLimit    = 100
+	processHistoryLogLines = 1000
+)
+
+func processHistoryLogKey(pid string) string {
+	return fmt.Sprintf("process-history/%s.log", pid)
+}
+
+// each recorded process is kept in its own ConfigMap so that the pod controller and
+// ProcessWait on any api replica can record the same process without losing updates
+func processHistoryName(pid string) string {
+	return fmt.Sprintf("process-history-%s", pid)
+}
+
+// processHistory returns the recorded one-off processes for an app, newest first
+func (p *Provider) processHistory(app string) (structs.Processes, error) {
+	cms, err := p.Cluster.CoreV1().ConfigMaps(p.AppNamespace(app)).List(am.ListOptions{LabelSelector: "type=process-history"})
+	if err != nil {
+		return nil, err
+	}
+
+	pss := structs.Processes{}
+
+	for _, cm := range cms.Items {
+		var ps structs.Process
+
+		if err := json.Unmarshal([]byte(cm.Data["process"]), &ps); err != nil {
+			return nil, err
+		}
+
+		pss = append(pss, ps)
+	}
+
+	sort.Slice(pss, func(i, j int) bool { return pss[i].Started.After(pss[j].Started) })
+
+	return pss, nil
+}
+
+func (p *Provider) processHistoryGet(app, pid string) (*structs.Process, error) {
+	cm, err := p.Cluster.CoreV1().ConfigMaps(p.AppNamespace(app)).Get(processHistoryName(pid), am.GetOptions{})
+	if ae.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ps structs.Process
+
+	if err := json.Unmarshal([]byte(cm.Data["process"]), &ps); err != nil {
+		return nil, err
+	}
+
+	return &ps, nil
+}
+
+// processHistoryLogs writes the retained log tail of a completed process
+func (p *Provider) processHistoryLogs(w io.Writer, app, pid string) (bool, error) {
+	ob := p.objectStorage()
+
+	exists, err := ob.ObjectExists(app, processHistoryLogKey(pid))
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		return false, nil
+	}
+
+	r, err := ob.ObjectFetch(app, processHistoryLogKey(pid))
+	if err != nil {
+		return false, err
+	}
+	defer r.Close()
+
+	if _, err := io.Copy(w, r); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// processHistoryRecord stores a completed one-off process along with the tail
+// of its logs so that it can be inspected after the pod is removed
+func (p *Provider) processHistoryRecord(pd ac.Pod) error {
+	if pd.ObjectMeta.Labels["type"] != "process" {
+		return nil
+	}
+
+	app := pd.ObjectMeta.Labels["app"]
+	pid := pd.ObjectMeta.Name
+
+	ps, err := processFromPod(pd)
+	if err != nil {
+		return err
+	}
+
+	ps.Identity = pd.ObjectMeta.Annotations["convox.com/identity"]
+
+	for _, cs := range pd.Status.ContainerStatuses {
+		if t := cs.State.Terminated; cs.Name == "main" && t != nil {
+			code := int(t.ExitCode)
+			ended := t.FinishedAt.Time
+			ps.ExitCode = &code
+			ps.Ended = &ended
+			ps.Status = "complete"
+
+			if code != 0 {
+				ps.Status = "failed"
+			}
+		}
+	}
+
+	if ps.ExitCode == nil {
+		return nil
+	}
+
+	if h, err := p.processHistoryGet(app, pid); err != nil || h != nil {
+		return err
+	}
+
+	if _, err := p.objectStorage().ObjectStore(app, processHistoryLogKey(pid), bytes.NewReader(p.processHistoryLogTail(pd)), structs.ObjectStoreOptions{}); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(ps)
+	if err != nil {
+		return err
+	}
+
+	cm := &ac.ConfigMap{
+		ObjectMeta: am.ObjectMeta{
+			Name: processHistoryName(pid),
+			Labels: map[string]string{
+				"app":  app,
+				"type": "process-history",
+			},
+		},
+		Data: map[string]string{
+			"process": string(data),
+		},
+	}
+
+	if _, err := p.Cluster.CoreV1().ConfigMaps(p.AppNamespace(app)).Create(cm); err != nil {
+		if ae.IsAlreadyExists(err) {
+			return nil
+		}
+		return err
+	}
+
+	return p.processHistoryPrune(app)
+}
+
+// processHistoryPrune removes the oldest recorded processes beyond the limit
+func (p *Provider) processHistoryPrune(app string) error {
+	pss, err := p.processHistory(app)
+	if err != nil {
+		return err
+	}
+
+	if len(pss) <= processHistoryLimit {
+		return nil
+	}
+
+	for _, h := range pss[processHistoryLimit:] {
+		if err := p.Cluster.CoreV1().ConfigMaps(p.AppNamespace(app)).Delete(processHistoryName(h.Id), nil); err != nil && !ae.IsNotFound(err) {
+			return err
+		}
+
+		if err := p.objectStorage().ObjectDelete(app, processHistoryLogKey(h.Id)); err != nil {
+			fmt.Printf("ns=k8s at=process.history.prune app=%s pid=%s error=%q\n", app, h.Id, err)
+		}
+	}
+
+	return nil
+}
+
+// processHistoryLogTail returns the last lines of a pod's logs, a pod whose logs
+// can no longer be read is still recorded without them
+func (p *Provider) processHistoryLogTail(pd ac.Pod) []byte {
+	lines := int64(processHistoryLogLines)
+
+	r, err := p.Cluster.CoreV1().Pods(pd.ObjectMeta.Namespace).GetLogs(pd.ObjectMeta.Name, &ac.PodLogOptions{TailLines: &lines}).Stream()
+	if err != nil {
+		return []byte{}
+	}
+	defer r.Close()
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return []byte{}
+	}
+
+	return data
+}
+
+func (p *Provider) processHistoryList(app string, opts structs.ProcessListOptions) (structs.Processes, error) {
+	pss, err := p.processHistory(app)
+	if err != nil {
+		return nil, err
+	}
+
+	fpss := structs.Processes{}
+
+	for _, ps := range pss {
+		if opts.Release != nil && ps.Release != *opts.Release {
+			continue
+		}
+
+		if opts.Service != nil && ps.Name != *opts.Service {
+			continue
+		}
+
+		fpss = append(fpss, ps)
+	}
+
+	return fpss, nil
+}
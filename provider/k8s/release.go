@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/convox/convox/pkg/common"
+	"github.com/convox/convox/pkg/diff"
 	"github.com/convox/convox/pkg/manifest"
 	"github.com/convox/convox/pkg/options"
 	"github.com/convox/convox/pkg/structs"
@@ -33,6 +34,15 @@ func (p *Provider) ReleaseCreate(app string, opts structs.ReleaseCreateOptions)
 		r.Env = *opts.Env
 	}
 
+	if opts.EnvMeta != nil {
+		m, err := structs.NewEnvironmentMeta([]byte(*opts.EnvMeta))
+		if err != nil {
+			return nil, fmt.Errorf("invalid env-meta: %s", err)
+		}
+
+		r.EnvMeta = m.String()
+	}
+
 	if r.Build != "" {
 		b, err := p.BuildGet(app, r.Build)
 		if err != nil {
@@ -50,6 +60,44 @@ func (p *Provider) ReleaseCreate(app string, opts structs.ReleaseCreateOptions)
 	return ro, nil
 }
 
+func (p *Provider) ReleaseDiff(app, id string, opts structs.ReleaseDiffOptions) (*structs.ReleaseDiff, error) {
+	to, err := p.releaseGet(app, id)
+	if err != nil {
+		return nil, err
+	}
+
+	fid := common.DefaultString(opts.From, "")
+
+	if fid == "" {
+		a, err := p.AppGet(app)
+		if err != nil {
+			return nil, err
+		}
+
+		fid = a.Release
+	}
+
+	from := &structs.Release{App: app}
+
+	if fid != "" {
+		from, err = p.releaseGet(app, fid)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var b *structs.Build
+
+	if to.Build != "" && to.Build != from.Build {
+		b, err = p.BuildGet(app, to.Build)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return diff.Releases(from, to, b, common.DefaultBool(opts.Reveal, false))
+}
+
 func (p *Provider) ReleaseGet(app, id string) (*structs.Release, error) {
 	r, err := p.releaseGet(app, id)
 	if err != nil {
@@ -86,6 +134,9 @@ func (p *Provider) ReleasePromote(app, id string, opts structs.ReleasePromoteOpt
 
 	items := [][]byte{}
 
+	// digests of the secret values resolved for this release
+	var digests map[string]string
+
 	// app
 	data, err := p.releaseTemplateApp(a, opts)
 	if err != nil {
@@ -110,11 +161,18 @@ func (p *Provider) ReleasePromote(app, id string, opts structs.ReleasePromoteOpt
 			return err
 		}
 
-		e, err := structs.NewEnvironment([]byte(r.Env))
+		re, err := structs.NewEnvironment([]byte(r.Env))
 		if err != nil {
 			return err
 		}
 
+		e, sd, err := p.secretEnvironment(app, re)
+		if err != nil {
+			return err
+		}
+
+		digests = sd
+
 		// balancers
 		for _, b := range m.Balancers {
 			data, err := p.releaseTemplateBalancer(a, r, b)
@@ -125,9 +183,34 @@ func (p *Provider) ReleasePromote(app, id string, opts structs.ReleasePromoteOpt
 			items = append(items, data)
 		}
 
+		// pages
+		pages, err := p.appPages(a.Name)
+		if err != nil {
+			return err
+		}
+
+		if len(pages) > 0 {
+			data, err := p.releaseTemplatePages(a, pages)
+			if err != nil {
+				return err
+			}
+
+			items = append(items, data)
+		}
+
 		// ingress
 		if rss := m.Services.Routable().External(); len(rss) > 0 {
-			data, err := p.releaseTemplateIngress(a, rss, opts)
+			data, err := p.releaseTemplateIngress(a, e, rss, m.Routes, pages, opts)
+			if err != nil {
+				return err
+			}
+
+			items = append(items, data)
+		}
+
+		// routes
+		if len(m.Routes) > 0 {
+			data, err := p.releaseTemplateRoutes(a, e, m, pages, opts)
 			if err != nil {
 				return err
 			}
@@ -136,8 +219,17 @@ func (p *Provider) ReleasePromote(app, id string, opts structs.ReleasePromoteOpt
 		}
 
 		// resources
+		if err := p.releaseResourcesPrepare(a, m, id, common.DefaultBool(opts.Force, false)); err != nil {
+			return err
+		}
+
 		for _, r := range m.Resources {
-			data, err := p.releaseTemplateResource(a, r)
+			volume, err := p.resourceVolume(app, r)
+			if err != nil {
+				return err
+			}
+
+			data, err := p.releaseTemplateResource(a, r, volume)
 			if err != nil {
 				return err
 			}
@@ -185,6 +277,12 @@ func (p *Provider) ReleasePromote(app, id string, opts structs.ReleasePromoteOpt
 		return err
 	}
 
+	if digests != nil {
+		if err := p.secretDigestsSave(app, digests); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -231,6 +329,7 @@ func (p *Provider) releaseFork(app string) (*structs.Release, error) {
 	if len(rs) > 0 {
 		r.Build = rs[0].Build
 		r.Env = rs[0].Env
+		r.EnvMeta = rs[0].EnvMeta
 	}
 
 	return r, nil
@@ -276,6 +375,7 @@ func (p *Provider) releaseMarshal(r *structs.Release) *ca.Release {
 			Build:    r.Build,
 			Created:  r.Created.Format(common.SortableTime),
 			Env:      r.Env,
+			EnvMeta:  r.EnvMeta,
 			Manifest: r.Manifest,
 		},
 	}
@@ -283,10 +383,11 @@ func (p *Provider) releaseMarshal(r *structs.Release) *ca.Release {
 
 func (p *Provider) releaseTemplateApp(a *structs.App, opts structs.ReleasePromoteOptions) ([]byte, error) {
 	params := map[string]interface{}{
-		"Locked":     a.Locked,
-		"Name":       a.Name,
-		"Namespace":  p.AppNamespace(a.Name),
-		"Parameters": a.Parameters,
+		"Locked":      a.Locked,
+		"Maintenance": a.Maintenance,
+		"Name":        a.Name,
+		"Namespace":   p.AppNamespace(a.Name),
+		"Parameters":  a.Parameters,
 	}
 
 	data, err := p.RenderTemplate("app/app", params)
@@ -326,12 +427,17 @@ func (p *Provider) releaseTemplateCA(a *structs.App, ca *v1.Secret) ([]byte, err
 	return data, nil
 }
 
-func (p *Provider) releaseTemplateIngress(a *structs.App, ss manifest.Services, opts structs.ReleasePromoteOptions) ([]byte, error) {
+func (p *Provider) releaseTemplateIngress(a *structs.App, e structs.Environment, ss manifest.Services, rs manifest.Routes, pages map[string]string, opts structs.ReleasePromoteOptions) ([]byte, error) {
 	ans, err := p.Engine.IngressAnnotations(a.Name)
 	if err != nil {
 		return nil, err
 	}
 
+	auth, err := ingressAuth(e, ss)
+	if err != nil {
+		return nil, err
+	}
+
 	iss, err := p.ingressSecrets(a, ss)
 	if err != nil {
 		return nil, err
@@ -342,11 +448,24 @@ func (p *Provider) releaseTemplateIngress(a *structs.App, ss manifest.Services,
 		return nil, err
 	}
 
+	// domains with a route at their root are served by the route ingress instead
+	routed := map[string]bool{}
+
+	for _, r := range rs {
+		if r.Path == "/" {
+			routed[r.Domain] = true
+		}
+	}
+
 	params := map[string]interface{}{
 		"Annotations": ans,
 		"App":         a.Name,
+		"Auth":        auth,
 		"Idles":       common.DefaultBool(opts.Idle, idles),
+		"Maintenance": a.Maintenance,
 		"Namespace":   p.AppNamespace(a.Name),
+		"Pages":       pagesHash(pages),
+		"Routed":      routed,
 		"Secrets":     iss,
 		"Services":    ss,
 	}
@@ -359,7 +478,104 @@ func (p *Provider) releaseTemplateIngress(a *structs.App, ss manifest.Services,
 	return data, nil
 }
 
-func (p *Provider) releaseTemplateResource(a *structs.App, r manifest.Resource) ([]byte, error) {
+func (p *Provider) releaseTemplateRoutes(a *structs.App, e structs.Environment, m *manifest.Manifest, pages map[string]string, opts structs.ReleasePromoteOptions) ([]byte, error) {
+	ans, err := p.Engine.IngressAnnotations(a.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// redirects and header rules are applied by the convox router, other ingress controllers ignore them
+	if class := ans["kubernetes.io/ingress.class"]; class != "" && class != "convox" {
+		for _, r := range m.Routes {
+			if r.Rules() {
+				return nil, fmt.Errorf("route %s: redirects and headers require the convox router, ingress class %s does not support them", r, class)
+			}
+		}
+	}
+
+	auth, err := ingressAuth(e, m.Services)
+	if err != nil {
+		return nil, err
+	}
+
+	ds := []string{}
+
+	for _, r := range m.Routes {
+		ds = append(ds, r.Domain)
+	}
+
+	iss, err := p.domainSecrets(a, ds)
+	if err != nil {
+		return nil, err
+	}
+
+	idles, err := p.Engine.AppIdles(a.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	items := [][]byte{}
+
+	for _, r := range m.Routes {
+		hash := sha256.Sum256([]byte(r.String()))
+
+		var s *manifest.Service
+
+		if r.Forward() {
+			if s, err = m.Service(r.Service); err != nil {
+				return nil, err
+			}
+		}
+
+		params := map[string]interface{}{
+			"Annotations": ans,
+			"Auth":        auth,
+			"Idles":       common.DefaultBool(opts.Idle, idles),
+			"Maintenance": a.Maintenance,
+			"Name":        fmt.Sprintf("%s-route-%x", a.Name, hash[0:8]),
+			"Namespace":   p.AppNamespace(a.Name),
+			"Pages":       pagesHash(pages),
+			"Route":       r,
+			"Secret":      iss[r.Domain],
+			"Service":     s,
+		}
+
+		data, err := p.RenderTemplate("app/route", params)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, data)
+	}
+
+	return bytes.Join(items, []byte("---\n")), nil
+}
+
+func (p *Provider) releaseTemplatePages(a *structs.App, pages map[string]string) ([]byte, error) {
+	params := map[string]interface{}{
+		"Namespace": p.AppNamespace(a.Name),
+		"Pages":     pages,
+	}
+
+	data, err := p.RenderTemplate("app/pages", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (p *Provider) releaseTemplateResource(a *structs.App, r manifest.Resource, volume string) ([]byte, error) {
+	ha, err := r.HA()
+	if err != nil {
+		return nil, err
+	}
+
+	replicas, err := r.Replicas()
+	if err != nil {
+		return nil, err
+	}
+
 	params := map[string]interface{}{
 		"App":        a.Name,
 		"Namespace":  p.AppNamespace(a.Name),
@@ -367,9 +583,17 @@ func (p *Provider) releaseTemplateResource(a *structs.App, r manifest.Resource)
 		"Parameters": r.Options,
 		"Password":   fmt.Sprintf("%x", sha256.Sum256([]byte(p.Name)))[0:30],
 		"Rack":       p.Name,
+		"Replicas":   replicas + 1,
+		"Volume":     volume,
 	}
 
-	data, err := p.RenderTemplate(fmt.Sprintf("resource/%s", r.Type), params)
+	template := fmt.Sprintf("resource/%s", r.Type)
+
+	if ha {
+		template = fmt.Sprintf("resource/%s-ha", r.Type)
+	}
+
+	data, err := p.RenderTemplate(template, params)
 	if err != nil {
 		return nil, err
 	}
@@ -453,13 +677,34 @@ func (p *Provider) releaseTemplateServices(a *structs.App, e structs.Environment
 }
 
 func (p *Provider) releaseTemplateTimer(a *structs.App, r *structs.Release, s *manifest.Service, t manifest.Timer) ([]byte, error) {
+	concurrency, err := t.ConcurrencyPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	zones := p.timerTimeZones()
+
+	schedule := t.Schedule
+
+	if !zones {
+		utc, err := timerSchedule(t.Name, t.Schedule, t.Timezone, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		schedule = utc
+	}
+
 	params := map[string]interface{}{
-		"App":       a,
-		"Namespace": p.AppNamespace(a.Name),
-		"Rack":      p.Name,
-		"Release":   r,
-		"Service":   s,
-		"Timer":     t,
+		"App":         a,
+		"Concurrency": concurrency,
+		"Namespace":   p.AppNamespace(a.Name),
+		"Rack":        p.Name,
+		"Release":     r,
+		"Schedule":    schedule,
+		"Service":     s,
+		"Timer":       t,
+		"TimeZones":   zones,
 	}
 
 	if ip, err := p.Engine.Resolver(); err == nil {
@@ -475,20 +720,14 @@ func (p *Provider) releaseTemplateTimer(a *structs.App, r *structs.Release, s *m
 }
 
 func (p *Provider) releaseTemplateVolumes(a *structs.App, ss manifest.Services) ([]byte, error) {
-	vsh := map[string]bool{}
+	vs := p.appVolumes(a.Name, ss)
 
-	for _, s := range ss {
-		for _, v := range p.volumeSources(a.Name, s.Name, s.Volumes) {
-			if !systemVolume(v) {
-				vsh[v] = true
-			}
-		}
+	if err := p.volumesCheck(a.Name, vs); err != nil {
+		return nil, err
 	}
 
-	vs := []string{}
-
-	for s := range vsh {
-		vs = append(vs, s)
+	if err := p.volumesSnapshots(a.Name, vs); err != nil {
+		return nil, err
 	}
 
 	params := map[string]interface{}{
@@ -517,6 +756,7 @@ func (p *Provider) releaseUnmarshal(kr *ca.Release) (*structs.Release, error) {
 		Build:    kr.Spec.Build,
 		Created:  created,
 		Env:      kr.Spec.Env,
+		EnvMeta:  kr.Spec.EnvMeta,
 		Id:       strings.ToUpper(kr.ObjectMeta.Name),
 		Manifest: kr.Spec.Manifest,
 	}
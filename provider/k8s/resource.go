@@ -1,14 +1,22 @@
 package k8s
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/url"
 	"os/exec"
+	"strings"
 
+	"github.com/convox/convox/pkg/manifest"
 	"github.com/convox/convox/pkg/structs"
 	"github.com/creack/pty"
+	ac "k8s.io/api/core/v1"
+	ae "k8s.io/apimachinery/pkg/api/errors"
 	am "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
 )
 
 func (p *Provider) ResourceConsole(app, name string, rw io.ReadWriter, opts structs.ResourceConsoleOptions) error {
@@ -25,12 +33,20 @@ func (p *Provider) ResourceConsole(app, name string, rw io.ReadWriter, opts stru
 	fmt.Printf("cn: %+v\n", cn)
 
 	switch r.Type {
+	case "elasticsearch":
+		fmt.Fprintf(rw, "use curl with $ELASTICSEARCH_URL, for example: curl $ELASTICSEARCH_URL/_cat/indices\n")
+		return p.resourceConsoleExec(app, name, rw, opts, "env", "ELASTICSEARCH_URL=http://127.0.0.1:9200", "bash")
 	case "memcached":
 		return resourceConsoleCommand(rw, opts, "telnet", cn.Host, cn.Port)
+	case "mongodb":
+		return p.resourceConsoleExec(app, name, rw, opts, "mongo", r.Url)
 	case "mysql":
 		return resourceConsoleCommand(rw, opts, "mysql", "-h", cn.Host, "-P", cn.Port, "-u", cn.Username, fmt.Sprintf("-p%s", cn.Password), "-D", cn.Database)
 	case "postgres":
 		return resourceConsoleCommand(rw, opts, "psql", r.Url)
+	case "rabbitmq":
+		fmt.Fprintf(rw, "rabbitmqadmin is configured for this resource, for example: rabbitmqadmin list queues\n")
+		return p.resourceConsoleExec(app, name, rw, opts, "env", "HOME=/etc/rabbitmqadmin", "bash")
 	case "redis":
 		return resourceConsoleCommand(rw, opts, "redis-cli", "-u", r.Url)
 	default:
@@ -44,18 +60,25 @@ func (p *Provider) ResourceExport(app, name string) (io.ReadCloser, error) {
 		return nil, err
 	}
 
-	switch r.Type {
-	case "mysql":
-		return resourceExportMysql(r)
-	case "postgres":
-		return resourceExportPostgres(r)
-	default:
+	if !resourceExportable(r.Type) {
 		return nil, fmt.Errorf("export not available for resources of type: %s", r.Type)
 	}
+
+	rr, ww := io.Pipe()
+
+	go func() {
+		defer ww.Close()
+
+		if err := p.resourceDump(app, r, ww); err != nil {
+			fmt.Fprintf(ww, "ERROR: could not export: %v\n", err)
+		}
+	}()
+
+	return rr, nil
 }
 
 func (p *Provider) ResourceGet(app, name string) (*structs.Resource, error) {
-	d, err := p.Cluster.AppsV1().Deployments(p.AppNamespace(app)).Get(fmt.Sprintf("resource-%s", name), am.GetOptions{})
+	kind, status, err := p.resourceStatus(app, name)
 	if err != nil {
 		return nil, err
 	}
@@ -65,16 +88,10 @@ func (p *Provider) ResourceGet(app, name string) (*structs.Resource, error) {
 		return nil, err
 	}
 
-	status := "running"
-
-	if d.Status.ReadyReplicas < 1 {
-		status = "pending"
-	}
-
 	r := &structs.Resource{
 		Name:   name,
 		Status: status,
-		Type:   d.ObjectMeta.Labels["kind"],
+		Type:   kind,
 		Url:    cm.Data["URL"],
 	}
 
@@ -88,29 +105,33 @@ func (p *Provider) ResourceImport(app, name string, r io.Reader) error {
 	}
 
 	switch rr.Type {
+	case "mongodb":
+		return p.resourceImportExec(app, rr, r, "mongorestore", "--quiet", "--archive", "--drop", fmt.Sprintf("--uri=%s", rr.Url))
 	case "mysql":
 		return resourceImportMysql(rr, r)
 	case "postgres":
 		return resourceImportPostgres(rr, r)
+	case "rabbitmq":
+		return p.resourceImportExec(app, rr, r, "sh", "-c", "cat > /tmp/convox-import.json && rabbitmqctl -q import_definitions /tmp/convox-import.json && rm -f /tmp/convox-import.json")
+	case "redis":
+		return p.resourceImportRedis(app, rr, r)
+	case "s3":
+		return p.resourceImportExec(app, rr, r, "tar", "-C", "/data/app", "-xf", "-")
 	default:
 		return fmt.Errorf("import not available for resources of type: %s", rr.Type)
 	}
 }
 
 func (p *Provider) ResourceList(app string) (structs.Resources, error) {
-	lopts := am.ListOptions{
-		LabelSelector: fmt.Sprintf("app=%s,type=resource", app),
-	}
-
-	ds, err := p.Cluster.AppsV1().Deployments(p.AppNamespace(app)).List(lopts)
+	names, err := p.resourceNames(app)
 	if err != nil {
 		return nil, err
 	}
 
 	rs := structs.Resources{}
 
-	for _, d := range ds.Items {
-		r, err := p.ResourceGet(app, d.ObjectMeta.Labels["resource"])
+	for _, name := range names {
+		r, err := p.ResourceGet(app, name)
 		if err != nil {
 			return nil, err
 		}
@@ -142,7 +163,7 @@ func (p *Provider) SystemResourceList() (structs.Resources, error) {
 }
 
 func (p *Provider) SystemResourceTypes() (structs.ResourceTypes, error) {
-	return nil, fmt.Errorf("unimplemented")
+	return resourceTypes, nil
 }
 
 func (p *Provider) SystemResourceUnlink(name, app string) (*structs.Resource, error) {
@@ -184,6 +205,55 @@ func parseResourceURL(url_ string) (*resourceConnection, error) {
 	return cn, nil
 }
 
+// resourceClone creates a resource in a and copies into it the current data of the resource with the same name in src
+func (p *Provider) resourceClone(src string, a *structs.App, mr manifest.Resource, release string) error {
+	sr, err := p.ResourceGet(src, mr.Name)
+	if err != nil {
+		return err
+	}
+
+	volume, err := p.resourceVolume(a.Name, mr)
+	if err != nil {
+		return err
+	}
+
+	data, err := p.releaseTemplateResource(a, mr, volume)
+	if err != nil {
+		return err
+	}
+
+	ldata, err := ApplyLabels(data, fmt.Sprintf("system=convox,provider=k8s,rack=%s,app=%s,release=%s", p.Name, a.Name, release))
+	if err != nil {
+		return err
+	}
+
+	if err := Apply(ldata); err != nil {
+		return err
+	}
+
+	// resources that can not be exported start empty
+	if sr.Type != mr.Type || !resourceExportable(sr.Type) {
+		return nil
+	}
+
+	if err := p.resourceWait(a.Name, mr); err != nil {
+		return err
+	}
+
+	rr, ww := io.Pipe()
+
+	go func() {
+		ww.CloseWithError(p.resourceDump(src, sr, ww))
+	}()
+
+	if err := p.ResourceImport(a.Name, mr.Name, rr); err != nil {
+		rr.CloseWithError(err)
+		return err
+	}
+
+	return nil
+}
+
 func resourceConsoleCommand(rw io.ReadWriter, opts structs.ResourceConsoleOptions, command string, args ...string) error {
 	cmd := exec.Command(command, args...)
 
@@ -208,38 +278,138 @@ func resourceConsoleCommand(rw io.ReadWriter, opts structs.ResourceConsoleOption
 	return nil
 }
 
-func resourceExportCommand(w io.WriteCloser, command string, args ...string) {
-	defer w.Close()
+// resourceConsoleExec attaches a terminal to a command in the resource container for
+// clients that are shipped with the resource image
+func (p *Provider) resourceConsoleExec(app, name string, rw io.ReadWriter, opts structs.ResourceConsoleOptions, command ...string) error {
+	pd, err := p.resourcePod(app, name)
+	if err != nil {
+		return err
+	}
+
+	req := p.Cluster.CoreV1().RESTClient().Post().Resource("pods").Name(pd.ObjectMeta.Name).Namespace(pd.ObjectMeta.Namespace).SubResource("exec")
+
+	req.VersionedParams(&ac.PodExecOptions{
+		Container: pd.Spec.Containers[0].Name,
+		Command:   command,
+		Stdin:     true,
+		Stdout:    true,
+		Stderr:    true,
+		TTY:       true,
+	}, scheme.ParameterCodec)
+
+	e, err := remotecommand.NewSPDYExecutor(p.Config, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+
+	inr, inw := io.Pipe()
+	go io.Copy(inw, rw)
+
+	sopts := remotecommand.StreamOptions{
+		Stdin:  inr,
+		Stdout: rw,
+		Stderr: rw,
+		Tty:    true,
+	}
+
+	if opts.Height != nil && opts.Width != nil {
+		sopts.TerminalSizeQueue = &terminalSize{Height: *opts.Height, Width: *opts.Width}
+	}
+
+	return e.Stream(sopts)
+}
+
+// resourceDump writes a dump of the resource to w, redis is dumped as an rdb snapshot,
+// rabbitmq as its definitions and s3 as a tarball of the bucket
+func (p *Provider) resourceDump(app string, r *structs.Resource, w io.Writer) error {
+	switch r.Type {
+	case "mongodb":
+		return p.resourceExec(app, r.Name, []string{"mongodump", "--quiet", "--archive", fmt.Sprintf("--uri=%s", r.Url)}, nil, w)
+	case "mysql":
+		cn, err := parseResourceURL(r.Url)
+		if err != nil {
+			return err
+		}
+
+		return resourceCommand(w, "mysqldump", "-h", cn.Host, "-P", cn.Port, "-u", cn.Username, fmt.Sprintf("-p%s", cn.Password), cn.Database)
+	case "postgres":
+		return resourceCommand(w, "pg_dump", "--no-acl", "--no-owner", r.Url)
+	case "rabbitmq":
+		return p.resourceExec(app, r.Name, []string{"sh", "-c", "rabbitmqctl -q export_definitions /tmp/convox-export.json && cat /tmp/convox-export.json && rm -f /tmp/convox-export.json"}, nil, w)
+	case "redis":
+		return p.resourceExec(app, r.Name, []string{"sh", "-c", "redis-cli --rdb /tmp/convox-export.rdb >/dev/null && cat /tmp/convox-export.rdb && rm -f /tmp/convox-export.rdb"}, nil, w)
+	case "s3":
+		return p.resourceExec(app, r.Name, []string{"tar", "-C", "/data/app", "-cf", "-", "."}, nil, w)
+	default:
+		return fmt.Errorf("export not available for resources of type: %s", r.Type)
+	}
+}
+
+func resourceExportable(kind string) bool {
+	switch kind {
+	case "mongodb", "mysql", "postgres", "rabbitmq", "redis", "s3":
+		return true
+	default:
+		return false
+	}
+}
 
+func resourceCommand(w io.Writer, command string, args ...string) error {
 	cmd := exec.Command(command, args...)
 
+	var stderr bytes.Buffer
+
 	cmd.Stdout = w
-	cmd.Stderr = w
+	cmd.Stderr = &stderr
 
 	if err := cmd.Run(); err != nil {
-		fmt.Fprintf(w, "ERROR: could not export: %v\n", err)
+		return fmt.Errorf("%s: %s", err, strings.TrimSpace(stderr.String()))
 	}
+
+	return nil
 }
 
-func resourceExportMysql(r *structs.Resource) (io.ReadCloser, error) {
-	cn, err := parseResourceURL(r.Url)
+// resourceExec runs a command in the container of a running resource pod
+func (p *Provider) resourceExec(app, name string, command []string, stdin io.Reader, stdout io.Writer) error {
+	pd, err := p.resourcePod(app, name)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	rr, ww := io.Pipe()
+	return p.resourcePodExec(pd, command, stdin, stdout)
+}
 
-	go resourceExportCommand(ww, "mysqldump", "-h", cn.Host, "-P", cn.Port, "-u", cn.Username, fmt.Sprintf("-p%s", cn.Password), cn.Database)
+func (p *Provider) resourcePodExec(pd *ac.Pod, command []string, stdin io.Reader, stdout io.Writer) error {
+	req := p.Cluster.CoreV1().RESTClient().Post().Resource("pods").Name(pd.ObjectMeta.Name).Namespace(pd.ObjectMeta.Namespace).SubResource("exec")
 
-	return rr, nil
-}
+	req.VersionedParams(&ac.PodExecOptions{
+		Container: pd.Spec.Containers[0].Name,
+		Command:   command,
+		Stdin:     stdin != nil,
+		Stdout:    stdout != nil,
+		Stderr:    true,
+	}, scheme.ParameterCodec)
 
-func resourceExportPostgres(r *structs.Resource) (io.ReadCloser, error) {
-	rr, ww := io.Pipe()
+	e, err := remotecommand.NewSPDYExecutor(p.Config, "POST", req.URL())
+	if err != nil {
+		return err
+	}
 
-	go resourceExportCommand(ww, "pg_dump", "--no-acl", "--no-owner", r.Url)
+	var stderr bytes.Buffer
 
-	return rr, nil
+	if err := e.Stream(remotecommand.StreamOptions{Stdin: stdin, Stdout: stdout, Stderr: &stderr}); err != nil {
+		return fmt.Errorf("%s: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+func (p *Provider) resourceImportExec(app string, rr *structs.Resource, r io.Reader, command ...string) error {
+	if err := p.resourceExec(app, rr.Name, command, r, ioutil.Discard); err != nil {
+		return fmt.Errorf("import failed: %s", err)
+	}
+
+	return nil
 }
 
 func resourceImportMysql(rr *structs.Resource, r io.Reader) error {
@@ -261,6 +431,22 @@ func resourceImportMysql(rr *structs.Resource, r io.Reader) error {
 	return nil
 }
 
+// resourceImportRedis replaces the rdb snapshot on the resource volume and restarts
+// redis without saving so that it loads the imported data when it comes back
+func (p *Provider) resourceImportRedis(app string, rr *structs.Resource, r io.Reader) error {
+	if err := p.resourceExec(app, rr.Name, []string{"sh", "-c", "cat > /data/convox-import.rdb && mv /data/convox-import.rdb /data/dump.rdb"}, r, nil); err != nil {
+		return fmt.Errorf("import failed: %s", err)
+	}
+
+	// redis loads the imported dump when it is restarted, shutting down without saving
+	// keeps it from overwriting the dump on the way out
+	if err := p.resourceExec(app, rr.Name, []string{"redis-cli", "shutdown", "nosave"}, nil, ioutil.Discard); err != nil {
+		return fmt.Errorf("restart failed: %s", err)
+	}
+
+	return nil
+}
+
 func resourceImportPostgres(rr *structs.Resource, r io.Reader) error {
 	cmd := exec.Command("psql", rr.Url)
 
@@ -274,3 +460,82 @@ func resourceImportPostgres(rr *structs.Resource, r io.Reader) error {
 
 	return nil
 }
+
+// resourcePod returns a running pod for a resource, for ha resources this is the primary
+func (p *Provider) resourcePod(app, name string) (*ac.Pod, error) {
+	pds, err := p.Cluster.CoreV1().Pods(p.AppNamespace(app)).List(am.ListOptions{LabelSelector: fmt.Sprintf("app=%s,resource=%s,type=resource", app, name)})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range pds.Items {
+		if role, ok := pds.Items[i].ObjectMeta.Labels["role"]; ok && role != "primary" {
+			continue
+		}
+
+		if pds.Items[i].Status.Phase == ac.PodRunning && pds.Items[i].ObjectMeta.DeletionTimestamp == nil && len(pds.Items[i].Spec.Containers) > 0 {
+			return &pds.Items[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("resource is not running: %s", name)
+}
+
+// resourceNames returns the names of the deployed resources of an app
+func (p *Provider) resourceNames(app string) ([]string, error) {
+	lopts := am.ListOptions{
+		LabelSelector: fmt.Sprintf("app=%s,type=resource", app),
+	}
+
+	ds, err := p.Cluster.AppsV1().Deployments(p.AppNamespace(app)).List(lopts)
+	if err != nil {
+		return nil, err
+	}
+
+	sss, err := p.Cluster.AppsV1().StatefulSets(p.AppNamespace(app)).List(lopts)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+
+	for _, d := range ds.Items {
+		names = append(names, d.ObjectMeta.Labels["resource"])
+	}
+
+	for _, ss := range sss.Items {
+		names = append(names, ss.ObjectMeta.Labels["resource"])
+	}
+
+	return names, nil
+}
+
+// resourceStatus returns the kind and status of a resource, ha resources run as a StatefulSet
+// and are degraded while any of their replicas are unavailable
+func (p *Provider) resourceStatus(app, name string) (string, string, error) {
+	d, err := p.Cluster.AppsV1().Deployments(p.AppNamespace(app)).Get(fmt.Sprintf("resource-%s", name), am.GetOptions{})
+	if err == nil {
+		if d.Status.ReadyReplicas < 1 {
+			return d.ObjectMeta.Labels["kind"], "pending", nil
+		}
+
+		return d.ObjectMeta.Labels["kind"], "running", nil
+	}
+	if !ae.IsNotFound(err) {
+		return "", "", err
+	}
+
+	ss, err := p.Cluster.AppsV1().StatefulSets(p.AppNamespace(app)).Get(fmt.Sprintf("resource-%s", name), am.GetOptions{})
+	if err != nil {
+		return "", "", err
+	}
+
+	switch {
+	case ss.Status.ReadyReplicas < 1:
+		return ss.ObjectMeta.Labels["kind"], "pending", nil
+	case ss.Spec.Replicas != nil && ss.Status.ReadyReplicas < *ss.Spec.Replicas:
+		return ss.ObjectMeta.Labels["kind"], "degraded", nil
+	default:
+		return ss.ObjectMeta.Labels["kind"], "running", nil
+	}
+}
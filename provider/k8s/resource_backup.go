@@ -0,0 +1,193 @@
+package k8s
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/convox/convox/pkg/common"
+	"github.com/convox/convox/pkg/structs"
+	ac "k8s.io/api/core/v1"
+	ae "k8s.io/apimachinery/pkg/api/errors"
+	am "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (p *Provider) ResourceBackupList(app, name string) (structs.ResourceBackups, error) {
+	if _, err := p.ResourceGet(app, name); err != nil {
+		return nil, err
+	}
+
+	return p.resourceBackups(app, name)
+}
+
+func (p *Provider) ResourceRestore(app, name, id string) error {
+	rbs, err := p.ResourceBackupList(app, name)
+	if err != nil {
+		return err
+	}
+
+	found := false
+
+	for _, rb := range rbs {
+		if rb.Id == id {
+			found = true
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("no such backup: %s", id)
+	}
+
+	r, err := p.objectStorage().ObjectFetch(app, resourceBackupKey(name, id))
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	return p.ResourceImport(app, name, gz)
+}
+
+// resourceBackup stores a compressed dump of the resource and removes the oldest
+// backups beyond retention
+func (p *Provider) resourceBackup(app string, r *structs.Resource, retention int) (*structs.ResourceBackup, error) {
+	ob := p.objectStorage()
+
+	now := time.Now().UTC()
+
+	rb := structs.ResourceBackup{
+		Created: now,
+		Id:      fmt.Sprintf("%s-%s", now.Format("20060102150405"), strings.ToLower(common.Id("", 6))),
+	}
+
+	pr, pw := io.Pipe()
+
+	cw := &resourceBackupCounter{w: pw}
+
+	go func() {
+		gz := gzip.NewWriter(cw)
+
+		err := p.resourceDump(app, r, gz)
+		if err == nil {
+			err = gz.Close()
+		}
+
+		pw.CloseWithError(err)
+	}()
+
+	if _, err := ob.ObjectStore(app, resourceBackupKey(r.Name, rb.Id), pr, structs.ObjectStoreOptions{}); err != nil {
+		pr.CloseWithError(err)
+		ob.ObjectDelete(app, resourceBackupKey(r.Name, rb.Id))
+		return nil, err
+	}
+
+	rb.Size = cw.n
+
+	data, err := json.Marshal(rb)
+	if err != nil {
+		return nil, err
+	}
+
+	cm := &ac.ConfigMap{
+		ObjectMeta: am.ObjectMeta{
+			Name: resourceBackupName(r.Name, rb.Id),
+			Labels: map[string]string{
+				"app":      app,
+				"resource": r.Name,
+				"type":     "resource-backup",
+			},
+		},
+		Data: map[string]string{
+			"backup": string(data),
+		},
+	}
+
+	if _, err := p.Cluster.CoreV1().ConfigMaps(p.AppNamespace(app)).Create(cm); err != nil {
+		ob.ObjectDelete(app, resourceBackupKey(r.Name, rb.Id))
+		return nil, err
+	}
+
+	if err := p.resourceBackupPrune(app, r.Name, retention); err != nil {
+		return nil, err
+	}
+
+	return &rb, nil
+}
+
+// resourceBackupPrune removes the oldest backups of a resource beyond retention
+func (p *Provider) resourceBackupPrune(app, name string, retention int) error {
+	rbs, err := p.resourceBackups(app, name)
+	if err != nil {
+		return err
+	}
+
+	if len(rbs) <= retention {
+		return nil
+	}
+
+	for _, old := range rbs[retention:] {
+		if err := p.Cluster.CoreV1().ConfigMaps(p.AppNamespace(app)).Delete(resourceBackupName(name, old.Id), nil); err != nil && !ae.IsNotFound(err) {
+			return err
+		}
+
+		if err := p.objectStorage().ObjectDelete(app, resourceBackupKey(name, old.Id)); err != nil {
+			fmt.Printf("ns=k8s at=resource.backup app=%s resource=%s id=%s error=%q\n", app, name, old.Id, err)
+		}
+	}
+
+	return nil
+}
+
+// resourceBackups returns the backups of a resource, newest first
+func (p *Provider) resourceBackups(app, name string) (structs.ResourceBackups, error) {
+	cms, err := p.Cluster.CoreV1().ConfigMaps(p.AppNamespace(app)).List(am.ListOptions{LabelSelector: fmt.Sprintf("type=resource-backup,resource=%s", name)})
+	if err != nil {
+		return nil, err
+	}
+
+	rbs := structs.ResourceBackups{}
+
+	for _, cm := range cms.Items {
+		var rb structs.ResourceBackup
+
+		if err := json.Unmarshal([]byte(cm.Data["backup"]), &rb); err != nil {
+			return nil, err
+		}
+
+		rbs = append(rbs, rb)
+	}
+
+	sort.Slice(rbs, func(i, j int) bool { return rbs[i].Created.After(rbs[j].Created) })
+
+	return rbs, nil
+}
+
+// each backup is kept in its own ConfigMap so that the backup worker and a migration
+// running on another api replica can record backups at the same time
+func resourceBackupName(name, id string) string {
+	return fmt.Sprintf("resource-backup-%s-%s", name, id)
+}
+
+func resourceBackupKey(name, id string) string {
+	return fmt.Sprintf("resources/%s/backups/%s.gz", name, id)
+}
+
+type resourceBackupCounter struct {
+	n int64
+	w io.Writer
+}
+
+func (c *resourceBackupCounter) Write(data []byte) (int, error) {
+	n, err := c.w.Write(data)
+	c.n += int64(n)
+	return n, err
+}
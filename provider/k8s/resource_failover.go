@@ -0,0 +1,211 @@
+package k8s
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"time"
+
+	aa "k8s.io/api/apps/v1"
+	ac "k8s.io/api/core/v1"
+	ae "k8s.io/apimachinery/pkg/api/errors"
+	am "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// how long the primary of an ha resource can be unavailable before a replica is promoted
+	resourceFailoverGrace = 60 * time.Second
+)
+
+// workerResourceFailover assigns the primary and replica roles to the pods of ha resources and
+// promotes a ready replica when the primary stays unavailable
+func (p *Provider) workerResourceFailover() error {
+	sss, err := p.Cluster.AppsV1().StatefulSets("").List(am.ListOptions{LabelSelector: fmt.Sprintf("system=convox,rack=%s,type=resource,ha=true", p.Name)})
+	if err != nil {
+		return err
+	}
+
+	for _, ss := range sss.Items {
+		if err := p.resourceFailover(ss); err != nil {
+			fmt.Printf("ns=k8s at=resource.failover namespace=%s resource=%s error=%q\n", ss.Namespace, ss.ObjectMeta.Labels["resource"], err)
+		}
+	}
+
+	return nil
+}
+
+func (p *Provider) resourceFailover(ss aa.StatefulSet) error {
+	app := ss.ObjectMeta.Labels["app"]
+	name := ss.ObjectMeta.Labels["resource"]
+
+	pds, err := p.Cluster.CoreV1().Pods(ss.Namespace).List(am.ListOptions{LabelSelector: fmt.Sprintf("app=%s,resource=%s,type=resource", app, name)})
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(pds.Items, func(i, j int) bool { return pds.Items[i].Name < pds.Items[j].Name })
+
+	primary := ss.ObjectMeta.Annotations["convox.com/primary"]
+
+	if primary == "" {
+		primary = fmt.Sprintf("%s-0", ss.Name)
+
+		if err := p.resourceFailoverPrimary(ss, primary); err != nil {
+			return err
+		}
+	}
+
+	var current *ac.Pod
+
+	for i := range pds.Items {
+		if pds.Items[i].Name == primary {
+			current = &pds.Items[i]
+		}
+	}
+
+	if current != nil && current.ObjectMeta.DeletionTimestamp == nil && podReady(*current) {
+		if err := p.resourceFailoverUnavailable(ss, nil); err != nil {
+			return err
+		}
+
+		return p.resourceFailoverRoles(pds.Items, primary)
+	}
+
+	since, err := time.Parse(time.RFC3339, ss.ObjectMeta.Annotations["convox.com/unavailable"])
+	if err != nil {
+		since = time.Now().UTC()
+
+		if err := p.resourceFailoverUnavailable(ss, &since); err != nil {
+			return err
+		}
+	}
+
+	if time.Since(since) < resourceFailoverGrace {
+		return p.resourceFailoverRoles(pds.Items, primary)
+	}
+
+	var candidate *ac.Pod
+
+	for i, pd := range pds.Items {
+		if pd.Name != primary && pd.ObjectMeta.Labels["role"] == "replica" && pd.ObjectMeta.DeletionTimestamp == nil && podReady(pd) {
+			candidate = &pds.Items[i]
+			break
+		}
+	}
+
+	if candidate == nil {
+		return fmt.Errorf("primary %s is unavailable and there is no ready replica to promote", primary)
+	}
+
+	if err := p.resourcePodExec(candidate, resourcePromoteCommand(ss.ObjectMeta.Labels["kind"]), nil, ioutil.Discard); err != nil {
+		return err
+	}
+
+	if err := p.resourceFailoverPrimary(ss, candidate.Name); err != nil {
+		return err
+	}
+
+	if err := p.resourceFailoverLabel(*candidate, "primary"); err != nil {
+		return err
+	}
+
+	if err := p.resourceFailoverUnavailable(ss, nil); err != nil {
+		return err
+	}
+
+	fmt.Printf("ns=k8s at=resource.failover namespace=%s resource=%s from=%s to=%s\n", ss.Namespace, name, primary, candidate.Name)
+
+	// the old primary and the other replicas start over from the new primary
+	for _, pd := range pds.Items {
+		if pd.Name == candidate.Name {
+			continue
+		}
+
+		if err := p.resourceFailoverLabel(pd, "replica"); err != nil {
+			return err
+		}
+
+		if err := p.Cluster.CoreV1().Pods(pd.Namespace).Delete(pd.Name, nil); err != nil && !ae.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *Provider) resourceFailoverLabel(pd ac.Pod, role string) error {
+	if pd.ObjectMeta.Labels["role"] == role {
+		return nil
+	}
+
+	patch := fmt.Sprintf(`{"metadata":{"labels":{"role":%q}}}`, role)
+
+	if _, err := p.Cluster.CoreV1().Pods(pd.Namespace).Patch(pd.Name, types.StrategicMergePatchType, []byte(patch)); err != nil && !ae.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// resourceFailoverPrimary records the primary on the StatefulSet, the annotation is not part
+// of the resource template so it survives deploys
+func (p *Provider) resourceFailoverPrimary(ss aa.StatefulSet, primary string) error {
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{"convox.com/primary":%q}}}`, primary)
+
+	if _, err := p.Cluster.AppsV1().StatefulSets(ss.Namespace).Patch(ss.Name, types.StrategicMergePatchType, []byte(patch)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// resourceFailoverUnavailable records on the StatefulSet when its primary was first seen
+// unavailable so that the grace period carries over when leadership moves to another replica
+func (p *Provider) resourceFailoverUnavailable(ss aa.StatefulSet, since *time.Time) error {
+	value := "null"
+
+	if since != nil {
+		value = fmt.Sprintf("%q", since.Format(time.RFC3339))
+	} else if _, ok := ss.ObjectMeta.Annotations["convox.com/unavailable"]; !ok {
+		return nil
+	}
+
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{"convox.com/unavailable":%s}}}`, value)
+
+	if _, err := p.Cluster.AppsV1().StatefulSets(ss.Namespace).Patch(ss.Name, types.StrategicMergePatchType, []byte(patch)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// resourceFailoverRoles labels new pods, they wait for a role before they start
+func (p *Provider) resourceFailoverRoles(pds []ac.Pod, primary string) error {
+	for _, pd := range pds {
+		role := "replica"
+
+		if pd.Name == primary {
+			role = "primary"
+		}
+
+		if err := p.resourceFailoverLabel(pd, role); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourcePromoteCommand(kind string) []string {
+	switch kind {
+	case "mysql":
+		return []string{"sh", "-c", `mysql -uroot -p"$MYSQL_ROOT_PASSWORD" -e "STOP SLAVE; RESET SLAVE ALL; SET GLOBAL read_only = OFF;"`}
+	case "postgres":
+		return []string{"sh", "-c", `gosu postgres pg_ctl promote -D "$PGDATA"`}
+	case "redis":
+		return []string{"redis-cli", "slaveof", "no", "one"}
+	default:
+		return []string{"true"}
+	}
+}
@@ -0,0 +1,59 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	aa "k8s.io/api/apps/v1"
+	ac "k8s.io/api/core/v1"
+	am "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	kt "k8s.io/client-go/testing"
+)
+
+func TestResourceFailoverUnavailable(t *testing.T) {
+	labels := map[string]string{"app": "app1", "resource": "db", "type": "resource"}
+
+	ss := &aa.StatefulSet{ObjectMeta: am.ObjectMeta{Namespace: "rack1-app1", Name: "resource-db", Labels: labels, Annotations: map[string]string{"convox.com/primary": "resource-db-0"}}}
+	pd := &ac.Pod{ObjectMeta: am.ObjectMeta{Namespace: "rack1-app1", Name: "resource-db-0", Labels: map[string]string{"app": "app1", "resource": "db", "type": "resource", "role": "primary"}}}
+
+	c := fake.NewSimpleClientset(ss, pd)
+	p := &Provider{Cluster: c, Name: "rack1"}
+
+	require.NoError(t, p.resourceFailover(*ss))
+
+	ss, err := c.AppsV1().StatefulSets("rack1-app1").Get("resource-db", am.GetOptions{})
+	require.NoError(t, err)
+
+	since, err := time.Parse(time.RFC3339, ss.ObjectMeta.Annotations["convox.com/unavailable"])
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now(), since, 5*time.Second)
+
+	// the grace period continues from the recorded time
+	require.NoError(t, p.resourceFailover(*ss))
+
+	ss, err = c.AppsV1().StatefulSets("rack1-app1").Get("resource-db", am.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, since.Format(time.RFC3339), ss.ObjectMeta.Annotations["convox.com/unavailable"])
+
+	pd.Status.Conditions = []ac.PodCondition{{Type: ac.PodReady, Status: ac.ConditionTrue}}
+
+	_, err = c.CoreV1().Pods("rack1-app1").UpdateStatus(pd)
+	require.NoError(t, err)
+
+	c.ClearActions()
+
+	require.NoError(t, p.resourceFailover(*ss))
+
+	// the fake clientset does not remove keys patched to null so check the patch itself
+	patches := []string{}
+
+	for _, a := range c.Actions() {
+		if pa, ok := a.(kt.PatchAction); ok && pa.GetResource().Resource == "statefulsets" {
+			patches = append(patches, string(pa.GetPatch()))
+		}
+	}
+
+	require.Equal(t, []string{`{"metadata":{"annotations":{"convox.com/unavailable":null}}}`}, patches)
+}
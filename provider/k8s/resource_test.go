@@ -0,0 +1,101 @@
+package k8s
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/convox/convox/pkg/structs"
+	"github.com/stretchr/testify/require"
+	aa "k8s.io/api/apps/v1"
+	ac "k8s.io/api/core/v1"
+	am "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSystemResourceTypes(t *testing.T) {
+	p := &Provider{}
+
+	rts, err := p.SystemResourceTypes()
+	require.NoError(t, err)
+
+	names := []string{}
+
+	for _, rt := range rts {
+		names = append(names, rt.Name)
+
+		_, err := os.Stat(fmt.Sprintf("template/resource/%s.yml.tmpl", rt.Name))
+		require.NoError(t, err, rt.Name)
+
+		params := map[string]bool{}
+
+		for _, rp := range rt.Parameters {
+			params[rp.Name] = true
+		}
+
+		require.True(t, params["version"], rt.Name)
+
+		if params["ha"] {
+			_, err := os.Stat(fmt.Sprintf("template/resource/%s-ha.yml.tmpl", rt.Name))
+			require.NoError(t, err, rt.Name)
+		}
+	}
+
+	require.Equal(t, []string{"elasticsearch", "memcached", "mongodb", "mysql", "postgres", "rabbitmq", "redis", "s3"}, names)
+}
+
+func TestResourceUnavailable(t *testing.T) {
+	tests := []struct {
+		Kind    string
+		Console bool
+		Export  bool
+		Import  bool
+	}{
+		{Kind: "elasticsearch", Console: true},
+		{Kind: "memcached", Console: true},
+		{Kind: "mongodb", Console: true, Export: true, Import: true},
+		{Kind: "mysql", Console: true, Export: true, Import: true},
+		{Kind: "postgres", Console: true, Export: true, Import: true},
+		{Kind: "rabbitmq", Console: true, Export: true, Import: true},
+		{Kind: "redis", Console: true, Export: true, Import: true},
+		{Kind: "s3", Export: true, Import: true},
+		{Kind: "unknown"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Kind, func(t *testing.T) {
+			p := testResourceProvider(test.Kind)
+
+			require.Equal(t, test.Export, resourceExportable(test.Kind))
+
+			if !test.Console {
+				err := p.ResourceConsole("app1", "resource1", nil, structs.ResourceConsoleOptions{})
+				require.EqualError(t, err, fmt.Sprintf("console not available for resources of type: %s", test.Kind))
+			}
+
+			if !test.Export {
+				_, err := p.ResourceExport("app1", "resource1")
+				require.EqualError(t, err, fmt.Sprintf("export not available for resources of type: %s", test.Kind))
+
+				err = p.resourceDump("app1", &structs.Resource{Name: "resource1", Type: test.Kind}, ioutil.Discard)
+				require.EqualError(t, err, fmt.Sprintf("export not available for resources of type: %s", test.Kind))
+			}
+
+			if !test.Import {
+				err := p.ResourceImport("app1", "resource1", strings.NewReader(""))
+				require.EqualError(t, err, fmt.Sprintf("import not available for resources of type: %s", test.Kind))
+			}
+		})
+	}
+}
+
+func testResourceProvider(kind string) *Provider {
+	c := fake.NewSimpleClientset(
+		&aa.Deployment{ObjectMeta: am.ObjectMeta{Namespace: "rack1-app1", Name: "resource-resource1", Labels: map[string]string{"kind": kind}}},
+		&ac.ConfigMap{ObjectMeta: am.ObjectMeta{Namespace: "rack1-app1", Name: "resource-resource1"}, Data: map[string]string{"URL": fmt.Sprintf("%s://user:pass@resource1:1234/db", kind)}},
+	)
+
+	return &Provider{Cluster: c, Name: "rack1"}
+}
@@ -0,0 +1,69 @@
+package k8s
+
+import "github.com/convox/convox/pkg/structs"
+
+// resourceTypes describes the resources in template/resource, defaults must match the templates
+var resourceTypes = structs.ResourceTypes{
+	{
+		Name: "elasticsearch",
+		Parameters: structs.ResourceParameters{
+			{Name: "heap", Default: "512", Description: "jvm heap size in megabytes"},
+			{Name: "storage", Default: "10", Description: "volume size in gigabytes"},
+			{Name: "version", Default: "7.9.3", Description: "elasticsearch version"},
+		},
+	},
+	{
+		Name: "memcached",
+		Parameters: structs.ResourceParameters{
+			{Name: "version", Default: "1.4.34", Description: "memcached version"},
+		},
+	},
+	{
+		Name: "mongodb",
+		Parameters: structs.ResourceParameters{
+			{Name: "storage", Default: "10", Description: "volume size in gigabytes"},
+			{Name: "version", Default: "4.2", Description: "mongodb version"},
+		},
+	},
+	{
+		Name: "mysql",
+		Parameters: structs.ResourceParameters{
+			{Name: "ha", Default: "false", Description: "run a primary with replicas and fail over automatically"},
+			{Name: "replicas", Default: "1", Description: "number of read replicas when ha is enabled"},
+			{Name: "storage", Default: "10", Description: "volume size in gigabytes"},
+			{Name: "version", Default: "5.7.23", Description: "mysql version"},
+		},
+	},
+	{
+		Name: "postgres",
+		Parameters: structs.ResourceParameters{
+			{Name: "ha", Default: "false", Description: "run a primary with replicas and fail over automatically"},
+			{Name: "replicas", Default: "1", Description: "number of read replicas when ha is enabled"},
+			{Name: "storage", Default: "10", Description: "volume size in gigabytes"},
+			{Name: "version", Default: "10.5", Description: "postgres version"},
+		},
+	},
+	{
+		Name: "rabbitmq",
+		Parameters: structs.ResourceParameters{
+			{Name: "storage", Default: "10", Description: "volume size in gigabytes"},
+			{Name: "version", Default: "3.8", Description: "rabbitmq version"},
+		},
+	},
+	{
+		Name: "redis",
+		Parameters: structs.ResourceParameters{
+			{Name: "ha", Default: "false", Description: "run a primary with replicas and fail over automatically"},
+			{Name: "replicas", Default: "1", Description: "number of read replicas when ha is enabled"},
+			{Name: "storage", Default: "10", Description: "volume size in gigabytes"},
+			{Name: "version", Default: "4.0.10", Description: "redis version"},
+		},
+	},
+	{
+		Name: "s3",
+		Parameters: structs.ResourceParameters{
+			{Name: "storage", Default: "10", Description: "volume size in gigabytes"},
+			{Name: "version", Default: "RELEASE.2020-10-18T21-54-12Z", Description: "minio release serving the bucket"},
+		},
+	},
+}
@@ -0,0 +1,610 @@
+package k8s
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/convox/convox/pkg/common"
+	"github.com/convox/convox/pkg/manifest"
+	"github.com/convox/convox/pkg/structs"
+	aa "k8s.io/api/apps/v1"
+	ac "k8s.io/api/core/v1"
+	ae "k8s.io/apimachinery/pkg/api/errors"
+	am "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// resourceState describes a resource as deployed or as rendered from a manifest
+type resourceState struct {
+	HA       bool
+	Replicas int
+	Storage  int64
+	Type     string
+	Version  string
+	Volume   string
+}
+
+// ResourceUpdate plans the changes needed to bring a resource in line with a release and,
+// unless this is a dry run, promotes that release to apply them
+func (p *Provider) ResourceUpdate(app, name string, opts structs.ResourceUpdateOptions) (structs.ResourceChanges, error) {
+	a, err := p.AppGet(app)
+	if err != nil {
+		return nil, err
+	}
+
+	release := common.DefaultString(opts.Release, a.Release)
+
+	if release == "" {
+		return nil, fmt.Errorf("no release to update resource from")
+	}
+
+	m, _, err := common.ReleaseManifest(p, app, release)
+	if err != nil {
+		return nil, err
+	}
+
+	chs, err := p.resourcePlan(a, m, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if common.DefaultBool(opts.DryRun, false) {
+		return chs, nil
+	}
+
+	// force only applies to the resource being updated
+	if err := p.resourcesDestructive(a, m, name); err != nil {
+		return nil, err
+	}
+
+	if err := p.ReleasePromote(app, release, structs.ReleasePromoteOptions{Force: opts.Force}); err != nil {
+		return nil, err
+	}
+
+	return chs, nil
+}
+
+// releaseResourcesPrepare refuses changes that could lose resource data unless forced and
+// moves the data of resources that need a new volume before the release is applied
+func (p *Provider) releaseResourcesPrepare(a *structs.App, m *manifest.Manifest, release string, force bool) error {
+	if !force {
+		if err := p.resourcesDestructive(a, m, ""); err != nil {
+			return err
+		}
+	}
+
+	for _, mr := range m.Resources {
+		chs, err := p.resourcePlan(a, m, mr.Name)
+		if err != nil {
+			return err
+		}
+
+		if chs.Migrate() {
+			if err := p.resourceMigrate(a, mr, release); err != nil {
+				return fmt.Errorf("could not migrate resource %s: %s", mr.Name, err)
+			}
+
+			continue
+		}
+
+		for _, ch := range chs {
+			if ch.Action != "expand" {
+				continue
+			}
+
+			if err := p.resourceExpand(a.Name, mr); err != nil {
+				return fmt.Errorf("could not expand resource %s: %s", mr.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// resourceCurrent returns the deployed state of a resource or nil if it does not exist
+func (p *Provider) resourceCurrent(app, name string) (*resourceState, error) {
+	d, err := p.Cluster.AppsV1().Deployments(p.AppNamespace(app)).Get(fmt.Sprintf("resource-%s", name), am.GetOptions{})
+	if ae.IsNotFound(err) {
+		ss, err := p.Cluster.AppsV1().StatefulSets(p.AppNamespace(app)).Get(fmt.Sprintf("resource-%s", name), am.GetOptions{})
+		if ae.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return resourceStatefulSetState(ss), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rs := &resourceState{Type: d.ObjectMeta.Labels["kind"]}
+
+	if cs := d.Spec.Template.Spec.Containers; len(cs) > 0 {
+		rs.Version = resourceImageVersion(cs[0].Image)
+	}
+
+	for _, v := range d.Spec.Template.Spec.Volumes {
+		if v.Name == "data" && v.PersistentVolumeClaim != nil {
+			rs.Volume = v.PersistentVolumeClaim.ClaimName
+		}
+	}
+
+	if rs.Volume == "" {
+		return rs, nil
+	}
+
+	pvc, err := p.Cluster.CoreV1().PersistentVolumeClaims(p.AppNamespace(app)).Get(rs.Volume, am.GetOptions{})
+	if ae.IsNotFound(err) {
+		return rs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if q, ok := pvc.Spec.Resources.Requests[ac.ResourceStorage]; ok {
+		rs.Storage = q.Value() >> 30
+	}
+
+	return rs, nil
+}
+
+// resourceDesired renders a resource from the manifest and reads back the parameters
+// that decide how it can be changed
+func (p *Provider) resourceDesired(a *structs.App, mr manifest.Resource, volume string) (*resourceState, error) {
+	data, err := p.releaseTemplateResource(a, mr, volume)
+	if err != nil {
+		return nil, err
+	}
+
+	rs := &resourceState{Type: mr.Type, Volume: volume}
+
+	for _, part := range bytes.Split(data, []byte("---\n")) {
+		if len(bytes.TrimSpace(part)) == 0 {
+			continue
+		}
+
+		o, _, err := scheme.Codecs.UniversalDeserializer().Decode(part, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := o.(type) {
+		case *aa.Deployment:
+			if cs := t.Spec.Template.Spec.Containers; len(cs) > 0 {
+				rs.Version = resourceImageVersion(cs[0].Image)
+			}
+		case *aa.StatefulSet:
+			rs = resourceStatefulSetState(t)
+		case *ac.PersistentVolumeClaim:
+			if q, ok := t.Spec.Resources.Requests[ac.ResourceStorage]; ok {
+				rs.Storage = q.Value() >> 30
+			}
+		}
+	}
+
+	return rs, nil
+}
+
+// resourcesDestructive returns an error for the first resource other than skip with
+// changes that could lose data
+func (p *Provider) resourcesDestructive(a *structs.App, m *manifest.Manifest, skip string) error {
+	names := []string{}
+	seen := map[string]bool{}
+
+	for _, mr := range m.Resources {
+		names = append(names, mr.Name)
+		seen[mr.Name] = true
+	}
+
+	deployed, err := p.resourceNames(a.Name)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range deployed {
+		if name != "" && !seen[name] {
+			names = append(names, name)
+			seen[name] = true
+		}
+	}
+
+	for _, name := range names {
+		if name == skip {
+			continue
+		}
+
+		chs, err := p.resourcePlan(a, m, name)
+		if err != nil {
+			return err
+		}
+
+		if chs.Destructive() {
+			return fmt.Errorf("resource %s has changes that may lose data, review them with: convox resources update %s", name, name)
+		}
+	}
+
+	return nil
+}
+
+// resourceMigrate backs up a resource, starts it on a new volume with the desired
+// parameters and restores the backup into it. the app keeps running while this happens
+// so writes made after the backup are lost, which is why migrations need --force.
+// it runs synchronously within ReleasePromote and AppUpdate so a large resource holds
+// up the promotion until the restore completes
+func (p *Provider) resourceMigrate(a *structs.App, mr manifest.Resource, release string) error {
+	cur, err := p.resourceCurrent(a.Name, mr.Name)
+	if err != nil {
+		return err
+	}
+
+	r, err := p.ResourceGet(a.Name, mr.Name)
+	if err != nil {
+		return err
+	}
+
+	retention, err := mr.BackupRetention()
+	if err != nil {
+		return err
+	}
+
+	rb, err := p.resourceBackup(a.Name, r, retention)
+	if err != nil {
+		return err
+	}
+
+	volume := fmt.Sprintf("resource-%s-%s-%s", mr.Name, mr.Type, strings.ToLower(common.Id("", 6)))
+
+	ha, err := mr.HA()
+	if err != nil {
+		return err
+	}
+
+	// the volumes of an ha resource belong to its StatefulSet so they are replaced along with it,
+	// moving between modes removes the old workload so that the restore reaches the new one
+	if cur.HA || ha {
+		if err := p.resourceRemoveWorkload(a.Name, mr.Name, cur.HA); err != nil {
+			return err
+		}
+	}
+
+	data, err := p.releaseTemplateResource(a, mr, volume)
+	if err != nil {
+		return err
+	}
+
+	ldata, err := ApplyLabels(data, fmt.Sprintf("system=convox,provider=k8s,rack=%s,app=%s,release=%s", p.Name, a.Name, release))
+	if err != nil {
+		return err
+	}
+
+	if err := Apply(ldata); err != nil {
+		return err
+	}
+
+	if err := p.resourceWait(a.Name, mr); err != nil {
+		return err
+	}
+
+	if err := p.ResourceRestore(a.Name, mr.Name, rb.Id); err != nil {
+		return err
+	}
+
+	if cur.Volume != "" && cur.Volume != volume {
+		if err := p.Cluster.CoreV1().PersistentVolumeClaims(p.AppNamespace(a.Name)).Delete(cur.Volume, nil); err != nil && !ae.IsNotFound(err) {
+			fmt.Printf("ns=k8s at=resource.migrate app=%s resource=%s volume=%s error=%q\n", a.Name, mr.Name, cur.Volume, err)
+		}
+	}
+
+	return nil
+}
+
+// resourcePlan compares a deployed resource with the manifest
+func (p *Provider) resourcePlan(a *structs.App, m *manifest.Manifest, name string) (structs.ResourceChanges, error) {
+	cur, err := p.resourceCurrent(a.Name, name)
+	if err != nil {
+		return nil, err
+	}
+
+	mr, _ := m.Resource(name)
+
+	switch {
+	case mr == nil && cur == nil:
+		return nil, fmt.Errorf("no such resource: %s", name)
+	case mr == nil:
+		return structs.ResourceChanges{{Action: "delete", Current: cur.Type, Destructive: true, Parameter: "type"}}, nil
+	case cur == nil:
+		return structs.ResourceChanges{{Action: "create", Desired: mr.Type, Parameter: "type"}}, nil
+	case cur.Type != mr.Type:
+		return structs.ResourceChanges{{Action: "replace", Current: cur.Type, Desired: mr.Type, Destructive: true, Parameter: "type"}}, nil
+	}
+
+	des, err := p.resourceDesired(a, *mr, cur.Volume)
+	if err != nil {
+		return nil, err
+	}
+
+	chs := structs.ResourceChanges{}
+
+	if cur.HA != des.HA {
+		chs = append(chs, structs.ResourceChange{Action: "migrate", Current: strconv.FormatBool(cur.HA), Desired: strconv.FormatBool(des.HA), Destructive: true, Parameter: "ha"})
+	}
+
+	if des.HA && cur.HA && cur.Replicas != des.Replicas {
+		chs = append(chs, structs.ResourceChange{Action: "update", Current: strconv.Itoa(cur.Replicas), Desired: strconv.Itoa(des.Replicas), Parameter: "replicas"})
+	}
+
+	if cur.Version != des.Version {
+		action, destructive := resourceVersionChange(mr.Type, cur.Version, des.Version)
+		chs = append(chs, structs.ResourceChange{Action: action, Current: cur.Version, Desired: des.Version, Destructive: destructive, Parameter: "version"})
+	}
+
+	if (cur.Volume != "" || cur.HA) && cur.HA == des.HA && cur.Storage != des.Storage {
+		action := "expand"
+
+		// volumes can not shrink so the data moves to a new one
+		if des.Storage < cur.Storage {
+			action = "migrate"
+		}
+
+		chs = append(chs, structs.ResourceChange{Action: action, Current: fmt.Sprintf("%dGi", cur.Storage), Desired: fmt.Sprintf("%dGi", des.Storage), Destructive: action == "migrate", Parameter: "storage"})
+	}
+
+	return chs, nil
+}
+
+// resourceReady checks that a resource accepts connections
+func (p *Provider) resourceReady(app string, mr manifest.Resource) bool {
+	var command []string
+
+	switch mr.Type {
+	case "elasticsearch":
+		command = []string{"curl", "-fs", "http://127.0.0.1:9200/_cluster/health?wait_for_status=yellow"}
+	case "mongodb":
+		command = []string{"mongo", "--quiet", "--eval", "db.adminCommand('ping')"}
+	case "mysql":
+		command = []string{"mysqladmin", "ping", "-h", "127.0.0.1", "--silent"}
+	case "postgres":
+		command = []string{"pg_isready", "-h", "127.0.0.1"}
+	case "rabbitmq":
+		command = []string{"rabbitmq-diagnostics", "-q", "ping"}
+	case "redis":
+		command = []string{"redis-cli", "ping"}
+	default:
+		return true
+	}
+
+	return p.resourceExec(app, mr.Name, command, nil, &bytes.Buffer{}) == nil
+}
+
+// resourceWait waits for a resource to finish rolling out and accept connections
+func (p *Provider) resourceWait(app string, mr manifest.Resource) error {
+	ha, err := mr.HA()
+	if err != nil {
+		return err
+	}
+
+	return common.Wait(5*time.Second, 10*time.Minute, 2, func() (bool, error) {
+		if ha {
+			ss, err := p.Cluster.AppsV1().StatefulSets(p.AppNamespace(app)).Get(fmt.Sprintf("resource-%s", mr.Name), am.GetOptions{})
+			if err != nil {
+				return false, err
+			}
+
+			if ss.Status.ObservedGeneration < ss.ObjectMeta.Generation || ss.Spec.Replicas == nil || ss.Status.ReadyReplicas < *ss.Spec.Replicas {
+				return false, nil
+			}
+
+			return p.resourceReady(app, mr), nil
+		}
+
+		d, err := p.Cluster.AppsV1().Deployments(p.AppNamespace(app)).Get(fmt.Sprintf("resource-%s", mr.Name), am.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		if d.Status.ObservedGeneration < d.ObjectMeta.Generation {
+			return false, nil
+		}
+
+		if d.Status.Replicas != d.Status.UpdatedReplicas || d.Status.AvailableReplicas != d.Status.UpdatedReplicas {
+			return false, nil
+		}
+
+		return p.resourceReady(app, mr), nil
+	})
+}
+
+func resourceImageVersion(image string) string {
+	if i := strings.LastIndex(image, ":"); i >= 0 {
+		return image[i+1:]
+	}
+
+	return "latest"
+}
+
+// resourceVersion parses the leading numeric components of a version such as 10.5 or 5.7.23-debian
+func resourceVersion(version string) ([]int, bool) {
+	vs := []int{}
+
+	for _, part := range strings.Split(strings.SplitN(version, "-", 2)[0], ".") {
+		v, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, false
+		}
+
+		vs = append(vs, v)
+	}
+
+	return vs, true
+}
+
+func resourceVersionCompare(a, b []int) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		switch {
+		case a[i] < b[i]:
+			return -1
+		case a[i] > b[i]:
+			return 1
+		}
+	}
+
+	return len(a) - len(b)
+}
+
+// resourceVersionChange decides how to move a resource between versions, data files are not
+// compatible across major versions of mongodb, mysql and postgres so those are dumped and
+// restored into a new volume, elasticsearch can not be dumped so a major change may lose
+// its indexes and downgrades are never known to be safe
+func resourceVersionChange(kind, current, desired string) (string, bool) {
+	cv, cok := resourceVersion(current)
+	dv, dok := resourceVersion(desired)
+
+	if !cok || !dok {
+		return "update", true
+	}
+
+	downgrade := resourceVersionCompare(dv, cv) < 0
+
+	major := resourceVersionCompare(resourceVersionMajor(kind, cv), resourceVersionMajor(kind, dv)) != 0
+
+	switch kind {
+	case "elasticsearch":
+		return "update", downgrade || major
+	case "mongodb", "mysql", "postgres":
+		// writes made after the backup is taken are lost when it is restored
+		if major {
+			return "migrate", true
+		}
+	}
+
+	return "update", downgrade
+}
+
+// resourceVersionMajor returns the components that make up a major version, postgres
+// switched from two components to one at version 10
+func resourceVersionMajor(kind string, v []int) []int {
+	n := 2
+
+	if kind == "elasticsearch" || (kind == "postgres" && len(v) > 0 && v[0] >= 10) {
+		n = 1
+	}
+
+	if len(v) < n {
+		return v
+	}
+
+	return v[0:n]
+}
+
+// resourceVolume returns the volume claim to use for a resource, a migrated resource keeps
+// the volume it was moved to until its type changes
+func (p *Provider) resourceVolume(app string, mr manifest.Resource) (string, error) {
+	cur, err := p.resourceCurrent(app, mr.Name)
+	if err != nil {
+		return "", err
+	}
+
+	if cur != nil && cur.Type == mr.Type && cur.Volume != "" {
+		return cur.Volume, nil
+	}
+
+	return fmt.Sprintf("resource-%s-%s", mr.Name, mr.Type), nil
+}
+
+// resourceExpand grows the volumes of an ha resource, claims made from a StatefulSet
+// template are not updated along with it
+func (p *Provider) resourceExpand(app string, mr manifest.Resource) error {
+	ha, err := mr.HA()
+	if err != nil {
+		return err
+	}
+
+	if !ha {
+		return nil
+	}
+
+	storage := common.CoalesceString(mr.Options["storage"], "10")
+
+	pvcs, err := p.Cluster.CoreV1().PersistentVolumeClaims(p.AppNamespace(app)).List(am.ListOptions{LabelSelector: fmt.Sprintf("app=%s,resource=%s,ha=true", app, mr.Name)})
+	if err != nil {
+		return err
+	}
+
+	patch := fmt.Sprintf(`{"spec":{"resources":{"requests":{"storage":"%sGi"}}}}`, storage)
+
+	for _, pvc := range pvcs.Items {
+		if _, err := p.Cluster.CoreV1().PersistentVolumeClaims(pvc.Namespace).Patch(pvc.Name, types.StrategicMergePatchType, []byte(patch)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resourceRemoveWorkload deletes the Deployment or StatefulSet running a resource along with
+// the volumes of a StatefulSet and waits for its pods to go away
+func (p *Provider) resourceRemoveWorkload(app, resource string, ha bool) error {
+	name := fmt.Sprintf("resource-%s", resource)
+	ns := p.AppNamespace(app)
+	propagation := am.DeletePropagationForeground
+
+	var err error
+
+	if ha {
+		err = p.Cluster.AppsV1().StatefulSets(ns).Delete(name, &am.DeleteOptions{PropagationPolicy: &propagation})
+	} else {
+		err = p.Cluster.AppsV1().Deployments(ns).Delete(name, &am.DeleteOptions{PropagationPolicy: &propagation})
+	}
+	if err != nil && !ae.IsNotFound(err) {
+		return err
+	}
+
+	err = common.Wait(2*time.Second, 5*time.Minute, 1, func() (bool, error) {
+		pds, err := p.Cluster.CoreV1().Pods(ns).List(am.ListOptions{LabelSelector: fmt.Sprintf("app=%s,resource=%s,type=resource", app, resource)})
+		if err != nil {
+			return false, err
+		}
+
+		return len(pds.Items) == 0, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if ha {
+		if err := p.Cluster.CoreV1().PersistentVolumeClaims(ns).DeleteCollection(nil, am.ListOptions{LabelSelector: fmt.Sprintf("app=%s,resource=%s,ha=true", app, resource)}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceStatefulSetState(ss *aa.StatefulSet) *resourceState {
+	rs := &resourceState{
+		HA:   true,
+		Type: ss.ObjectMeta.Labels["kind"],
+	}
+
+	if ss.Spec.Replicas != nil {
+		rs.Replicas = int(*ss.Spec.Replicas) - 1
+	}
+
+	if cs := ss.Spec.Template.Spec.Containers; len(cs) > 0 {
+		rs.Version = resourceImageVersion(cs[0].Image)
+	}
+
+	for _, vct := range ss.Spec.VolumeClaimTemplates {
+		if q, ok := vct.Spec.Resources.Requests[ac.ResourceStorage]; ok && vct.ObjectMeta.Name == "data" {
+			rs.Storage = q.Value() >> 30
+		}
+	}
+
+	return rs
+}
@@ -0,0 +1,267 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/convox/convox/pkg/secret"
+	"github.com/convox/convox/pkg/structs"
+	am "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// namespace annotation holding the digests of the secret values used by the last promote
+const secretDigestAnnotation = "convox.com/secrets"
+
+func (p *Provider) EnvironmentSecretList(app string) (structs.EnvironmentSecrets, error) {
+	a, err := p.AppGet(app)
+	if err != nil {
+		return nil, err
+	}
+
+	ss := structs.EnvironmentSecrets{}
+
+	if a.Release == "" {
+		return ss, nil
+	}
+
+	r, err := p.releaseGet(app, a.Release)
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := structs.NewEnvironment([]byte(r.Env))
+	if err != nil {
+		return nil, err
+	}
+
+	digests, err := p.secretDigests(app)
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err := p.secretResolvers(app)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range secret.References(env) {
+		s := structs.EnvironmentSecret{
+			Name:      name,
+			Reference: env[name],
+		}
+
+		v, err := rs.Resolve(env[name])
+
+		switch d, ok := digests[name]; {
+		case err != nil:
+			s.Status = structs.EnvironmentSecretError
+			s.Error = err.Error()
+		case !ok:
+			s.Status = structs.EnvironmentSecretPending
+		case d != secret.Digest(v):
+			s.Status = structs.EnvironmentSecretStale
+		default:
+			s.Status = structs.EnvironmentSecretCurrent
+		}
+
+		ss = append(ss, s)
+	}
+
+	return ss, nil
+}
+
+// secretEnvironment resolves the secret references in env and returns the digests of the resolved values
+func (p *Provider) secretEnvironment(app string, env structs.Environment) (structs.Environment, map[string]string, error) {
+	digests := map[string]string{}
+
+	names := secret.References(env)
+
+	if len(names) == 0 {
+		return env, digests, nil
+	}
+
+	rs, err := p.secretResolvers(app)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	re, err := rs.Environment(env)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, name := range names {
+		digests[name] = secret.Digest(re[name])
+	}
+
+	return re, digests, nil
+}
+
+func (p *Provider) secretDigests(app string) (map[string]string, error) {
+	ns, err := p.Cluster.CoreV1().Namespaces().Get(p.AppNamespace(app), am.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	digests := map[string]string{}
+
+	if data, ok := ns.Annotations[secretDigestAnnotation]; ok && data > "" {
+		if err := json.Unmarshal([]byte(data), &digests); err != nil {
+			return nil, err
+		}
+	}
+
+	return digests, nil
+}
+
+func (p *Provider) secretDigestsSave(app string, digests map[string]string) error {
+	ns, err := p.Cluster.CoreV1().Namespaces().Get(p.AppNamespace(app), am.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(digests)
+	if err != nil {
+		return err
+	}
+
+	if ns.Annotations == nil {
+		ns.Annotations = map[string]string{}
+	}
+
+	ns.Annotations[secretDigestAnnotation] = string(data)
+
+	if _, err := p.Cluster.CoreV1().Namespaces().Update(ns); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// secretResolvers returns the built in kubernetes resolver for an app along with
+// any http resolvers configured with the SecretResolvers rack parameter
+func (p *Provider) secretResolvers(app string) (secret.Resolvers, error) {
+	params, err := p.systemParameters()
+	if err != nil {
+		return nil, err
+	}
+
+	rs := secret.Resolvers{
+		"k8s": &secretResolverKubernetes{
+			cluster:    p.Cluster,
+			namespaces: append([]string{p.AppNamespace(app)}, parseSecretNamespaces(params["SecretNamespaces"])...),
+			rack:       p.Namespace,
+		},
+	}
+
+	hrs, err := parseSecretResolvers(params["SecretResolvers"])
+	if err != nil {
+		return nil, err
+	}
+
+	for name, endpoint := range hrs {
+		token := ""
+
+		if s, err := p.Cluster.CoreV1().Secrets(p.Namespace).Get(fmt.Sprintf("secret-resolver-%s", name), am.GetOptions{}); err == nil {
+			token = string(s.Data["token"])
+		}
+
+		rs[name] = secret.NewHTTPResolver(endpoint, token)
+	}
+
+	return rs, nil
+}
+
+// parseSecretResolvers parses name=url pairs separated by commas
+func parseSecretResolvers(value string) (map[string]string, error) {
+	rs := map[string]string{}
+
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid secret resolver: %s", pair)
+		}
+
+		name := strings.TrimSpace(parts[0])
+		endpoint := strings.TrimSpace(parts[1])
+
+		if name == "" || name == "k8s" {
+			return nil, fmt.Errorf("invalid secret resolver name: %s", name)
+		}
+
+		if u, err := url.Parse(endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid secret resolver url: %s", endpoint)
+		}
+
+		rs[name] = endpoint
+	}
+
+	return rs, nil
+}
+
+// parseSecretNamespaces parses a list of namespaces separated by commas
+func parseSecretNamespaces(value string) []string {
+	nss := []string{}
+
+	for _, ns := range strings.Split(value, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			nss = append(nss, ns)
+		}
+	}
+
+	return nss
+}
+
+// secretResolverKubernetes resolves secret://k8s/namespace/name#key from a Secret in the
+// app namespace or in one of the namespaces allowed with the SecretNamespaces rack parameter
+type secretResolverKubernetes struct {
+	cluster    kubernetes.Interface
+	namespaces []string
+	rack       string
+}
+
+func (r *secretResolverKubernetes) Resolve(path, key string) (string, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("path must be namespace/name")
+	}
+
+	ns, name := parts[0], parts[1]
+
+	if !r.allowed(ns) {
+		return "", fmt.Errorf("namespace not allowed: %s", ns)
+	}
+
+	s, err := r.cluster.CoreV1().Secrets(ns).Get(name, am.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	v, ok := s.Data[key]
+	if !ok {
+		return "", fmt.Errorf("key not found: %s", key)
+	}
+
+	return string(v), nil
+}
+
+func (r *secretResolverKubernetes) allowed(ns string) bool {
+	if ns == r.rack || ns == "convox-system" || strings.HasPrefix(ns, "kube-") {
+		return false
+	}
+
+	for _, n := range r.namespaces {
+		if n == ns {
+			return true
+		}
+	}
+
+	return false
+}
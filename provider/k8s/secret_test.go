@@ -0,0 +1,46 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	ac "k8s.io/api/core/v1"
+	am "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSecretResolverKubernetes(t *testing.T) {
+	c := fake.NewSimpleClientset(
+		&ac.Secret{ObjectMeta: am.ObjectMeta{Namespace: "rack1-app1", Name: "db"}, Data: map[string][]byte{"PASSWORD": []byte("pw1")}},
+		&ac.Secret{ObjectMeta: am.ObjectMeta{Namespace: "rack1-app2", Name: "db"}, Data: map[string][]byte{"PASSWORD": []byte("pw2")}},
+		&ac.Secret{ObjectMeta: am.ObjectMeta{Namespace: "shared", Name: "db"}, Data: map[string][]byte{"PASSWORD": []byte("pw3")}},
+		&ac.Secret{ObjectMeta: am.ObjectMeta{Namespace: "rack1", Name: "api"}, Data: map[string][]byte{"PASSWORD": []byte("pw4")}},
+	)
+
+	r := &secretResolverKubernetes{cluster: c, namespaces: []string{"rack1-app1", "shared"}, rack: "rack1"}
+
+	v, err := r.Resolve("rack1-app1/db", "PASSWORD")
+	require.NoError(t, err)
+	require.Equal(t, "pw1", v)
+
+	v, err = r.Resolve("shared/db", "PASSWORD")
+	require.NoError(t, err)
+	require.Equal(t, "pw3", v)
+
+	_, err = r.Resolve("rack1-app2/db", "PASSWORD")
+	require.EqualError(t, err, "namespace not allowed: rack1-app2")
+
+	_, err = r.Resolve("rack1/api", "PASSWORD")
+	require.EqualError(t, err, "namespace not allowed: rack1")
+
+	_, err = r.Resolve("rack1-app1/db", "OTHER")
+	require.EqualError(t, err, "key not found: OTHER")
+
+	_, err = r.Resolve("rack1-app1", "PASSWORD")
+	require.EqualError(t, err, "path must be namespace/name")
+}
+
+func TestParseSecretNamespaces(t *testing.T) {
+	require.Equal(t, []string{}, parseSecretNamespaces(""))
+	require.Equal(t, []string{"shared", "other"}, parseSecretNamespaces("shared, other,"))
+}
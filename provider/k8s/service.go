@@ -1,16 +1,30 @@
 package k8s
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/convox/convox/pkg/common"
+	"github.com/convox/convox/pkg/cron"
 	"github.com/convox/convox/pkg/manifest"
 	"github.com/convox/convox/pkg/structs"
+	asb "k8s.io/api/autoscaling/v2beta1"
+	ae "k8s.io/apimachinery/pkg/api/errors"
 	am "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// serviceSchedule is stored as json in the app namespace annotations
+type serviceSchedule struct {
+	Applied  time.Time `json:"applied"`
+	Count    int       `json:"count"`
+	Schedule string    `json:"schedule"`
+	Service  string    `json:"service"`
+}
+
 func (p *Provider) ServiceHost(app string, s manifest.Service) string {
 	if s.Internal {
 		return fmt.Sprintf("%s.%s-%s.svc.cluster.local", s.Name, p.Name, app)
@@ -43,6 +57,11 @@ func (p *Provider) ServiceList(app string) (structs.Services, error) {
 		return nil, err
 	}
 
+	sss, err := p.serviceSchedules(app)
+	if err != nil {
+		return nil, err
+	}
+
 	ss := structs.Services{}
 
 	for _, d := range ds.Items {
@@ -88,6 +107,20 @@ func (p *Provider) ServiceList(app string) (structs.Services, error) {
 			}
 		}
 
+		for _, sc := range sss {
+			if sc.Service != s.Name {
+				continue
+			}
+
+			sch := structs.ServiceSchedule{Count: sc.Count, Schedule: sc.Schedule}
+
+			if cs, err := cron.Parse(sc.Schedule); err == nil {
+				sch.Next = cs.Next(time.Now().UTC())
+			}
+
+			s.Schedules = append(s.Schedules, sch)
+		}
+
 		ss = append(ss, s)
 	}
 
@@ -154,17 +187,118 @@ func (p *Provider) serviceRestartDeployment(app, name string) error {
 	return nil
 }
 
+func (p *Provider) ServiceScheduleDelete(app, name, schedule string) error {
+	sss, err := p.serviceSchedules(app)
+	if err != nil {
+		return err
+	}
+
+	schedule = strings.Join(strings.Fields(schedule), " ")
+
+	nss := []serviceSchedule{}
+
+	for _, sc := range sss {
+		if sc.Service != name || sc.Schedule != schedule {
+			nss = append(nss, sc)
+		}
+	}
+
+	if len(nss) == len(sss) {
+		return fmt.Errorf("no such schedule for %s: %s", name, schedule)
+	}
+
+	return p.serviceSchedulesSave(app, nss)
+}
+
+func (p *Provider) ServiceScheduleSet(app, name, schedule string, opts structs.ServiceScheduleSetOptions) error {
+	schedule = strings.Join(strings.Fields(schedule), " ")
+
+	if _, err := cron.Parse(schedule); err != nil {
+		return err
+	}
+
+	if opts.Count == nil || *opts.Count < 0 {
+		return fmt.Errorf("count must be zero or more")
+	}
+
+	if _, err := p.Cluster.AppsV1().Deployments(p.AppNamespace(app)).Get(name, am.GetOptions{}); err != nil {
+		return err
+	}
+
+	hpa, err := p.serviceAutoscaler(app, name)
+	if err != nil {
+		return err
+	}
+
+	if hpa != nil {
+		if err := serviceAutoscaleCount(name, hpa, *opts.Count); err != nil {
+			return err
+		}
+	}
+
+	sss, err := p.serviceSchedules(app)
+	if err != nil {
+		return err
+	}
+
+	sc := serviceSchedule{
+		Applied:  time.Now().UTC(),
+		Count:    *opts.Count,
+		Schedule: schedule,
+		Service:  name,
+	}
+
+	nss := []serviceSchedule{sc}
+
+	for _, s := range sss {
+		if s.Service != name || s.Schedule != schedule {
+			nss = append(nss, s)
+		}
+	}
+
+	return p.serviceSchedulesSave(app, nss)
+}
+
+// ServiceUpdate scales a service, cpu and memory come from the manifest so that releases keep them
 func (p *Provider) ServiceUpdate(app, name string, opts structs.ServiceUpdateOptions) error {
+	if opts.Cpu != nil || opts.Memory != nil {
+		return fmt.Errorf("cpu and memory can only be changed in convox.yml")
+	}
+
 	d, err := p.Cluster.AppsV1().Deployments(p.AppNamespace(app)).Get(name, am.GetOptions{})
 	if err != nil {
 		return err
 	}
 
-	if opts.Count != nil {
-		c := int32(*opts.Count)
-		d.Spec.Replicas = &c
+	if opts.Count == nil {
+		return nil
+	}
+
+	hpa, err := p.serviceAutoscaler(app, name)
+	if err != nil {
+		return err
+	}
+
+	// autoscaled services take the count as the autoscaler minimum
+	if hpa != nil {
+		if err := serviceAutoscaleCount(name, hpa, *opts.Count); err != nil {
+			return err
+		}
+
+		min := int32(*opts.Count)
+
+		hpa.Spec.MinReplicas = &min
+
+		if _, err := p.Cluster.AutoscalingV2beta1().HorizontalPodAutoscalers(p.AppNamespace(app)).Update(hpa); err != nil {
+			return err
+		}
+
+		return nil
 	}
 
+	c := int32(*opts.Count)
+	d.Spec.Replicas = &c
+
 	if _, err := p.Cluster.AppsV1().Deployments(p.AppNamespace(app)).Update(d); err != nil {
 		return err
 	}
@@ -172,6 +306,28 @@ func (p *Provider) ServiceUpdate(app, name string, opts structs.ServiceUpdateOpt
 	return nil
 }
 
+// serviceAutoscaler returns the autoscaler of a service, or nil if the service is not autoscaled
+func (p *Provider) serviceAutoscaler(app, name string) (*asb.HorizontalPodAutoscaler, error) {
+	hpa, err := p.Cluster.AutoscalingV2beta1().HorizontalPodAutoscalers(p.AppNamespace(app)).Get(name, am.GetOptions{})
+	if ae.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return hpa, nil
+}
+
+// serviceAutoscaleCount returns an error if an autoscaled service can not use count as its minimum
+func serviceAutoscaleCount(name string, hpa *asb.HorizontalPodAutoscaler, count int) error {
+	if count < 1 || int32(count) > hpa.Spec.MaxReplicas {
+		return fmt.Errorf("count for autoscaled service %s must be between 1 and %d", name, hpa.Spec.MaxReplicas)
+	}
+
+	return nil
+}
+
 func (p *Provider) serviceInstall(app, release, service string) error {
 	a, err := p.AppGet(app)
 	if err != nil {
@@ -209,3 +365,51 @@ func (p *Provider) serviceInstall(app, release, service string) error {
 
 	return nil
 }
+
+func (p *Provider) serviceSchedules(app string) ([]serviceSchedule, error) {
+	ns, err := p.Cluster.CoreV1().Namespaces().Get(p.AppNamespace(app), am.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	sss := []serviceSchedule{}
+
+	if data, ok := ns.Annotations["convox.com/schedules"]; ok && data > "" {
+		if err := json.Unmarshal([]byte(data), &sss); err != nil {
+			return nil, err
+		}
+	}
+
+	return sss, nil
+}
+
+func (p *Provider) serviceSchedulesSave(app string, sss []serviceSchedule) error {
+	sort.Slice(sss, func(i, j int) bool {
+		if sss[i].Service == sss[j].Service {
+			return sss[i].Schedule < sss[j].Schedule
+		}
+		return sss[i].Service < sss[j].Service
+	})
+
+	ns, err := p.Cluster.CoreV1().Namespaces().Get(p.AppNamespace(app), am.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(sss)
+	if err != nil {
+		return err
+	}
+
+	if ns.Annotations == nil {
+		ns.Annotations = map[string]string{}
+	}
+
+	ns.Annotations["convox.com/schedules"] = string(data)
+
+	if _, err := p.Cluster.CoreV1().Namespaces().Update(ns); err != nil {
+		return err
+	}
+
+	return nil
+}
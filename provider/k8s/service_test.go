@@ -0,0 +1,63 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/convox/convox/pkg/options"
+	"github.com/convox/convox/pkg/structs"
+	"github.com/stretchr/testify/require"
+	aa "k8s.io/api/apps/v1"
+	asb "k8s.io/api/autoscaling/v2beta1"
+	am "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestServiceUpdate(t *testing.T) {
+	c := fake.NewSimpleClientset(
+		&aa.Deployment{ObjectMeta: am.ObjectMeta{Namespace: "rack1-app1", Name: "web"}},
+	)
+
+	p := &Provider{Cluster: c, Name: "rack1"}
+
+	require.NoError(t, p.ServiceUpdate("app1", "web", structs.ServiceUpdateOptions{Count: options.Int(3)}))
+
+	d, err := c.AppsV1().Deployments("rack1-app1").Get("web", am.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, int32(3), *d.Spec.Replicas)
+
+	err = p.ServiceUpdate("app1", "web", structs.ServiceUpdateOptions{Count: options.Int(3), Cpu: options.Int(256)})
+	require.EqualError(t, err, "cpu and memory can only be changed in convox.yml")
+}
+
+func TestServiceUpdateAutoscaled(t *testing.T) {
+	min := int32(2)
+
+	c := fake.NewSimpleClientset(
+		&aa.Deployment{ObjectMeta: am.ObjectMeta{Namespace: "rack1-app1", Name: "web"}},
+		&asb.HorizontalPodAutoscaler{ObjectMeta: am.ObjectMeta{Namespace: "rack1-app1", Name: "web"}, Spec: asb.HorizontalPodAutoscalerSpec{MinReplicas: &min, MaxReplicas: 5}},
+	)
+
+	p := &Provider{Cluster: c, Name: "rack1"}
+
+	require.NoError(t, p.ServiceUpdate("app1", "web", structs.ServiceUpdateOptions{Count: options.Int(4)}))
+
+	hpa, err := c.AutoscalingV2beta1().HorizontalPodAutoscalers("rack1-app1").Get("web", am.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, int32(4), *hpa.Spec.MinReplicas)
+
+	for _, count := range []int{0, 6} {
+		err := p.ServiceUpdate("app1", "web", structs.ServiceUpdateOptions{Count: options.Int(count)})
+		require.EqualError(t, err, "count for autoscaled service web must be between 1 and 5")
+
+		err = p.ServiceScheduleSet("app1", "web", "0 8 * * *", structs.ServiceScheduleSetOptions{Count: options.Int(count)})
+		require.EqualError(t, err, "count for autoscaled service web must be between 1 and 5")
+	}
+
+	hpa, err = c.AutoscalingV2beta1().HorizontalPodAutoscalers("rack1-app1").Get("web", am.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, int32(4), *hpa.Spec.MinReplicas)
+
+	d, err := c.AppsV1().Deployments("rack1-app1").Get("web", am.GetOptions{})
+	require.NoError(t, err)
+	require.Nil(t, d.Spec.Replicas)
+}
@@ -1,13 +1,26 @@
 package k8s
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 
 	"github.com/convox/convox/pkg/structs"
 	am "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// rack parameters and their defaults
+var systemParameters = map[string]string{
+	"RateLimitConcurrency": "20",
+	"RateLimitRead":        "600",
+	"RateLimitSocket":      "60",
+	"RateLimitWrite":       "120",
+	"SecretNamespaces":     "",
+	"SecretResolvers":      "",
+}
+
 func (p *Provider) SystemGet() (*structs.System, error) {
 	status := "running"
 
@@ -28,12 +41,18 @@ func (p *Provider) SystemGet() (*structs.System, error) {
 	// 	status = common.AtomStatus(ss)
 	// }
 
+	params, err := p.systemParameters()
+	if err != nil {
+		return nil, err
+	}
+
 	s := &structs.System{
-		Domain:   p.Domain,
-		Name:     p.Name,
-		Provider: p.Provider,
-		Status:   status,
-		Version:  p.Version,
+		Domain:     p.Domain,
+		Name:       p.Name,
+		Parameters: params,
+		Provider:   p.Provider,
+		Status:     status,
+		Version:    p.Version,
 	}
 
 	return s, nil
@@ -100,5 +119,92 @@ func (p *Provider) SystemUninstall(name string, w io.Writer, opts structs.System
 }
 
 func (p *Provider) SystemUpdate(opts structs.SystemUpdateOptions) error {
-	return fmt.Errorf("unimplemented")
+	if opts.Count != nil || opts.Type != nil || opts.Version != nil {
+		return fmt.Errorf("unimplemented")
+	}
+
+	if opts.Parameters != nil {
+		if err := p.systemParametersUpdate(opts.Parameters); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *Provider) systemParameters() (map[string]string, error) {
+	ns, err := p.Cluster.CoreV1().Namespaces().Get(p.Namespace, am.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]string{}
+
+	if data, ok := ns.Annotations["convox.com/params"]; ok && data > "" {
+		if err := json.Unmarshal([]byte(data), &params); err != nil {
+			return nil, err
+		}
+	}
+
+	for k, v := range systemParameters {
+		if _, ok := params[k]; !ok {
+			params[k] = v
+		}
+	}
+
+	return params, nil
+}
+
+func (p *Provider) systemParametersUpdate(params map[string]string) error {
+	for k, v := range params {
+		if _, ok := systemParameters[k]; !ok {
+			return fmt.Errorf("invalid parameter: %s", k)
+		}
+
+		if strings.HasPrefix(k, "RateLimit") {
+			if n, err := strconv.Atoi(v); err != nil || n < 0 {
+				return fmt.Errorf("invalid value for %s: %s", k, v)
+			}
+		}
+
+		if k == "SecretResolvers" {
+			if _, err := parseSecretResolvers(v); err != nil {
+				return err
+			}
+		}
+	}
+
+	ns, err := p.Cluster.CoreV1().Namespaces().Get(p.Namespace, am.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	current := map[string]string{}
+
+	if data, ok := ns.Annotations["convox.com/params"]; ok && data > "" {
+		if err := json.Unmarshal([]byte(data), &current); err != nil {
+			return err
+		}
+	}
+
+	for k, v := range params {
+		current[k] = v
+	}
+
+	data, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+
+	if ns.Annotations == nil {
+		ns.Annotations = map[string]string{}
+	}
+
+	ns.Annotations["convox.com/params"] = string(data)
+
+	if _, err := p.Cluster.CoreV1().Namespaces().Update(ns); err != nil {
+		return err
+	}
+
+	return nil
 }
@@ -74,6 +74,9 @@ func (p *Provider) templateHelpers() template.FuncMap {
 			}
 			return fmt.Sprintf("%s:%s.%s", repo, s.Name, r.Build), nil
 		},
+		"join": func(ss []string, sep string) string {
+			return strings.Join(ss, sep)
+		},
 		"json": func(v interface{}) (string, error) {
 			data, err := json.Marshal(v)
 			if err != nil {
@@ -102,23 +105,20 @@ func (p *Provider) templateHelpers() template.FuncMap {
 			return p.Engine.SystemHost()
 		},
 		"systemVolume": func(v string) bool {
-			return systemVolume(v)
+			return SystemVolume(v)
 		},
 		"upper": func(s string) string {
 			return strings.ToUpper(s)
 		},
-		"volumeFrom": func(app, service, v string) string {
+		"volumeFrom": func(app, service string, v manifest.ServiceVolume) string {
 			return p.volumeFrom(app, service, v)
 		},
-		"volumeSources": func(app, service string, vs []string) []string {
+		"volumeSources": func(app, service string, vs manifest.ServiceVolumes) []string {
 			return p.volumeSources(app, service, vs)
 		},
 		"volumeName": func(app, v string) string {
 			return p.volumeName(app, v)
 		},
-		"volumeTo": func(v string) (string, error) {
-			return volumeTo(v)
-		},
 	}
 }
 
@@ -0,0 +1,273 @@
+package k8s
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/convox/convox/pkg/common"
+	"github.com/convox/convox/pkg/cron"
+	"github.com/convox/convox/pkg/manifest"
+	"github.com/convox/convox/pkg/structs"
+	ab "k8s.io/api/batch/v1"
+	abb "k8s.io/api/batch/v1beta1"
+	ac "k8s.io/api/core/v1"
+	ae "k8s.io/apimachinery/pkg/api/errors"
+	am "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (p *Provider) TimerList(app string) (structs.Timers, error) {
+	a, err := p.AppGet(app)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.Release == "" {
+		return structs.Timers{}, nil
+	}
+
+	m, _, err := common.ReleaseManifest(p, app, a.Release)
+	if err != nil {
+		return nil, err
+	}
+
+	js, err := p.Cluster.BatchV1().Jobs(p.AppNamespace(app)).List(am.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	ts := structs.Timers{}
+
+	for _, mt := range m.Timers {
+		t := structs.Timer{
+			Command:     mt.Command,
+			Concurrency: common.CoalesceString(mt.Concurrency, "allow"),
+			Name:        mt.Name,
+			Schedule:    mt.Schedule,
+			Service:     mt.Service,
+			Timezone:    mt.Timezone,
+		}
+
+		cj, err := p.Cluster.BatchV1beta1().CronJobs(p.AppNamespace(app)).Get(timerName(mt.Name), am.GetOptions{})
+		if ae.IsNotFound(err) {
+			ts = append(ts, t)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		t.Suspended = common.DefaultBool(cj.Spec.Suspend, false)
+
+		if lj := timerLastJob(cj, js.Items); lj != nil {
+			t.LastRun = &lj.CreationTimestamp.Time
+			t.LastStatus = timerJobStatus(lj)
+		}
+
+		ts = append(ts, t)
+	}
+
+	return ts, nil
+}
+
+func (p *Provider) TimerResume(app, name string) error {
+	return p.timerSuspend(app, name, false)
+}
+
+// TimerRun starts a job from the timer's job template immediately, the job is owned by
+// the CronJob so that it shows up as the last run and is removed along with the timer
+func (p *Provider) TimerRun(app, name string) error {
+	cj, err := p.timerCronJob(app, name)
+	if err != nil {
+		return err
+	}
+
+	labels := map[string]string{}
+
+	for k, v := range cj.Spec.JobTemplate.Labels {
+		labels[k] = v
+	}
+
+	j := &ab.Job{
+		ObjectMeta: am.ObjectMeta{
+			Annotations: map[string]string{
+				"cronjob.kubernetes.io/instantiate": "manual",
+			},
+			Labels:    labels,
+			Name:      fmt.Sprintf("%s-%s", cj.Name, strings.ToLower(common.Id("", 6))),
+			Namespace: cj.Namespace,
+			OwnerReferences: []am.OwnerReference{
+				{
+					APIVersion: "batch/v1beta1",
+					Kind:       "CronJob",
+					Name:       cj.Name,
+					UID:        cj.UID,
+				},
+			},
+		},
+		Spec: cj.Spec.JobTemplate.Spec,
+	}
+
+	if _, err := p.Cluster.BatchV1().Jobs(cj.Namespace).Create(j); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *Provider) TimerSuspend(app, name string) error {
+	return p.timerSuspend(app, name, true)
+}
+
+func (p *Provider) timerCronJob(app, name string) (*abb.CronJob, error) {
+	cj, err := p.Cluster.BatchV1beta1().CronJobs(p.AppNamespace(app)).Get(timerName(name), am.GetOptions{})
+	if ae.IsNotFound(err) {
+		return nil, fmt.Errorf("no such timer: %s", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return cj, nil
+}
+
+// timerSuspend patches only the suspend field, it is not part of the timer template
+// so a suspended timer stays suspended across deploys until it is resumed
+func (p *Provider) timerSuspend(app, name string, suspend bool) error {
+	cj, err := p.timerCronJob(app, name)
+	if err != nil {
+		return err
+	}
+
+	patch := fmt.Sprintf(`{"spec":{"suspend":%t}}`, suspend)
+
+	if _, err := p.Cluster.BatchV1beta1().CronJobs(cj.Namespace).Patch(cj.Name, types.StrategicMergePatchType, []byte(patch)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func timerLastJob(cj *abb.CronJob, js []ab.Job) *ab.Job {
+	var last *ab.Job
+
+	for i, j := range js {
+		for _, o := range j.OwnerReferences {
+			if o.UID != cj.UID {
+				continue
+			}
+
+			if last == nil || j.CreationTimestamp.After(last.CreationTimestamp.Time) {
+				last = &js[i]
+			}
+		}
+	}
+
+	return last
+}
+
+func timerJobStatus(j *ab.Job) string {
+	for _, c := range j.Status.Conditions {
+		if c.Status != ac.ConditionTrue {
+			continue
+		}
+
+		switch c.Type {
+		case ab.JobComplete:
+			return "succeeded"
+		case ab.JobFailed:
+			return "failed"
+		}
+	}
+
+	return "running"
+}
+
+// timerSchedule returns the schedule of a timer on the UTC clock that CronJobs run on, using the
+// current offset of the timer's time zone, for clusters that can not run CronJobs in a time zone.
+// workerTimerZones follows changes in that offset, so a timer can fire an hour early or late for up
+// to a minute after a daylight saving time change.
+func timerSchedule(name, schedule, timezone string, now time.Time) (string, error) {
+	if timezone == "" {
+		return schedule, nil
+	}
+
+	loc, err := manifest.Timer{Name: name, Timezone: timezone}.Location()
+	if err != nil {
+		return "", err
+	}
+
+	cs, err := cron.Parse(schedule)
+	if err != nil {
+		return "", err
+	}
+
+	_, offset := now.In(loc).Zone()
+
+	utc, err := cs.Shift(offset / 60)
+	if err != nil {
+		return "", fmt.Errorf("can not run the schedule of timer %s in timezone %s: %s", name, timezone, err)
+	}
+
+	return utc, nil
+}
+
+// timerTimeZones returns true if the cluster runs CronJobs in the time zone set on them, which
+// kubernetes does from 1.25
+func (p *Provider) timerTimeZones() bool {
+	v, err := p.Cluster.Discovery().ServerVersion()
+	if err != nil {
+		return false
+	}
+
+	major, err := strconv.Atoi(v.Major)
+	if err != nil {
+		return false
+	}
+
+	minor, err := strconv.Atoi(strings.TrimSuffix(v.Minor, "+"))
+	if err != nil {
+		return false
+	}
+
+	return major > 1 || (major == 1 && minor >= 25)
+}
+
+// timerZones moves the schedules of timers with a time zone when the offset of the zone changes
+func (p *Provider) timerZones(app string, now time.Time) error {
+	cjs, err := p.Cluster.BatchV1beta1().CronJobs(p.AppNamespace(app)).List(am.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	for _, cj := range cjs.Items {
+		timezone := cj.Annotations["convox.com/timezone"]
+
+		if timezone == "" {
+			continue
+		}
+
+		schedule, err := timerSchedule(strings.TrimPrefix(cj.Name, "timer-"), cj.Annotations["convox.com/schedule"], timezone, now)
+		if err != nil {
+			return err
+		}
+
+		if schedule == cj.Spec.Schedule {
+			continue
+		}
+
+		patch := fmt.Sprintf(`{"spec":{"schedule":%q}}`, schedule)
+
+		if _, err := p.Cluster.BatchV1beta1().CronJobs(cj.Namespace).Patch(cj.Name, types.StrategicMergePatchType, []byte(patch)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func timerName(name string) string {
+	return fmt.Sprintf("timer-%s", name)
+}
@@ -0,0 +1,78 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/convox/convox/pkg/manifest"
+	"github.com/convox/convox/pkg/structs"
+	"github.com/convox/convox/pkg/templater"
+	"github.com/gobuffalo/packr"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type testTimerEngine struct {
+	Engine
+}
+
+func (e *testTimerEngine) RepositoryHost(app string) (string, bool, error) {
+	return "registry.example.org/" + app, false, nil
+}
+
+func TestTimerTimeZones(t *testing.T) {
+	tests := []struct {
+		Major string
+		Minor string
+		Zones bool
+	}{
+		{Major: "1", Minor: "24", Zones: false},
+		{Major: "1", Minor: "25", Zones: true},
+		{Major: "1", Minor: "27+", Zones: true},
+		{Major: "", Minor: "", Zones: false},
+	}
+
+	for _, test := range tests {
+		c := fake.NewSimpleClientset()
+		c.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{Major: test.Major, Minor: test.Minor}
+
+		p := &Provider{Cluster: c}
+
+		require.Equal(t, test.Zones, p.timerTimeZones(), test.Major+"."+test.Minor)
+	}
+}
+
+func TestTimerTemplateTimeZones(t *testing.T) {
+	p := &Provider{Engine: &testTimerEngine{}, Name: "rack1"}
+	p.templater = templater.New(packr.NewBox("../k8s/template"), p.templateHelpers())
+
+	params := map[string]interface{}{
+		"App":         &structs.App{Name: "app1"},
+		"Concurrency": "Allow",
+		"Namespace":   "rack1-app1",
+		"Rack":        "rack1",
+		"Release":     &structs.Release{Id: "R1234"},
+		"Schedule":    "0 5 1 * *",
+		"Service":     &manifest.Service{Name: "web"},
+		"Timer":       manifest.Timer{Name: "monthly", Command: "bin/monthly", Schedule: "0 0 1 * *", Timezone: "America/New_York"},
+		"TimeZones":   false,
+	}
+
+	data, err := p.RenderTemplate("app/timer", params)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "apiVersion: batch/v1beta1\n")
+	require.Contains(t, string(data), "schedule: 0 5 1 * *")
+	require.Contains(t, string(data), "convox.com/timezone: America/New_York")
+	require.NotContains(t, string(data), "timeZone:")
+
+	params["Schedule"] = "0 0 1 * *"
+	params["TimeZones"] = true
+
+	data, err = p.RenderTemplate("app/timer", params)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "apiVersion: batch/v1\n")
+	require.Contains(t, string(data), "schedule: 0 0 1 * *")
+	require.Contains(t, string(data), "timeZone: America/New_York")
+	require.NotContains(t, string(data), "convox.com/timezone")
+}
@@ -0,0 +1,159 @@
+package k8s
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/convox/convox/pkg/common"
+	"github.com/convox/convox/pkg/structs"
+	ac "k8s.io/api/core/v1"
+	ae "k8s.io/apimachinery/pkg/api/errors"
+	am "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (p *Provider) TokenAuthenticate(secret string) (*structs.Token, error) {
+	ss, err := p.tokenSecrets()
+	if err != nil {
+		return nil, err
+	}
+
+	hash := tokenHash(secret)
+
+	for _, s := range ss {
+		if subtle.ConstantTimeCompare(s.Data["hash"], []byte(hash)) == 1 {
+			return p.tokenFromSecret(s)
+		}
+	}
+
+	return nil, fmt.Errorf("invalid token")
+}
+
+func (p *Provider) TokenCreate(name string, opts structs.TokenCreateOptions) (*structs.Token, error) {
+	if name == "" {
+		return nil, fmt.Errorf("token name required")
+	}
+
+	role := common.DefaultString(opts.Role, structs.TokenRoleReadOnly)
+
+	if !structs.TokenRoleValid(role) {
+		return nil, fmt.Errorf("invalid role: %s", role)
+	}
+
+	secret, err := common.RandomString(40)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &structs.Token{
+		Id:      common.Id("T", 10),
+		Name:    name,
+		Role:    role,
+		Apps:    opts.Apps,
+		Created: time.Now().UTC(),
+	}
+
+	s := &ac.Secret{
+		ObjectMeta: am.ObjectMeta{
+			Name: tokenSecretName(t.Id),
+			Labels: map[string]string{
+				"system": "convox",
+				"rack":   p.Name,
+				"type":   "token",
+			},
+		},
+		Type: ac.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"apps":    []byte(strings.Join(t.Apps, ",")),
+			"created": []byte(t.Created.Format(time.RFC3339)),
+			"hash":    []byte(tokenHash(secret)),
+			"id":      []byte(t.Id),
+			"name":    []byte(t.Name),
+			"role":    []byte(t.Role),
+		},
+	}
+
+	if _, err := p.Cluster.CoreV1().Secrets(p.Namespace).Create(s); err != nil {
+		return nil, err
+	}
+
+	t.Secret = secret
+
+	return t, nil
+}
+
+func (p *Provider) TokenDelete(id string) error {
+	err := p.Cluster.CoreV1().Secrets(p.Namespace).Delete(tokenSecretName(id), nil)
+	if ae.IsNotFound(err) {
+		return fmt.Errorf("token not found: %s", id)
+	}
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *Provider) TokenList() (structs.Tokens, error) {
+	ss, err := p.tokenSecrets()
+	if err != nil {
+		return nil, err
+	}
+
+	ts := structs.Tokens{}
+
+	for _, s := range ss {
+		t, err := p.tokenFromSecret(s)
+		if err != nil {
+			return nil, err
+		}
+
+		ts = append(ts, *t)
+	}
+
+	return ts, nil
+}
+
+func (p *Provider) tokenFromSecret(s ac.Secret) (*structs.Token, error) {
+	t := &structs.Token{
+		Id:   string(s.Data["id"]),
+		Name: string(s.Data["name"]),
+		Role: string(s.Data["role"]),
+	}
+
+	if apps := string(s.Data["apps"]); apps != "" {
+		t.Apps = strings.Split(apps, ",")
+	}
+
+	created, err := time.Parse(time.RFC3339, string(s.Data["created"]))
+	if err != nil {
+		return nil, err
+	}
+
+	t.Created = created
+
+	return t, nil
+}
+
+func (p *Provider) tokenSecrets() ([]ac.Secret, error) {
+	ss, err := p.Cluster.CoreV1().Secrets(p.Namespace).List(am.ListOptions{
+		LabelSelector: fmt.Sprintf("system=convox,rack=%s,type=token", p.Name),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ss.Items, nil
+}
+
+func tokenHash(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
+func tokenSecretName(id string) string {
+	return fmt.Sprintf("token-%s", strings.ToLower(id))
+}
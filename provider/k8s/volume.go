@@ -0,0 +1,646 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/convox/convox/pkg/common"
+	"github.com/convox/convox/pkg/manifest"
+	"github.com/convox/convox/pkg/structs"
+	aa "k8s.io/api/apps/v1"
+	ac "k8s.io/api/core/v1"
+	ae "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	am "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	volumeSnapshotApi = "/apis/snapshot.storage.k8s.io/v1beta1"
+)
+
+func (p *Provider) VolumeList(app string) (structs.Volumes, error) {
+	a, err := p.AppGet(app)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.Release == "" {
+		return structs.Volumes{}, nil
+	}
+
+	m, _, err := common.ReleaseManifest(p, app, a.Release)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := p.volumeUsage(app)
+	if err != nil {
+		return nil, err
+	}
+
+	vs := structs.Volumes{}
+
+	for _, av := range p.appVolumes(app, m.Services) {
+		v := structs.Volume{
+			Access:   av.Access,
+			Class:    av.Class,
+			Name:     av.Name,
+			Services: av.Services,
+			Size:     int64(av.Size) * 1024 * 1024 * 1024,
+			Status:   "pending",
+		}
+
+		pvc, err := p.Cluster.CoreV1().PersistentVolumeClaims(p.AppNamespace(app)).Get(p.volumeName(app, av.Source), am.GetOptions{})
+		if err != nil && !ae.IsNotFound(err) {
+			return nil, err
+		}
+
+		if err == nil {
+			if pvc.Spec.StorageClassName != nil {
+				v.Class = *pvc.Spec.StorageClassName
+			}
+
+			if size, ok := pvc.Status.Capacity["storage"]; ok {
+				v.Size = size.Value()
+			}
+
+			v.Status = strings.ToLower(string(pvc.Status.Phase))
+			v.Used = usage[pvc.Name]
+		}
+
+		vs = append(vs, v)
+	}
+
+	return vs, nil
+}
+
+// VolumeRestore replaces the claim for a volume with one provisioned from a snapshot, services
+// that mount the volume are stopped while it is replaced. The snapshot can belong to another app.
+func (p *Provider) VolumeRestore(app, name, id string, opts structs.VolumeRestoreOptions) error {
+	// restore into the latest release so a new app can be seeded before its first promote
+	r, err := common.ReleaseLatest(p, app)
+	if err != nil {
+		return err
+	}
+
+	if r == nil {
+		return fmt.Errorf("no release for app: %s", app)
+	}
+
+	m, _, err := common.ReleaseManifest(p, app, r.Id)
+	if err != nil {
+		return err
+	}
+
+	v, err := p.appVolume(app, m.Services, name)
+	if err != nil {
+		return err
+	}
+
+	if v.Class == "" {
+		return fmt.Errorf("volume %s has no class and can not be restored from a snapshot", name)
+	}
+
+	source := common.DefaultString(opts.SourceApp, app)
+
+	vs, err := p.volumeSnapshot(source, id)
+	if err != nil {
+		return err
+	}
+
+	if vs.status() != "ready" {
+		return fmt.Errorf("snapshot %s is not ready", id)
+	}
+
+	if source != app {
+		if err := p.volumeSnapshotImport(source, app, vs, name); err != nil {
+			return err
+		}
+	}
+
+	v.Snapshot = id
+
+	ns := p.AppNamespace(app)
+	claim := p.volumeName(app, v.Source)
+
+	ds, err := p.volumeDeployments(app, claim)
+	if err != nil {
+		return err
+	}
+
+	// services are started again even when the restore fails
+	defer func() {
+		for _, d := range ds {
+			p.volumeScale(app, d.Name, common.DefaultInt32(d.Spec.Replicas, 1))
+		}
+	}()
+
+	for _, d := range ds {
+		if err := p.volumeScale(app, d.Name, 0); err != nil {
+			return err
+		}
+	}
+
+	err = common.Wait(2*time.Second, 5*time.Minute, 1, func() (bool, error) {
+		for _, d := range ds {
+			pds, err := p.Cluster.CoreV1().Pods(ns).List(am.ListOptions{LabelSelector: fmt.Sprintf("app=%s,service=%s,type=service", app, d.Name)})
+			if err != nil {
+				return false, err
+			}
+
+			if len(pds.Items) > 0 {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := p.Cluster.CoreV1().PersistentVolumeClaims(ns).Delete(claim, nil); err != nil && !ae.IsNotFound(err) {
+		return err
+	}
+
+	err = common.Wait(2*time.Second, 5*time.Minute, 1, func() (bool, error) {
+		_, err := p.Cluster.CoreV1().PersistentVolumeClaims(ns).Get(claim, am.GetOptions{})
+		if ae.IsNotFound(err) {
+			return true, nil
+		}
+
+		return false, err
+	})
+	if err != nil {
+		return fmt.Errorf("volume %s is still in use", name)
+	}
+
+	params := map[string]interface{}{
+		"App":       app,
+		"Namespace": ns,
+		"Rack":      p.Name,
+		"Volumes":   []appVolume{*v},
+	}
+
+	data, err := p.RenderTemplate("app/volumes", params)
+	if err != nil {
+		return err
+	}
+
+	ldata, err := ApplyLabels(data, fmt.Sprintf("system=convox,provider=k8s,rack=%s,app=%s", p.Name, app))
+	if err != nil {
+		return err
+	}
+
+	if err := Apply(ldata); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *Provider) VolumeSnapshot(app, name string) (*structs.VolumeSnapshot, error) {
+	a, err := p.AppGet(app)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.Release == "" {
+		return nil, fmt.Errorf("no release for app: %s", app)
+	}
+
+	m, _, err := common.ReleaseManifest(p, app, a.Release)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := p.appVolume(app, m.Services, name)
+	if err != nil {
+		return nil, err
+	}
+
+	claim := p.volumeName(app, v.Source)
+
+	if _, err := p.Cluster.CoreV1().PersistentVolumeClaims(p.AppNamespace(app)).Get(claim, am.GetOptions{}); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	vs := &structs.VolumeSnapshot{
+		Created: now,
+		Id:      strings.ToLower(fmt.Sprintf("%s-%s", name, now.Format("20060102150405"))),
+		Status:  "pending",
+		Volume:  name,
+	}
+
+	params := map[string]interface{}{
+		"App":       app,
+		"Claim":     claim,
+		"Name":      vs.Id,
+		"Namespace": p.AppNamespace(app),
+		"Rack":      p.Name,
+		"Volume":    name,
+	}
+
+	data, err := p.RenderTemplate("app/volume-snapshot", params)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := Apply(data); err != nil {
+		return nil, err
+	}
+
+	return vs, nil
+}
+
+func (p *Provider) VolumeSnapshotList(app, name string) (structs.VolumeSnapshots, error) {
+	if _, err := p.AppGet(app); err != nil {
+		return nil, err
+	}
+
+	data, err := p.Cluster.Discovery().RESTClient().Get().AbsPath(volumeSnapshotApi, "namespaces", p.AppNamespace(app), "volumesnapshots").Param("labelSelector", fmt.Sprintf("type=snapshot,volume=%s", name)).DoRaw()
+	if ae.IsNotFound(err) {
+		return nil, fmt.Errorf("volume snapshots are not available on this rack")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var vsl struct {
+		Items []volumeSnapshot `json:"items"`
+	}
+
+	if err := json.Unmarshal(data, &vsl); err != nil {
+		return nil, err
+	}
+
+	vss := structs.VolumeSnapshots{}
+
+	for _, vs := range vsl.Items {
+		vss = append(vss, vs.snapshot())
+	}
+
+	return vss, nil
+}
+
+// volumeSnapshot is the part of a VolumeSnapshot from the CSI snapshot api that the rack reads,
+// there is no typed client for it
+type volumeSnapshot struct {
+	Metadata struct {
+		CreationTimestamp time.Time         `json:"creationTimestamp"`
+		Labels            map[string]string `json:"labels"`
+		Name              string            `json:"name"`
+	} `json:"metadata"`
+	Status struct {
+		BoundVolumeSnapshotContentName string `json:"boundVolumeSnapshotContentName"`
+		Error                          *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+		ReadyToUse  bool   `json:"readyToUse"`
+		RestoreSize string `json:"restoreSize"`
+	} `json:"status"`
+}
+
+func (vs volumeSnapshot) snapshot() structs.VolumeSnapshot {
+	s := structs.VolumeSnapshot{
+		Created: vs.Metadata.CreationTimestamp,
+		Id:      vs.Metadata.Name,
+		Status:  vs.status(),
+		Volume:  vs.Metadata.Labels["volume"],
+	}
+
+	if q, err := resource.ParseQuantity(vs.Status.RestoreSize); err == nil {
+		s.Size = q.Value()
+	}
+
+	return s
+}
+
+func (vs volumeSnapshot) status() string {
+	switch {
+	case vs.Status.Error != nil:
+		return "failed"
+	case vs.Status.ReadyToUse:
+		return "ready"
+	default:
+		return "pending"
+	}
+}
+
+// appVolume is a persistent volume claim for an app, Source is the path returned by volumeFrom
+// and Snapshot is only set when the claim is restored
+type appVolume struct {
+	Access   string
+	Class    string
+	Name     string
+	Services []string
+	Size     int
+	Snapshot string
+	Source   string
+}
+
+// appVolumes returns the claims needed by the services of an app, shared volumes are only claimed once
+func (p *Provider) appVolumes(app string, ss manifest.Services) []appVolume {
+	vsh := map[string]appVolume{}
+
+	for _, s := range ss {
+		for _, v := range s.Volumes {
+			source := p.volumeFrom(app, s.Name, v)
+
+			if SystemVolume(source) {
+				continue
+			}
+
+			if av, ok := vsh[source]; ok {
+				av.Services = append(av.Services, s.Name)
+				vsh[source] = av
+				continue
+			}
+
+			vsh[source] = appVolume{
+				Access:   p.volumeAccess(v.Access),
+				Class:    v.Class,
+				Name:     volumeLabel(s.Name, v),
+				Services: []string{s.Name},
+				Size:     v.Size,
+				Source:   source,
+			}
+		}
+	}
+
+	vs := []appVolume{}
+
+	for _, v := range vsh {
+		vs = append(vs, v)
+	}
+
+	sort.Slice(vs, func(i, j int) bool { return vs[i].Source < vs[j].Source })
+
+	return vs
+}
+
+// appVolume finds a volume of an app by the name returned by volumeLabel
+func (p *Provider) appVolume(app string, ss manifest.Services, name string) (*appVolume, error) {
+	for _, v := range p.appVolumes(app, ss) {
+		if v.Name == name {
+			return &v, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no such volume: %s", name)
+}
+
+// volumesCheck returns an error for changes that kubernetes can not make to an existing claim,
+// volumes can grow in place but can not shrink or change their class or access mode
+func (p *Provider) volumesCheck(app string, vs []appVolume) error {
+	for _, v := range vs {
+		pvc, err := p.Cluster.CoreV1().PersistentVolumeClaims(p.AppNamespace(app)).Get(p.volumeName(app, v.Source), am.GetOptions{})
+		if ae.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		desired := resource.MustParse(fmt.Sprintf("%dGi", v.Size))
+
+		if current, ok := pvc.Spec.Resources.Requests["storage"]; ok && desired.Cmp(current) < 0 {
+			return fmt.Errorf("volume %s can not shrink from %s to %s", v.Name, current.String(), desired.String())
+		}
+
+		if v.Class != "" && pvc.Spec.StorageClassName != nil && *pvc.Spec.StorageClassName != v.Class {
+			return fmt.Errorf("can not change class of volume %s from %s to %s", v.Name, *pvc.Spec.StorageClassName, v.Class)
+		}
+
+		if len(pvc.Spec.AccessModes) > 0 && string(pvc.Spec.AccessModes[0]) != v.Access {
+			return fmt.Errorf("can not change access of volume %s from %s to %s", v.Name, pvc.Spec.AccessModes[0], v.Access)
+		}
+	}
+
+	return nil
+}
+
+// volumesSnapshots sets the snapshot of claims that were restored from one, the data source
+// of a claim can not change so it has to be rendered on every release after a restore
+func (p *Provider) volumesSnapshots(app string, vs []appVolume) error {
+	for i, v := range vs {
+		pvc, err := p.Cluster.CoreV1().PersistentVolumeClaims(p.AppNamespace(app)).Get(p.volumeName(app, v.Source), am.GetOptions{})
+		if ae.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if snapshot, ok := pvc.ObjectMeta.Annotations["convox.com/snapshot"]; ok {
+			vs[i].Snapshot = snapshot
+		}
+	}
+
+	return nil
+}
+
+// volumeAccess returns the access mode for a volume, volumes that do not set one use
+// the default of the engine
+func (p *Provider) volumeAccess(access string) string {
+	if access != "" {
+		return access
+	}
+
+	if ve, ok := p.Engine.(VolumeAccessEngine); ok {
+		return ve.VolumeAccessDefault()
+	}
+
+	return manifest.DefaultVolumeAccess
+}
+
+// volumeLabel is the name of a volume outside of the manifest, volumes that are not shared
+// are prefixed with their service
+func volumeLabel(service string, v manifest.ServiceVolume) string {
+	name := strings.Trim(strings.Replace(v.Name, "/", "-", -1), "-")
+
+	if v.Shared {
+		return name
+	}
+
+	return fmt.Sprintf("%s-%s", service, name)
+}
+
+// volumeDeployments returns the services of an app that mount a claim
+func (p *Provider) volumeDeployments(app, claim string) ([]aa.Deployment, error) {
+	ds, err := p.Cluster.AppsV1().Deployments(p.AppNamespace(app)).List(am.ListOptions{LabelSelector: fmt.Sprintf("app=%s,type=service", app)})
+	if err != nil {
+		return nil, err
+	}
+
+	vds := []aa.Deployment{}
+
+	for _, d := range ds.Items {
+		for _, v := range d.Spec.Template.Spec.Volumes {
+			if v.PersistentVolumeClaim != nil && v.PersistentVolumeClaim.ClaimName == claim {
+				vds = append(vds, d)
+				break
+			}
+		}
+	}
+
+	return vds, nil
+}
+
+func (p *Provider) volumeScale(app, service string, replicas int32) error {
+	patch := fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas)
+
+	if _, err := p.Cluster.AppsV1().Deployments(p.AppNamespace(app)).Patch(service, types.StrategicMergePatchType, []byte(patch)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *Provider) volumeSnapshot(app, id string) (*volumeSnapshot, error) {
+	data, err := p.Cluster.Discovery().RESTClient().Get().AbsPath(volumeSnapshotApi, "namespaces", p.AppNamespace(app), "volumesnapshots", id).DoRaw()
+	if ae.IsNotFound(err) {
+		return nil, fmt.Errorf("no such snapshot: %s", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var vs volumeSnapshot
+
+	if err := json.Unmarshal(data, &vs); err != nil {
+		return nil, err
+	}
+
+	return &vs, nil
+}
+
+// volumeSnapshotImport makes a snapshot from another app available to restore into app, snapshots
+// are namespaced so a copy of its content is bound to a new snapshot with the same id. The content is
+// retained so the snapshot of the source app survives the copy being deleted.
+func (p *Provider) volumeSnapshotImport(source, app string, vs *volumeSnapshot, volume string) error {
+	data, err := p.Cluster.Discovery().RESTClient().Get().AbsPath(volumeSnapshotApi, "volumesnapshotcontents", vs.Status.BoundVolumeSnapshotContentName).DoRaw()
+	if err != nil {
+		return err
+	}
+
+	var vsc struct {
+		Spec struct {
+			Driver string `json:"driver"`
+		} `json:"spec"`
+		Status struct {
+			SnapshotHandle string `json:"snapshotHandle"`
+		} `json:"status"`
+	}
+
+	if err := json.Unmarshal(data, &vsc); err != nil {
+		return err
+	}
+
+	content := fmt.Sprintf("%s-%s", p.AppNamespace(app), vs.Metadata.Name)
+
+	params := map[string]interface{}{
+		"App":       app,
+		"Content":   content,
+		"Driver":    vsc.Spec.Driver,
+		"Handle":    vsc.Status.SnapshotHandle,
+		"Name":      vs.Metadata.Name,
+		"Namespace": p.AppNamespace(app),
+		"Rack":      p.Name,
+		"Snapshot":  vs.Metadata.Name,
+		"Volume":    volume,
+	}
+
+	cdata, err := p.RenderTemplate("app/volume-snapshot-content", params)
+	if err != nil {
+		return err
+	}
+
+	sdata, err := p.RenderTemplate("app/volume-snapshot", params)
+	if err != nil {
+		return err
+	}
+
+	if err := Apply(cdata); err != nil {
+		return err
+	}
+
+	if err := Apply(sdata); err != nil {
+		return err
+	}
+
+	return common.Wait(2*time.Second, 5*time.Minute, 1, func() (bool, error) {
+		ivs, err := p.volumeSnapshot(app, vs.Metadata.Name)
+		if err != nil {
+			return false, err
+		}
+
+		return ivs.status() == "ready", nil
+	})
+}
+
+// volumeUsage returns the bytes used on each claim in the namespace of an app as reported by
+// the kubelets running its pods, nodes that can not be reached are skipped
+func (p *Provider) volumeUsage(app string) (map[string]int64, error) {
+	ns := p.AppNamespace(app)
+
+	pds, err := p.Cluster.CoreV1().Pods(ns).List(am.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := map[string]bool{}
+
+	for _, pd := range pds.Items {
+		if pd.Status.Phase != ac.PodRunning || pd.Spec.NodeName == "" {
+			continue
+		}
+
+		for _, v := range pd.Spec.Volumes {
+			if v.PersistentVolumeClaim != nil {
+				nodes[pd.Spec.NodeName] = true
+			}
+		}
+	}
+
+	usage := map[string]int64{}
+
+	for node := range nodes {
+		data, err := p.Cluster.CoreV1().RESTClient().Get().Resource("nodes").Name(node).SubResource("proxy").Suffix("stats/summary").DoRaw()
+		if err != nil {
+			continue
+		}
+
+		var summary struct {
+			Pods []struct {
+				Volume []struct {
+					PvcRef *struct {
+						Name      string `json:"name"`
+						Namespace string `json:"namespace"`
+					} `json:"pvcRef"`
+					UsedBytes int64 `json:"usedBytes"`
+				} `json:"volume"`
+			} `json:"pods"`
+		}
+
+		if err := json.Unmarshal(data, &summary); err != nil {
+			return nil, err
+		}
+
+		for _, pd := range summary.Pods {
+			for _, v := range pd.Volume {
+				if v.PvcRef != nil && v.PvcRef.Namespace == ns {
+					usage[v.PvcRef.Name] = v.UsedBytes
+				}
+			}
+		}
+	}
+
+	return usage, nil
+}
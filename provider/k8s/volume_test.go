@@ -0,0 +1,72 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/convox/convox/pkg/manifest"
+	"github.com/convox/convox/pkg/templater"
+	"github.com/gobuffalo/packr"
+	"github.com/stretchr/testify/require"
+	ac "k8s.io/api/core/v1"
+	am "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type testVolumeEngine struct {
+	Engine
+}
+
+func (e *testVolumeEngine) VolumeAccessDefault() string {
+	return "ReadWriteOnce"
+}
+
+func TestAppVolumesAccess(t *testing.T) {
+	ss := manifest.Services{
+		{Name: "web", Volumes: manifest.ServiceVolumes{
+			{Name: "data", Path: "/data", Size: 10, Shared: true},
+			{Name: "cache", Path: "/cache", Size: 10, Access: "ReadOnlyMany"},
+		}},
+	}
+
+	p := &Provider{Name: "rack1"}
+
+	vs := p.appVolumes("app1", ss)
+	require.Len(t, vs, 2)
+	require.Equal(t, "data", vs[0].Name)
+	require.Equal(t, "ReadWriteMany", vs[0].Access)
+	require.Equal(t, "web-cache", vs[1].Name)
+	require.Equal(t, "ReadOnlyMany", vs[1].Access)
+
+	p.Engine = &testVolumeEngine{}
+
+	vs = p.appVolumes("app1", ss)
+	require.Len(t, vs, 2)
+	require.Equal(t, "ReadWriteOnce", vs[0].Access)
+	require.Equal(t, "ReadOnlyMany", vs[1].Access)
+}
+
+func TestVolumesSnapshots(t *testing.T) {
+	p := &Provider{Name: "rack1"}
+	p.templater = templater.New(packr.NewBox("../k8s/template"), p.templateHelpers())
+
+	vs := []appVolume{
+		{Access: "ReadWriteOnce", Class: "standard", Name: "data", Size: 10, Source: "/mnt/volumes/app1/app/data"},
+		{Access: "ReadWriteOnce", Class: "standard", Name: "web-cache", Size: 10, Source: "/mnt/volumes/app1/service/web/cache"},
+	}
+
+	p.Cluster = fake.NewSimpleClientset(
+		&ac.PersistentVolumeClaim{ObjectMeta: am.ObjectMeta{Namespace: "rack1-app1", Name: p.volumeName("app1", vs[0].Source), Annotations: map[string]string{"convox.com/snapshot": "snap1"}}},
+		&ac.PersistentVolumeClaim{ObjectMeta: am.ObjectMeta{Namespace: "rack1-app1", Name: p.volumeName("app1", vs[1].Source)}},
+	)
+
+	require.NoError(t, p.volumesSnapshots("app1", vs))
+	require.Equal(t, "snap1", vs[0].Snapshot)
+	require.Equal(t, "", vs[1].Snapshot)
+
+	data, err := p.RenderTemplate("app/volumes", map[string]interface{}{"App": "app1", "Namespace": "rack1-app1", "Rack": "rack1", "Volumes": vs})
+	require.NoError(t, err)
+	require.Contains(t, string(data), "convox.com/snapshot: snap1")
+	require.Contains(t, string(data), "kind: VolumeSnapshot\n    name: snap1")
+	require.Equal(t, 1, strings.Count(string(data), "dataSource:"))
+}
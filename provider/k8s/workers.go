@@ -1,11 +1,220 @@
 package k8s
 
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/convox/convox/pkg/common"
+	"github.com/convox/convox/pkg/cron"
+	"github.com/convox/convox/pkg/kctl"
+	"github.com/convox/convox/pkg/options"
+	"github.com/convox/convox/pkg/structs"
+)
+
 const (
 	BuildMax = 30
 )
 
+// Workers runs the periodic workers on the api replica that holds the leader lock
 func (p *Provider) Workers() error {
+	return kctl.RunLeader(p.Cluster, p.Namespace, "convox-k8s-workers", p.workers)
+}
+
+func (p *Provider) workers(stop <-chan struct{}) {
 	// go common.Tick(1*time.Hour, workerHandler(p.workerBuildCleanup))
+	go common.TickUntil(stop, 1*time.Minute, p.workerAppExpire)
+	go common.TickUntil(stop, 1*time.Minute, p.workerResourceBackups(map[string]time.Time{}))
+	go common.TickUntil(stop, 10*time.Second, p.workerResourceFailover)
+	go common.TickUntil(stop, 1*time.Minute, p.workerServiceSchedules)
+	go common.TickUntil(stop, 1*time.Minute, p.workerTimerZones)
+}
+
+// workerAppExpire deletes preview apps that have passed their expiry
+func (p *Provider) workerAppExpire() error {
+	as, err := p.AppList()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	for _, a := range as {
+		expires := a.Expires()
+
+		if expires.IsZero() || expires.After(now) || a.Status == "deleting" {
+			continue
+		}
+
+		// locked apps are kept until they are unlocked
+		if a.Locked {
+			continue
+		}
+
+		fmt.Printf("ns=k8s at=app.expire app=%s expires=%q\n", a.Name, expires.Format(time.RFC3339))
+
+		if err := p.AppDelete(a.Name); err != nil {
+			fmt.Printf("ns=k8s at=app.expire app=%s error=%q\n", a.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// workerResourceBackups backs up resources that have a backup schedule in the manifest of
+// the current release, a failed backup is not retried until the schedule fires again.
+// workers only run on the leader so attempts are only tracked for the current term
+func (p *Provider) workerResourceBackups(attempts map[string]time.Time) common.Ticker {
+	return func() error {
+		return p.workerResourceBackupsRun(attempts)
+	}
+}
+
+func (p *Provider) workerResourceBackupsRun(attempts map[string]time.Time) error {
+	as, err := p.AppList()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+
+	for _, a := range as {
+		if a.Status == "deleting" || a.Release == "" {
+			continue
+		}
+
+		m, _, err := common.ReleaseManifest(p, a.Name, a.Release)
+		if err != nil {
+			fmt.Printf("ns=k8s at=resource.backup app=%s error=%q\n", a.Name, err)
+			continue
+		}
+
+		for _, mr := range m.Resources {
+			if mr.BackupSchedule() == "" {
+				continue
+			}
+
+			cs, err := cron.Parse(mr.BackupSchedule())
+			if err != nil {
+				continue
+			}
+
+			retention, err := mr.BackupRetention()
+			if err != nil {
+				continue
+			}
+
+			rbs, err := p.resourceBackups(a.Name, mr.Name)
+			if err != nil {
+				fmt.Printf("ns=k8s at=resource.backup app=%s resource=%s error=%q\n", a.Name, mr.Name, err)
+				continue
+			}
+
+			key := fmt.Sprintf("%s/%s", a.Name, mr.Name)
+
+			since := attempts[key]
+
+			if len(rbs) > 0 && rbs[0].Created.After(since) {
+				since = rbs[0].Created
+			}
+
+			if since.Before(now.Add(-1 * time.Hour)) {
+				since = now.Add(-1 * time.Hour)
+			}
+
+			if cs.Prev(now, since).IsZero() {
+				continue
+			}
+
+			attempts[key] = now
+
+			r, err := p.ResourceGet(a.Name, mr.Name)
+			if err != nil {
+				fmt.Printf("ns=k8s at=resource.backup app=%s resource=%s error=%q\n", a.Name, mr.Name, err)
+				continue
+			}
+
+			rb, err := p.resourceBackup(a.Name, r, retention)
+			if err != nil {
+				fmt.Printf("ns=k8s at=resource.backup app=%s resource=%s error=%q\n", a.Name, mr.Name, err)
+				continue
+			}
+
+			fmt.Printf("ns=k8s at=resource.backup app=%s resource=%s id=%s size=%d\n", a.Name, mr.Name, rb.Id, rb.Size)
+		}
+	}
+
+	return nil
+}
+
+// workerServiceSchedules applies scale schedules that have fired since they were last applied,
+// schedules missed for more than an hour are skipped
+func (p *Provider) workerServiceSchedules() error {
+	as, err := p.AppList()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+
+	for _, a := range as {
+		if a.Status == "deleting" {
+			continue
+		}
+
+		sss, err := p.serviceSchedules(a.Name)
+		if err != nil {
+			fmt.Printf("ns=k8s at=service.schedule app=%s error=%q\n", a.Name, err)
+			continue
+		}
+
+		type due struct {
+			at    time.Time
+			index int
+		}
+
+		dues := []due{}
+
+		for i, sc := range sss {
+			cs, err := cron.Parse(sc.Schedule)
+			if err != nil {
+				continue
+			}
+
+			since := sc.Applied
+
+			if since.Before(now.Add(-1 * time.Hour)) {
+				since = now.Add(-1 * time.Hour)
+			}
+
+			if at := cs.Prev(now, since); !at.IsZero() {
+				dues = append(dues, due{at: at, index: i})
+			}
+		}
+
+		if len(dues) == 0 {
+			continue
+		}
+
+		// when several schedules fire for the same service the latest one wins
+		sort.Slice(dues, func(i, j int) bool { return dues[i].at.Before(dues[j].at) })
+
+		for _, d := range dues {
+			sc := sss[d.index]
+
+			fmt.Printf("ns=k8s at=service.schedule app=%s service=%s schedule=%q count=%d\n", a.Name, sc.Service, sc.Schedule, sc.Count)
+
+			if err := p.ServiceUpdate(a.Name, sc.Service, structs.ServiceUpdateOptions{Count: options.Int(sc.Count)}); err != nil {
+				fmt.Printf("ns=k8s at=service.schedule app=%s service=%s error=%q\n", a.Name, sc.Service, err)
+			}
+
+			sss[d.index].Applied = now
+		}
+
+		if err := p.serviceSchedulesSave(a.Name, sss); err != nil {
+			fmt.Printf("ns=k8s at=service.schedule app=%s error=%q\n", a.Name, err)
+		}
+	}
 
 	return nil
 }
@@ -60,3 +269,30 @@ func (p *Provider) Workers() error {
 //     }
 //   }
 // }
+
+// workerTimerZones follows daylight saving changes for timers that run in a time zone
+func (p *Provider) workerTimerZones() error {
+	// timers run in their own time zone
+	if p.timerTimeZones() {
+		return nil
+	}
+
+	as, err := p.AppList()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	for _, a := range as {
+		if a.Status == "deleting" {
+			continue
+		}
+
+		if err := p.timerZones(a.Name, now); err != nil {
+			fmt.Printf("ns=k8s at=timer.zones app=%s error=%q\n", a.Name, err)
+		}
+	}
+
+	return nil
+}
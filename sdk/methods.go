@@ -118,6 +118,26 @@ func (c *Client) AppUpdate(name string, opts structs.AppUpdateOptions) error {
 	return err
 }
 
+func (c *Client) AuditList(opts structs.AuditListOptions) (structs.AuditEntries, error) {
+	var err error
+
+	ro, err := stdsdk.MarshalOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var v structs.AuditEntries
+
+	err = c.Get(fmt.Sprintf("/system/audit"), ro, &v)
+
+	return v, err
+}
+
+func (c *Client) AuditRecord(entry structs.AuditEntry) error {
+	err := fmt.Errorf("not available via api")
+	return err
+}
+
 func (c *Client) BalancerList(app string) (structs.Balancers, error) {
 	var err error
 
@@ -320,6 +340,18 @@ func (c *Client) CertificateList() (structs.Certificates, error) {
 	return v, err
 }
 
+func (c *Client) EnvironmentSecretList(app string) (structs.EnvironmentSecrets, error) {
+	var err error
+
+	ro := stdsdk.RequestOptions{Headers: stdsdk.Headers{}, Params: stdsdk.Params{}, Query: stdsdk.Query{}}
+
+	var v structs.EnvironmentSecrets
+
+	err = c.Get(fmt.Sprintf("/apps/%s/environment/secrets", app), ro, &v)
+
+	return v, err
+}
+
 func (c *Client) EventSend(action string, opts structs.EventSendOptions) error {
 	var err error
 
@@ -335,6 +367,20 @@ func (c *Client) EventSend(action string, opts structs.EventSendOptions) error {
 	return err
 }
 
+func (c *Client) FilesChecksums(app string, pid string, path string) (structs.FileChecksums, error) {
+	var err error
+
+	ro := stdsdk.RequestOptions{Headers: stdsdk.Headers{}, Params: stdsdk.Params{}, Query: stdsdk.Query{}}
+
+	ro.Query["path"] = path
+
+	var v structs.FileChecksums
+
+	err = c.Get(fmt.Sprintf("/apps/%s/processes/%s/files/checksums", app, pid), ro, &v)
+
+	return v, err
+}
+
 func (c *Client) FilesDelete(app string, pid string, files []string) error {
 	var err error
 
@@ -505,6 +551,26 @@ func (c *Client) ObjectStore(app string, key string, r io.Reader, opts structs.O
 	return v, err
 }
 
+func (c *Client) ProcessDebug(app string, pid string, rw io.ReadWriter, opts structs.ProcessDebugOptions) (int, error) {
+	var err error
+
+	ro, err := stdsdk.MarshalOptions(opts)
+	if err != nil {
+		return 0, err
+	}
+
+	ro.Body = rw
+
+	var v int
+
+	v, err = c.WebsocketExit(fmt.Sprintf("/apps/%s/processes/%s/debug", app, pid), ro, rw)
+	if err != nil {
+		return 0, err
+	}
+
+	return v, err
+}
+
 func (c *Client) ProcessExec(app string, pid string, command string, rw io.ReadWriter, opts structs.ProcessExecOptions) (int, error) {
 	var err error
 
@@ -526,6 +592,28 @@ func (c *Client) ProcessExec(app string, pid string, command string, rw io.ReadW
 	return v, err
 }
 
+func (c *Client) ProcessForward(app string, pid string, port int, rw io.ReadWriter, opts structs.ProcessForwardOptions) error {
+	var err error
+
+	ro, err := stdsdk.MarshalOptions(opts)
+	if err != nil {
+		return err
+	}
+
+	ro.Body = rw
+
+	r, err := c.Websocket(fmt.Sprintf("/apps/%s/processes/%s/forward/%d", app, pid, port), ro)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(rw, r); err != nil {
+		return err
+	}
+
+	return err
+}
+
 func (c *Client) ProcessGet(app string, pid string) (*structs.Process, error) {
 	var err error
 
@@ -673,6 +761,21 @@ func (c *Client) ReleaseCreate(app string, opts structs.ReleaseCreateOptions) (*
 	return v, err
 }
 
+func (c *Client) ReleaseDiff(app string, id string, opts structs.ReleaseDiffOptions) (*structs.ReleaseDiff, error) {
+	var err error
+
+	ro, err := stdsdk.MarshalOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var v *structs.ReleaseDiff
+
+	err = c.Get(fmt.Sprintf("/apps/%s/releases/%s/diff", app, id), ro, &v)
+
+	return v, err
+}
+
 func (c *Client) ReleaseGet(app string, id string) (*structs.Release, error) {
 	var err error
 
@@ -713,6 +816,18 @@ func (c *Client) ReleasePromote(app string, id string, opts structs.ReleasePromo
 	return err
 }
 
+func (c *Client) ResourceBackupList(app string, name string) (structs.ResourceBackups, error) {
+	var err error
+
+	ro := stdsdk.RequestOptions{Headers: stdsdk.Headers{}, Params: stdsdk.Params{}, Query: stdsdk.Query{}}
+
+	var v structs.ResourceBackups
+
+	err = c.Get(fmt.Sprintf("/apps/%s/resources/%s/backups", app, name), ro, &v)
+
+	return v, err
+}
+
 func (c *Client) ResourceConsole(app string, name string, rw io.ReadWriter, opts structs.ResourceConsoleOptions) error {
 	var err error
 
@@ -788,6 +903,53 @@ func (c *Client) ResourceList(app string) (structs.Resources, error) {
 	return v, err
 }
 
+func (c *Client) ResourceRestore(app string, name string, id string) error {
+	var err error
+
+	ro := stdsdk.RequestOptions{Headers: stdsdk.Headers{}, Params: stdsdk.Params{}, Query: stdsdk.Query{}}
+
+	err = c.Post(fmt.Sprintf("/apps/%s/resources/%s/backups/%s/restore", app, name, id), ro, nil)
+
+	return err
+}
+
+func (c *Client) ResourceUpdate(app string, name string, opts structs.ResourceUpdateOptions) (structs.ResourceChanges, error) {
+	var err error
+
+	ro, err := stdsdk.MarshalOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var v structs.ResourceChanges
+
+	err = c.Put(fmt.Sprintf("/apps/%s/resources/%s", app, name), ro, &v)
+
+	return v, err
+}
+
+func (c *Client) ServiceForward(app string, name string, port int, rw io.ReadWriter, opts structs.ServiceForwardOptions) error {
+	var err error
+
+	ro, err := stdsdk.MarshalOptions(opts)
+	if err != nil {
+		return err
+	}
+
+	ro.Body = rw
+
+	r, err := c.Websocket(fmt.Sprintf("/apps/%s/services/%s/forward/%d", app, name, port), ro)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(rw, r); err != nil {
+		return err
+	}
+
+	return err
+}
+
 func (c *Client) ServiceList(app string) (structs.Services, error) {
 	var err error
 
@@ -810,6 +972,33 @@ func (c *Client) ServiceRestart(app string, name string) error {
 	return err
 }
 
+func (c *Client) ServiceScheduleDelete(app string, name string, schedule string) error {
+	var err error
+
+	ro := stdsdk.RequestOptions{Headers: stdsdk.Headers{}, Params: stdsdk.Params{}, Query: stdsdk.Query{}}
+
+	ro.Query["schedule"] = schedule
+
+	err = c.Delete(fmt.Sprintf("/apps/%s/services/%s/schedules", app, name), ro, nil)
+
+	return err
+}
+
+func (c *Client) ServiceScheduleSet(app string, name string, schedule string, opts structs.ServiceScheduleSetOptions) error {
+	var err error
+
+	ro, err := stdsdk.MarshalOptions(opts)
+	if err != nil {
+		return err
+	}
+
+	ro.Params["schedule"] = schedule
+
+	err = c.Post(fmt.Sprintf("/apps/%s/services/%s/schedules", app, name), ro, nil)
+
+	return err
+}
+
 func (c *Client) ServiceUpdate(app string, name string, opts structs.ServiceUpdateOptions) error {
 	var err error
 
@@ -1029,6 +1218,141 @@ func (c *Client) SystemUpdate(opts structs.SystemUpdateOptions) error {
 	return err
 }
 
+func (c *Client) TimerList(app string) (structs.Timers, error) {
+	var err error
+
+	ro := stdsdk.RequestOptions{Headers: stdsdk.Headers{}, Params: stdsdk.Params{}, Query: stdsdk.Query{}}
+
+	var v structs.Timers
+
+	err = c.Get(fmt.Sprintf("/apps/%s/timers", app), ro, &v)
+
+	return v, err
+}
+
+func (c *Client) TimerResume(app string, name string) error {
+	var err error
+
+	ro := stdsdk.RequestOptions{Headers: stdsdk.Headers{}, Params: stdsdk.Params{}, Query: stdsdk.Query{}}
+
+	err = c.Post(fmt.Sprintf("/apps/%s/timers/%s/resume", app, name), ro, nil)
+
+	return err
+}
+
+func (c *Client) TimerRun(app string, name string) error {
+	var err error
+
+	ro := stdsdk.RequestOptions{Headers: stdsdk.Headers{}, Params: stdsdk.Params{}, Query: stdsdk.Query{}}
+
+	err = c.Post(fmt.Sprintf("/apps/%s/timers/%s/run", app, name), ro, nil)
+
+	return err
+}
+
+func (c *Client) TimerSuspend(app string, name string) error {
+	var err error
+
+	ro := stdsdk.RequestOptions{Headers: stdsdk.Headers{}, Params: stdsdk.Params{}, Query: stdsdk.Query{}}
+
+	err = c.Post(fmt.Sprintf("/apps/%s/timers/%s/suspend", app, name), ro, nil)
+
+	return err
+}
+
+func (c *Client) TokenAuthenticate(secret string) (*structs.Token, error) {
+	err := fmt.Errorf("not available via api")
+	return nil, err
+}
+
+func (c *Client) TokenCreate(name string, opts structs.TokenCreateOptions) (*structs.Token, error) {
+	var err error
+
+	ro, err := stdsdk.MarshalOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ro.Params["name"] = name
+
+	var v *structs.Token
+
+	err = c.Post(fmt.Sprintf("/system/tokens"), ro, &v)
+
+	return v, err
+}
+
+func (c *Client) TokenDelete(id string) error {
+	var err error
+
+	ro := stdsdk.RequestOptions{Headers: stdsdk.Headers{}, Params: stdsdk.Params{}, Query: stdsdk.Query{}}
+
+	err = c.Delete(fmt.Sprintf("/system/tokens/%s", id), ro, nil)
+
+	return err
+}
+
+func (c *Client) TokenList() (structs.Tokens, error) {
+	var err error
+
+	ro := stdsdk.RequestOptions{Headers: stdsdk.Headers{}, Params: stdsdk.Params{}, Query: stdsdk.Query{}}
+
+	var v structs.Tokens
+
+	err = c.Get(fmt.Sprintf("/system/tokens"), ro, &v)
+
+	return v, err
+}
+
+func (c *Client) VolumeList(app string) (structs.Volumes, error) {
+	var err error
+
+	ro := stdsdk.RequestOptions{Headers: stdsdk.Headers{}, Params: stdsdk.Params{}, Query: stdsdk.Query{}}
+
+	var v structs.Volumes
+
+	err = c.Get(fmt.Sprintf("/apps/%s/volumes", app), ro, &v)
+
+	return v, err
+}
+
+func (c *Client) VolumeRestore(app string, name string, id string, opts structs.VolumeRestoreOptions) error {
+	var err error
+
+	ro, err := stdsdk.MarshalOptions(opts)
+	if err != nil {
+		return err
+	}
+
+	err = c.Post(fmt.Sprintf("/apps/%s/volumes/%s/snapshots/%s/restore", app, name, id), ro, nil)
+
+	return err
+}
+
+func (c *Client) VolumeSnapshot(app string, name string) (*structs.VolumeSnapshot, error) {
+	var err error
+
+	ro := stdsdk.RequestOptions{Headers: stdsdk.Headers{}, Params: stdsdk.Params{}, Query: stdsdk.Query{}}
+
+	var v *structs.VolumeSnapshot
+
+	err = c.Post(fmt.Sprintf("/apps/%s/volumes/%s/snapshots", app, name), ro, &v)
+
+	return v, err
+}
+
+func (c *Client) VolumeSnapshotList(app string, name string) (structs.VolumeSnapshots, error) {
+	var err error
+
+	ro := stdsdk.RequestOptions{Headers: stdsdk.Headers{}, Params: stdsdk.Params{}, Query: stdsdk.Query{}}
+
+	var v structs.VolumeSnapshots
+
+	err = c.Get(fmt.Sprintf("/apps/%s/volumes/%s/snapshots", app, name), ro, &v)
+
+	return v, err
+}
+
 func (c *Client) Workers() error {
 	err := fmt.Errorf("not available via api")
 	return err
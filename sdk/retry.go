@@ -0,0 +1,74 @@
+package sdk
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/convox/stdsdk"
+)
+
+var (
+	// RateLimitRetries is the number of times a request that was rate limited by the rack will be retried
+	RateLimitRetries = 5
+
+	// RateLimitBackoff is the initial delay between retries when the rack does not send Retry-After
+	RateLimitBackoff = 1 * time.Second
+)
+
+func init() {
+	stdsdk.DefaultClient.Transport = &retryTransport{RoundTripper: stdsdk.DefaultClient.Transport}
+}
+
+// retryTransport retries requests that receive a 429 response
+type retryTransport struct {
+	http.RoundTripper
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		res, err := t.RoundTripper.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if res.StatusCode != http.StatusTooManyRequests || attempt >= RateLimitRetries {
+			return res, nil
+		}
+
+		// streamed request bodies can not be replayed
+		if req.Body != nil && req.GetBody == nil {
+			return res, nil
+		}
+
+		res.Body.Close()
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(retryDelay(res, attempt)):
+		}
+
+		req = req.WithContext(req.Context())
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			req.Body = body
+		}
+	}
+}
+
+func retryDelay(res *http.Response, attempt int) time.Duration {
+	if s, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second
+	}
+
+	d := RateLimitBackoff * time.Duration(1<<uint(attempt))
+
+	return d + time.Duration(rand.Int63n(int64(d)/2+1))
+}
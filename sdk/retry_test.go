@@ -0,0 +1,64 @@
+package sdk_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/convox/convox/sdk"
+	"github.com/convox/stdsdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitRetry(t *testing.T) {
+	attempts := 0
+
+	ht := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "web", r.Form.Get("service"))
+
+		if attempts < 3 {
+			w.Header().Set("Retry-After", "0")
+			http.Error(w, "rate limit exceeded for write requests", 429)
+			return
+		}
+
+		fmt.Fprintf(w, `{"id":"P1"}`)
+	}))
+	defer ht.Close()
+
+	c, err := sdk.New(ht.URL)
+	require.NoError(t, err)
+
+	var out map[string]string
+
+	err = c.Post("/test", stdsdk.RequestOptions{Params: stdsdk.Params{"service": "web"}}, &out)
+	require.NoError(t, err)
+	require.Equal(t, 3, attempts)
+	require.Equal(t, "P1", out["id"])
+}
+
+func TestRateLimitRetryExhausted(t *testing.T) {
+	retries := sdk.RateLimitRetries
+	sdk.RateLimitRetries = 1
+	defer func() { sdk.RateLimitRetries = retries }()
+
+	attempts := 0
+
+	ht := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "0")
+		http.Error(w, "rate limit exceeded for read requests", 429)
+	}))
+	defer ht.Close()
+
+	c, err := sdk.New(ht.URL)
+	require.NoError(t, err)
+
+	_, err = c.AppList()
+	require.EqualError(t, err, "rate limit exceeded for read requests")
+	require.Equal(t, 2, attempts)
+}
@@ -24,6 +24,24 @@ resource "aws_dynamodb_table" "hosts" {
   tags = local.tags
 }
 
+resource "aws_dynamodb_table" "rates" {
+  name         = "${var.name}-rates"
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "key"
+
+  attribute {
+    name = "key"
+    type = "S"
+  }
+
+  ttl {
+    attribute_name = "expires"
+    enabled        = true
+  }
+
+  tags = local.tags
+}
+
 resource "aws_dynamodb_table" "targets" {
   name         = "${var.name}-targets"
   billing_mode = "PAY_PER_REQUEST"
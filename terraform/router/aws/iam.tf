@@ -41,6 +41,13 @@ data "aws_iam_policy_document" "router" {
     ]
   }
 
+  statement {
+    resources = [aws_dynamodb_table.rates.arn]
+    actions = [
+      "dynamodb:UpdateItem",
+    ]
+  }
+
   statement {
     resources = [aws_dynamodb_table.targets.arn]
     actions = [
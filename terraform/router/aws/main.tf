@@ -45,6 +45,7 @@ module "k8s" {
     STORAGE        = "dynamodb"
     ROUTER_CACHE   = aws_dynamodb_table.cache.name
     ROUTER_HOSTS   = aws_dynamodb_table.hosts.name
+    ROUTER_RATES   = aws_dynamodb_table.rates.name
     ROUTER_TARGETS = aws_dynamodb_table.targets.name
   }
 }
@@ -40,6 +40,7 @@ module "k8s" {
     REDIS_ADDR   = "${azurerm_redis_cache.cache.hostname}:${azurerm_redis_cache.cache.ssl_port}"
     REDIS_AUTH   = azurerm_redis_cache.cache.primary_access_key
     REDIS_SECURE = "true"
+    STORAGE      = "redis"
   }
 }
 
@@ -50,7 +51,8 @@ resource "kubernetes_service" "router" {
   }
 
   spec {
-    type = "LoadBalancer"
+    external_traffic_policy = "Local"
+    type                    = "LoadBalancer"
 
     port {
       name        = "http"
@@ -36,6 +36,7 @@ module "k8s" {
     REDIS_ADDR   = "${digitalocean_database_cluster.cache.private_host}:${digitalocean_database_cluster.cache.port}"
     REDIS_AUTH   = digitalocean_database_cluster.cache.password
     REDIS_SECURE = "true"
+    STORAGE      = "redis"
   }
 }
 
@@ -46,7 +47,8 @@ resource "kubernetes_service" "router" {
   }
 
   spec {
-    type = "LoadBalancer"
+    external_traffic_policy = "Local"
+    type                    = "LoadBalancer"
 
     port {
       name        = "http"
@@ -34,6 +34,7 @@ module "k8s" {
   env = {
     CACHE      = "redis"
     REDIS_ADDR = "${google_redis_instance.cache.host}:${google_redis_instance.cache.port}"
+    STORAGE    = "redis"
   }
 }
 
@@ -44,7 +45,8 @@ resource "kubernetes_service" "router" {
   }
 
   spec {
-    type = "LoadBalancer"
+    external_traffic_policy = "Local"
+    type                    = "LoadBalancer"
 
     port {
       name        = "http"